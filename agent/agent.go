@@ -5,7 +5,10 @@ package agent
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -680,6 +683,31 @@ func (c *configInternal) StateServingInfo() (params.StateServingInfo, bool) {
 	return *c.servingInfo, true
 }
 
+// NewSecretsKey returns a new controller secrets key, encoded
+// for the state serving info of the controllers' agent config.
+func NewSecretsKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SecretsKey returns the controller key, held in the state serving info
+// of a controller's agent config, which wraps the keys encrypting each
+// model's secrets. It returns nil if the agent config holds no key.
+func SecretsKey(config Config) ([]byte, error) {
+	info, ok := config.StateServingInfo()
+	if !ok || info.SecretsKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(info.SecretsKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding secrets key")
+	}
+	return key, nil
+}
+
 func (c *configInternal) SetStateServingInfo(info params.StateServingInfo) {
 	c.servingInfo = &info
 	if c.statePassword == "" && c.apiDetails != nil {
@@ -84,6 +84,21 @@ func InitializeState(
 	if !ok {
 		return nil, nil, errors.Errorf("state serving information not available")
 	}
+	if servingInfo.SecretsKey == "" {
+		// The secrets key is only held in the agent config of
+		// the controllers, so it is generated here rather
+		// than stored with the rest of the controller.
+		secretsKey, err := agent.NewSecretsKey()
+		if err != nil {
+			return nil, nil, errors.Annotate(err, "generating secrets key")
+		}
+		servingInfo.SecretsKey = secretsKey
+		c.SetStateServingInfo(servingInfo)
+	}
+	secretsKey, err := agent.SecretsKey(c)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	// N.B. no users are set up when we're initializing the state,
 	// so don't use any tag or password when opening it.
 	info, ok := c.MongoInfo()
@@ -137,6 +152,7 @@ func InitializeState(
 		MongoSession:              session,
 		AdminPassword:             info.Password,
 		NewPolicy:                 newPolicy,
+		SecretsKey:                secretsKey,
 	})
 	if err != nil {
 		return nil, nil, errors.Errorf("failed to initialize state: %v", err)
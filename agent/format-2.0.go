@@ -57,6 +57,7 @@ type format_2_0Serialization struct {
 	StatePort          int    `yaml:"stateport,omitempty"`
 	SharedSecret       string `yaml:"sharedsecret,omitempty"`
 	SystemIdentity     string `yaml:"systemidentity,omitempty"`
+	SecretsKey         string `yaml:"secretskey,omitempty"`
 	MongoVersion       string `yaml:"mongoversion,omitempty"`
 	MongoMemoryProfile string `yaml:"mongomemoryprofile,omitempty"`
 }
@@ -122,6 +123,7 @@ func (formatter_2_0) unmarshal(data []byte) (*configInternal, error) {
 			StatePort:         format.StatePort,
 			SharedSecret:      format.SharedSecret,
 			SystemIdentity:    format.SystemIdentity,
+			SecretsKey:        format.SecretsKey,
 		}
 		// If private key is not present, infer it from the ports in the state addresses.
 		if config.servingInfo.StatePort == 0 {
@@ -184,6 +186,7 @@ func (formatter_2_0) marshal(config *configInternal) ([]byte, error) {
 		format.StatePort = config.servingInfo.StatePort
 		format.SharedSecret = config.servingInfo.SharedSecret
 		format.SystemIdentity = config.servingInfo.SystemIdentity
+		format.SecretsKey = config.servingInfo.SecretsKey
 		format.StatePassword = config.statePassword
 	}
 	if config.apiDetails != nil {
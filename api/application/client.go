@@ -932,3 +932,106 @@ func (c *Client) GetLXDProfileUpgradeMessages(applicationName string, watcherId
 	}
 	return messages, nil
 }
+
+// ApplicationsInfo retrieves detailed information about the given
+// applications, including their endpoints, bindings, relations,
+// resources and recent status history.
+func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("ApplicationsInfo")
+	}
+	all := make([]params.Entity, len(applications))
+	for i, one := range applications {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.ApplicationInfoResults
+	if err := c.facade.FacadeCall("ApplicationsInfo", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen, argsLen := len(out.Results), len(applications); resultsLen != argsLen {
+		return nil, errors.Errorf("expected %d results, got %d", argsLen, resultsLen)
+	}
+	return out.Results, nil
+}
+
+// UnitsInfo retrieves detailed information about the given units,
+// including the relation settings of the units they are related to.
+func (c *Client) UnitsInfo(units []names.UnitTag) ([]params.UnitInfoResult, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("UnitsInfo")
+	}
+	all := make([]params.Entity, len(units))
+	for i, one := range units {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.UnitInfoResults
+	if err := c.facade.FacadeCall("UnitsInfo", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen, argsLen := len(out.Results), len(units); resultsLen != argsLen {
+		return nil, errors.Errorf("expected %d results, got %d", argsLen, resultsLen)
+	}
+	return out.Results, nil
+}
+
+// SetBindings changes the spaces the given application's endpoints are
+// bound to. The empty endpoint name sets the default space. Unless force
+// is true, the machines hosting the application's units must already be
+// connected to the new spaces.
+func (c *Client) SetBindings(application string, bindings map[string]string, force bool) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("SetBindings")
+	}
+	args := params.ApplicationSetBindingsArgs{
+		Args: []params.ApplicationSetBindings{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Bindings:       bindings,
+			Force:          force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetBindings", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RolloutStatus returns the progress of replacing the pods of the
+// given Kubernetes application after its pod spec changed.
+func (c *Client) RolloutStatus(application string) (*params.RolloutStatus, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("RolloutStatus")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.RolloutStatusResults
+	if err := c.facade.FacadeCall("RolloutStatus", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// AbortRollout returns the pods of the given Kubernetes application to
+// their previous pod spec, stopping any rollout in progress.
+func (c *Client) AbortRollout(application string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("AbortRollout")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AbortRollout", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
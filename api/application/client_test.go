@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	basetesting "github.com/juju/juju/api/base/testing"
@@ -1261,3 +1262,175 @@ func (s *applicationSuite) TestGetLXDProfileUpgradeMessagesError(c *gc.C) {
 	_, err := client.GetLXDProfileUpgradeMessages("foo", "xxx-aaa-yyy-ccc")
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
+
+func (s *applicationSuite) TestApplicationsInfo(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ApplicationsInfo")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			result, ok := response.(*params.ApplicationInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ApplicationInfoResult{{
+				Result: &params.ApplicationResult{Tag: "application-foo", Leader: "foo/0"},
+			}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.ApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag("foo")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ApplicationInfoResult{{
+		Result: &params.ApplicationResult{Tag: "application-foo", Leader: "foo/0"},
+	}})
+}
+
+func (s *applicationSuite) TestApplicationsInfoNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.ApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag("foo")})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "UnitsInfo")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-foo-0"}, {Tag: "unit-foo-1"}},
+			})
+			result, ok := response.(*params.UnitInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.UnitInfoResult{
+				{Result: &params.UnitResult{Tag: "unit-foo-0", Leader: true}},
+				{Error: &params.Error{Message: "boom"}},
+			}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.UnitsInfo([]names.UnitTag{
+		names.NewUnitTag("foo/0"), names.NewUnitTag("foo/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.UnitInfoResult{
+		{Result: &params.UnitResult{Tag: "unit-foo-0", Leader: true}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *applicationSuite) TestSetBindings(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetBindings")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetBindingsArgs{
+				Args: []params.ApplicationSetBindings{{
+					ApplicationTag: "application-foo",
+					Bindings:       map[string]string{"": "alpha", "db": "internal"},
+					Force:          true,
+				}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetBindings("foo", map[string]string{"": "alpha", "db": "internal"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestSetBindingsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetBindings("foo", map[string]string{"db": "internal"}, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestRolloutStatus(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "RolloutStatus")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			result, ok := response.(*params.RolloutStatusResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.RolloutStatusResult{{
+				Result: &params.RolloutStatus{Desired: 2, Updated: 1, Ready: 2, Message: "1 of 2 pods updated"},
+			}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	rollout, err := client.RolloutStatus("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, &params.RolloutStatus{
+		Desired: 2, Updated: 1, Ready: 2, Message: "1 of 2 pods updated",
+	})
+}
+
+func (s *applicationSuite) TestRolloutStatusError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			result, ok := response.(*params.RolloutStatusResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.RolloutStatusResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.RolloutStatus("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestAbortRollout(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "AbortRollout")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.AbortRollout("foo")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestRolloutNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.RolloutStatus("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.AbortRollout("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
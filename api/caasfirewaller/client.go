@@ -18,6 +18,7 @@ import (
 
 // Client allows access to the CAAS firewaller API endpoint.
 type Client struct {
+	*common.ModelWatcher
 	facade base.FacadeCaller
 }
 
@@ -25,7 +26,8 @@ type Client struct {
 func NewClient(caller base.APICaller) *Client {
 	facadeCaller := base.NewFacadeCaller(caller, "CAASFirewaller")
 	return &Client{
-		facade: facadeCaller,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
 	}
 }
 
@@ -70,6 +72,16 @@ func (c *Client) WatchApplication(appName string) (watcher.NotifyWatcher, error)
 	return common.Watch(c.facade, "Watch", appTag)
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the config of the application in the current model.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.Watch(c.facade, "WatchApplicationsConfig", appTag)
+}
+
 // Life returns the lifecycle state for the specified CAAS application
 // in the current model.
 func (c *Client) Life(appName string) (life.Value, error) {
@@ -130,6 +142,28 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// RelatedApplications returns the names of the other applications
+// the specified CAAS application in the current model is related to.
+func (c *Client) RelatedApplications(appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("RelatedApplications", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
@@ -103,6 +103,30 @@ func (c *Client) WatchApplicationScale(application string) (watcher.NotifyWatche
 	return w, nil
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the config of the specified CAAS application in the
+// current model.
+func (c *Client) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	applicationTag, err := applicationTag(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(applicationTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
 // ApplicationScale returns the scale for the specified application.
 func (c *Client) ApplicationScale(applicationName string) (int, error) {
 	var results params.IntResults
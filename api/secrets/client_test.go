@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	now := time.Now()
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSecrets")
+			c.Check(a, gc.IsNil)
+			c.Assert(response, gc.FitsTypeOf, &params.ListSecretResults{})
+			*(response.(*params.ListSecretResults)) = params.ListSecretResults{
+				Results: []params.SecretDetails{{
+					ID:         "mysql/root-password",
+					Owner:      "mysql",
+					Name:       "root-password",
+					Revision:   2,
+					CreateTime: now,
+					UpdateTime: now,
+				}},
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.SecretDetails{{
+		ID:         "mysql/root-password",
+		Owner:      "mysql",
+		Name:       "root-password",
+		Revision:   2,
+		CreateTime: now,
+		UpdateTime: now,
+	}})
+}
@@ -152,6 +152,66 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ApplicationSettings returns a Settings which allows access to the
+// application-level settings of the unit's application within the
+// relation. Only the application's leader may read or change them.
+func (ru *RelationUnit) ApplicationSettings() (*Settings, error) {
+	if ru.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("application relation settings")
+	}
+	var results params.SettingsResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("ReadLocalApplicationSettings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return newApplicationSettings(ru.st, ru.relation.tag.String(), ru.unit.tag.String(), result.Settings), nil
+}
+
+// ReadApplicationSettings returns a map holding the application-level
+// settings of the related application with the supplied name within
+// this relation.
+func (ru *RelationUnit) ReadApplicationSettings(appName string) (params.Settings, error) {
+	if !names.IsValidApplication(appName) {
+		return nil, errors.Errorf("%q is not a valid application", appName)
+	}
+	if ru.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("application relation settings")
+	}
+	var results params.SettingsResults
+	args := params.RelationUnitPairs{
+		RelationUnitPairs: []params.RelationUnitPair{{
+			Relation:   ru.relation.tag.String(),
+			LocalUnit:  ru.unit.tag.String(),
+			RemoteUnit: names.NewApplicationTag(appName).String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("ReadRemoteSettings", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Settings, nil
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
@@ -0,0 +1,147 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// SaveSecret creates or updates the named secret owned by the unit's
+// application, returning the secret's id. Only the leader may save
+// secrets.
+func (u *Unit) SaveSecret(name, value, description string, rotateInterval time.Duration) (string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return "", errors.NotImplementedf("secrets")
+	}
+	var results params.StringResults
+	args := params.SaveSecretArgs{
+		Args: []params.SaveSecretArg{{
+			UnitTag:        u.tag.String(),
+			Name:           name,
+			Value:          value,
+			Description:    description,
+			RotateInterval: rotateInterval,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("SaveSecrets", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	return oneStringResult(results)
+}
+
+// SecretValue returns the value of the secret with the given id, if the
+// unit's application may read it.
+func (u *Unit) SecretValue(id string) (string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return "", errors.NotImplementedf("secrets")
+	}
+	var results params.StringResults
+	args := params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{UnitTag: u.tag.String(), ID: id}},
+	}
+	if err := u.st.facade.FacadeCall("GetSecretValues", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	return oneStringResult(results)
+}
+
+// GrantSecret gives the remote application on the given relation access
+// to a secret owned by the unit's application. Only the leader may grant
+// access to secrets.
+func (u *Unit) GrantSecret(id string, relation names.RelationTag) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("secrets")
+	}
+	var results params.ErrorResults
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{UnitTag: u.tag.String(), ID: id, RelationTag: relation.String()}},
+	}
+	if err := u.st.facade.FacadeCall("GrantSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecretRotations returns a watcher that notifies when the secrets
+// owned by the unit's application change.
+func (u *Unit) WatchSecretRotations() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("secrets")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("WatchSecretRotations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result), nil
+}
+
+// SecretRotations returns when each secret owned by the unit's
+// application is next due to be rotated, keyed by secret id.
+func (u *Unit) SecretRotations() (map[string]time.Time, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("secrets")
+	}
+	var results params.SecretRotationsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("SecretRotations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rotations := make(map[string]time.Time)
+	for _, rotation := range result.Rotations {
+		rotations[rotation.ID] = rotation.NextRotateTime
+	}
+	return rotations, nil
+}
+
+// SecretRotated records that the unit has rotated a secret owned by its
+// application.
+func (u *Unit) SecretRotated(id string) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("secrets")
+	}
+	var results params.ErrorResults
+	args := params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{UnitTag: u.tag.String(), ID: id}},
+	}
+	if err := u.st.facade.FacadeCall("SecretsRotated", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func oneStringResult(results params.StringResults) (string, error) {
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
@@ -10,12 +10,17 @@ import (
 // This module implements a subset of the interface provided by
 // state.Settings, as needed by the uniter API.
 
-// Settings manages changes to unit settings in a relation.
+// Settings manages changes to unit settings in a relation, or to the
+// application-level settings of the unit's application.
 type Settings struct {
 	st          *State
 	relationTag string
 	unitTag     string
 	settings    params.Settings
+
+	// application is true if the settings are the application-level
+	// settings of the unit's application.
+	application bool
 }
 
 func newSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
@@ -30,6 +35,12 @@ func newSettings(st *State, relationTag, unitTag string, settings params.Setting
 	}
 }
 
+func newApplicationSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
+	s := newSettings(st, relationTag, unitTag, settings)
+	s.application = true
+	return s
+}
+
 // Map returns all keys and values of the node.
 //
 // TODO(dimitern): This differes from state.Settings.Map() - it does
@@ -80,13 +91,21 @@ func (s *Settings) Write() error {
 		settingsCopy[k] = v
 	}
 
+	arg := params.RelationUnitSettings{
+		Relation: s.relationTag,
+		Unit:     s.unitTag,
+	}
+	if s.application {
+		if len(settingsCopy) == 0 {
+			return nil
+		}
+		arg.ApplicationSettings = settingsCopy
+	} else {
+		arg.Settings = settingsCopy
+	}
 	var result params.ErrorResults
 	args := params.RelationUnitsSettings{
-		RelationUnits: []params.RelationUnitSettings{{
-			Relation: s.relationTag,
-			Unit:     s.unitTag,
-			Settings: settingsCopy,
-		}},
+		RelationUnits: []params.RelationUnitSettings{arg},
 	}
 	err := s.st.facade.FacadeCall("UpdateSettings", args, &result)
 	if err != nil {
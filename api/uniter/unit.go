@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
@@ -92,6 +95,26 @@ func (u *Unit) SetUnitStatus(unitStatus status.Status, info string, data map[str
 	return result.OneError()
 }
 
+// SetUnitHealthStatus sets the status of the unit while its workload
+// health probes are failing. Unlike SetUnitStatus, it accepts the error
+// status.
+func (u *Unit) SetUnitHealthStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("setting unit health status")
+	}
+	var result params.ErrorResults
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data},
+		},
+	}
+	err := u.st.facade.FacadeCall("SetUnitHealthStatus", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // UnitStatus gets the status details of the unit.
 func (u *Unit) UnitStatus() (params.StatusResult, error) {
 	var results params.StatusResults
@@ -253,6 +276,85 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	return charm.Settings(result.Settings), nil
 }
 
+// HookLimits returns the resource limits to apply to the unit's hooks.
+// Controllers that predate hook limits report no limits.
+func (u *Unit) HookLimits() (application.HookLimits, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return application.HookLimits{}, nil
+	}
+	var results params.HookLimitsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookLimits", args, &results)
+	if err != nil {
+		return application.HookLimits{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return application.HookLimits{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return application.HookLimits{}, result.Error
+	}
+	return application.HookLimits{
+		Timeout:    result.Result.Timeout,
+		MemoryMB:   result.Result.MemoryMB,
+		CPUPercent: result.Result.CPUPercent,
+	}, nil
+}
+
+// HookRecording returns which of the unit's hooks should be recorded
+// for offline replay: "failed", "all", or "" for none. Controllers that
+// predate hook recording report that no hooks are recorded.
+func (u *Unit) HookRecording() (string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return "", nil
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookRecording", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// UpdateStatusHookInterval returns the interval at which the unit should
+// run its update-status hook. The unit's application may override the
+// model's interval, or disable update-status by setting it to zero.
+// Controllers that predate the override report the model's interval.
+func (u *Unit) UpdateStatusHookInterval() (time.Duration, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return u.st.UpdateStatusHookInterval()
+	}
+	var results params.UpdateStatusHookIntervalResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpdateStatusHookInterval", args, &results)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Interval, nil
+}
+
 // ApplicationName returns the application name.
 func (u *Unit) ApplicationName() string {
 	application, err := names.UnitApplication(u.Name())
@@ -550,6 +652,24 @@ func (u *Unit) ClearResolved() error {
 	return result.OneError()
 }
 
+// SetDeferredEvents records with the controller the custom events that
+// the unit's hooks have asked to be delivered to later hooks. Controllers
+// that predate deferred hooks do not record them.
+func (u *Unit) SetDeferredEvents(events []params.DeferredEvent) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil
+	}
+	var result params.ErrorResults
+	args := params.SetDeferredEventsArgs{
+		Entities: []params.EntityDeferredEvents{{Tag: u.tag.String(), Events: events}},
+	}
+	err := u.st.facade.FacadeCall("SetDeferredEvents", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -127,6 +127,19 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(agentStatusInfo.Data, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestSetUnitHealthStatus(c *gc.C) {
+	err := s.apiUnit.SetUnitStatus(status.Error, "broken", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set invalid status "error"`)
+
+	err = s.apiUnit.SetUnitHealthStatus(status.Error, `health probe "web" failed`, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Error)
+	c.Assert(statusInfo.Message, gc.Equals, `health probe "web" failed`)
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -460,6 +473,121 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestHookLimits(c *gc.C) {
+	limits, err := s.apiUnit.HookLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits.IsZero(), jc.IsTrue)
+
+	err = s.wordpressApplication.UpdateApplicationConfig(application.ConfigAttributes{
+		"hook-timeout":      "2m",
+		"hook-memory-limit": "1G",
+	}, nil, environschema.Fields{
+		"hook-timeout":      {Type: environschema.Tstring},
+		"hook-memory-limit": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	limits, err = s.apiUnit.HookLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, application.HookLimits{
+		Timeout:  2 * time.Minute,
+		MemoryMB: 1024,
+	})
+}
+
+func (s *unitSuite) TestHookRecording(c *gc.C) {
+	mode, err := s.apiUnit.HookRecording()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "")
+
+	err = s.wordpressApplication.UpdateApplicationConfig(application.ConfigAttributes{
+		"hook-recording": "all",
+	}, nil, environschema.Fields{
+		"hook-recording": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mode, err = s.apiUnit.HookRecording()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "all")
+}
+
+func (s *unitSuite) TestSetDeferredEvents(c *gc.C) {
+	at := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	err := s.apiUnit.SetDeferredEvents([]params.DeferredEvent{{Name: "check-again", Time: at}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deferred := s.wordpressUnit.DeferredEvents()
+	c.Assert(deferred, gc.HasLen, 1)
+	c.Assert(deferred[0].Name, gc.Equals, "check-again")
+	c.Assert(deferred[0].Time.Equal(at), jc.IsTrue)
+}
+
+func (s *unitSuite) TestSecrets(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := s.apiUnit.SaveSecret("password", "sekrit", "admin password", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "wordpress/password")
+
+	value, err := s.apiUnit.SecretValue(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "sekrit")
+
+	rotations, err := s.apiUnit.SecretRotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotations, gc.HasLen, 1)
+	next := rotations[id]
+
+	err = s.apiUnit.SecretRotated(id)
+	c.Assert(err, jc.ErrorIsNil)
+	rotations, err = s.apiUnit.SecretRotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotations[id].Before(next), jc.IsFalse)
+}
+
+func (s *unitSuite) TestSecretValueNotGranted(c *gc.C) {
+	s.addMachineAppCharmAndUnit(c, "mysql")
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "mysql", Name: "password", Value: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.apiUnit.SecretValue("mysql/password")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *unitSuite) TestWatchSecretRotations(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := s.apiUnit.WatchSecretRotations()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	_, err = s.apiUnit.SaveSecret("password", "sekrit", "", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *unitSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	interval, err := s.apiUnit.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 5*time.Minute)
+
+	err = s.wordpressApplication.UpdateApplicationConfig(application.ConfigAttributes{
+		"update-status-hook-interval": "1h",
+	}, nil, environschema.Fields{
+		"update-status-hook-interval": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	interval, err = s.apiUnit.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, time.Hour)
+}
+
 func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	var called int
 	relId := 2
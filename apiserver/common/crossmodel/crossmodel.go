@@ -133,6 +133,17 @@ func PublishRelationChange(backend Backend, relationTag names.Tag, change params
 		}
 	}
 
+	if change.ApplicationSettings != nil {
+		settings := make(map[string]interface{})
+		for k, v := range change.ApplicationSettings {
+			settings[k] = v
+		}
+		logger.Debugf("%s updated application settings (%v)", applicationTag.Id(), settings)
+		if err := rel.ReplaceApplicationSettings(applicationTag.Id(), settings); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for _, change := range change.ChangedUnits {
 		unitTag := names.NewUnitTag(fmt.Sprintf("%s/%v", applicationTag.Id(), change.UnitId))
 		logger.Debugf("changed unit tag for unit id %v is %v", change.UnitId, unitTag)
@@ -186,6 +197,8 @@ func WatchRelationUnits(backend Backend, tag names.RelationTag) (state.RelationU
 }
 
 // RelationUnitSettings returns the unit settings for the specified relation unit.
+// If the unit is specified by an application tag, the application-level
+// relation settings for that application are returned instead.
 func RelationUnitSettings(backend Backend, ru params.RelationUnit) (params.Settings, error) {
 	relationTag, err := names.ParseRelationTag(ru.Relation)
 	if err != nil {
@@ -195,18 +208,36 @@ func RelationUnitSettings(backend Backend, ru params.RelationUnit) (params.Setti
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	unitTag, err := names.ParseUnitTag(ru.Unit)
+	tag, err := names.ParseTag(ru.Unit)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	unit, err := rel.Unit(unitTag.Id())
-	if err != nil {
-		return nil, errors.Trace(err)
+	var settings map[string]interface{}
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		if _, err = rel.Endpoint(tag.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err = rel.ApplicationSettings(tag.Id())
+	case names.UnitTag:
+		var unit RelationUnit
+		unit, err = rel.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err = unit.Settings()
+	default:
+		return nil, errors.NotValidf("unit tag %q", ru.Unit)
 	}
-	settings, err := unit.Settings()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return paramsSettingsFromMap(settings)
+}
+
+// paramsSettingsFromMap converts relation settings into params.Settings,
+// returning an error if any value is not a string.
+func paramsSettingsFromMap(settings map[string]interface{}) (params.Settings, error) {
 	paramsSettings := make(params.Settings)
 	for k, v := range settings {
 		vString, ok := v.(string)
@@ -133,6 +133,14 @@ type Relation interface {
 
 	// SetSuspended sets the suspended status of the relation.
 	SetSuspended(bool, string) error
+
+	// ApplicationSettings returns the application-level relation
+	// settings for the specified application.
+	ApplicationSettings(appName string) (map[string]interface{}, error)
+
+	// ReplaceApplicationSettings replaces the application-level relation
+	// settings for the specified application.
+	ReplaceApplicationSettings(appName string, settings map[string]interface{}) error
 }
 
 // RelationUnit provides access to the settings of a single unit in a relation,
@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -130,6 +132,11 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		SharedSecret:      info.SharedSecret,
 		SystemIdentity:    info.SystemIdentity,
 	}
+	// The secrets key is never stored in the database,
+	// so it comes from the controller serving this call.
+	if secretsKey := api.st.SecretsKey(); len(secretsKey) > 0 {
+		result.SecretsKey = base64.StdEncoding.EncodeToString(secretsKey)
+	}
 
 	return result, nil
 }
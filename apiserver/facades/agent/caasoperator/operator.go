@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/status"
@@ -199,10 +200,15 @@ func (f *Facade) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults,
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		if _, err := caasProvider.ParsePodSpec(arg.Value); err != nil {
+		spec, err := caasProvider.ParsePodSpec(arg.Value)
+		if err != nil {
 			results.Results[i].Error = common.ServerError(errors.New("invalid pod spec"))
 			continue
 		}
+		if err := f.validatePodSpecTrust(tag, spec); err != nil {
+			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
+			continue
+		}
 		results.Results[i].Error = common.ServerError(
 			f.model.SetPodSpec(tag, arg.Value),
 		)
@@ -210,6 +216,24 @@ func (f *Facade) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults,
 	return results, nil
 }
 
+// validatePodSpecTrust returns an error if the pod spec asks for
+// access which the application has not been trusted with.
+func (f *Facade) validatePodSpecTrust(tag names.ApplicationTag, spec *caas.PodSpec) error {
+	if spec.ValidateTrust(false) == nil {
+		// Nothing in the spec requires trust.
+		return nil
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return spec.ValidateTrust(config.GetBool(application.TrustConfigOptionName, false))
+}
+
 // WatchUnits starts a StringsWatcher to watch changes to the
 // lifecycle states of units for the specified applications in
 // this model.
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -262,6 +263,45 @@ containers:
 	s.st.model.CheckCall(c, 0, "SetPodSpec", names.NewApplicationTag("gitlab"), validSpecStr)
 }
 
+func (s *CAASOperatorSuite) TestSetPodSpecGlobalServiceAccount(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  global: true
+  rules:
+    - resources: ["pods"]
+      verbs: ["get"]
+`[1:]
+
+	args := params.SetPodSpecParams{
+		Specs: []params.EntityString{
+			{Tag: "application-gitlab", Value: specStr},
+		},
+	}
+	results, err := s.facade.SetPodSpec(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: &params.Error{
+				Message: "invalid pod spec: global service account requires the application to be trusted",
+			},
+		}},
+	})
+	s.st.model.CheckNoCalls(c)
+
+	s.st.app.config = application.ConfigAttributes{"trust": true}
+	results, err = s.facade.SetPodSpec(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.st.CheckCallNames(c, "Model", "Application", "Model", "Application")
+	s.st.app.CheckCallNames(c, "ApplicationConfig", "ApplicationConfig")
+	s.st.model.CheckCall(c, 0, "SetPodSpec", names.NewApplicationTag("gitlab"), specStr)
+}
+
 func (s *CAASOperatorSuite) TestModel(c *gc.C) {
 	result, err := s.facade.CurrentModel()
 	c.Assert(err, jc.ErrorIsNil)
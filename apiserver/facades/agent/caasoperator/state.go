@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -43,6 +44,7 @@ type Application interface {
 	SetOperatorStatus(status.StatusInfo) error
 	WatchUnits() state.StringsWatcher
 	AllUnits() ([]Unit, error)
+	ApplicationConfig() (application.ConfigAttributes, error)
 }
 
 // Charm provides the subset of charm state required by the
@@ -13,12 +13,13 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
-// Right now, these are defined as constants, but the plan is to maybe make
-// them configurable in the future
+// These are the defaults; applications may override the retry times
+// in their hook retry policy.
 const (
 	MinRetryTime    = 5 * time.Second
 	MaxRetryTime    = 5 * time.Minute
@@ -89,25 +90,77 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the model.
-			// The rest are hardcoded.
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			results.Results[i].Result, err = h.oneRetryStrategy(tag, config.AutomaticallyRetryHooks())
 		}
 		results.Results[i].Error = common.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the model. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// oneRetryStrategy returns the retry strategy for the unit or
+// application with the given tag. Whether hooks are retried at all is
+// taken from the model; the application's hook retry policy may then
+// limit and tune the retries.
+func (h *RetryStrategyAPI) oneRetryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := application.ParseHookRetryPolicy(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+		MaxAttempts:     policy.MaxAttempts,
+		RetryHooks:      policy.Hooks,
+		ExcludeHooks:    policy.ExcludeHooks,
+	}
+	if policy.MinBackoff > 0 {
+		strategy.MinRetryTime = policy.MinBackoff
+	}
+	if policy.MaxBackoff > 0 {
+		strategy.MaxRetryTime = policy.MaxBackoff
+	}
+	if strategy.MinRetryTime > strategy.MaxRetryTime {
+		// Only one bound was set by the application, past
+		// the other's default.
+		if policy.MinBackoff > 0 {
+			strategy.MaxRetryTime = strategy.MinRetryTime
+		} else {
+			strategy.MinRetryTime = strategy.MaxRetryTime
+		}
+	}
+	return strategy, nil
+}
+
+// application returns the application of the unit or application with
+// the given tag.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		appName, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return h.st.Application(appName)
+	case names.ApplicationTag:
+		return h.st.Application(tag.Id())
+	}
+	return nil, common.ErrPerm
+}
+
+// WatchRetryStrategy watches for changes to the model config, which
+// determines whether retries should be attempted, and to the hook retry
+// policy in the config of each entity's application.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -124,18 +177,31 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API calls to Watch
-			// 'transmit' the initial event in the Watch response. But
-			// NotifyWatchers have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				results.Results[i].NotifyWatcherId = h.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
-			}
+			results.Results[i].NotifyWatcherId, err = h.watchOneRetryStrategy(tag)
 		}
 		results.Results[i].Error = common.ServerError(err)
 	}
 	return results, nil
 }
+
+// watchOneRetryStrategy watches the model config, which determines
+// whether hooks are retried, and the config of the application of the
+// unit or application with the given tag, which holds its hook retry
+// policy.
+func (h *RetryStrategyAPI) watchOneRetryStrategy(tag names.Tag) (string, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := common.NewMultiNotifyWatcher(
+		h.model.WatchForModelConfigChanges(),
+		app.WatchApplicationConfig(),
+	)
+	// Consume the initial event. Technically, API calls to Watch
+	// 'transmit' the initial event in the Watch response. But
+	// NotifyWatchers have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return h.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
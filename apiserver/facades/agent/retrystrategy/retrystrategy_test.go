@@ -5,8 +5,11 @@
 package retrystrategy_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
@@ -131,6 +134,45 @@ func (s *retryStrategySuite) assertRetryStrategy(c *gc.C, tag string) {
 	c.Assert(r.Results[0].Result, jc.DeepEquals, expected)
 }
 
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		"hook-retry-max-attempts":  3,
+		"hook-retry-max-backoff":   "1s",
+		"hook-retry-hooks":         "install",
+		"hook-retry-exclude-hooks": "upgrade-charm",
+	})
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry: true,
+		// The default minimum is capped by the application's maximum.
+		MinRetryTime:    time.Second,
+		MaxRetryTime:    time.Second,
+		JitterRetryTime: retrystrategy.JitterRetryTime,
+		RetryTimeFactor: retrystrategy.RetryTimeFactor,
+		MaxAttempts:     3,
+		RetryHooks:      []string{"install"},
+		ExcludeHooks:    []string{"upgrade-charm"},
+	})
+}
+
+func (s *retryStrategySuite) setHookRetryPolicy(c *gc.C, attrs map[string]interface{}) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	schema := make(environschema.Fields)
+	for name, value := range attrs {
+		schema[name] = environschema.Attr{Type: environschema.Tstring}
+		if _, ok := value.(int); ok {
+			schema[name] = environschema.Attr{Type: environschema.Tint}
+		}
+	}
+	err = app.UpdateApplicationConfig(attrs, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks bool) {
 	err := s.Model.UpdateModelConfig(map[string]interface{}{"automatically-retry-hooks": automaticallyRetryHooks}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -193,4 +235,7 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	s.setRetryStrategy(c, false)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
+
+	s.setHookRetryPolicy(c, map[string]interface{}{"hook-retry-max-attempts": 5})
+	wc.AssertOneChange()
 }
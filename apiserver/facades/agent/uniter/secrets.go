@@ -0,0 +1,246 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// secretsUnit returns the unit with the given tag, if the caller may
+// act for it.
+func (u *UniterAPI) secretsUnit(canAccess common.AuthFunc, unitTag string) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return u.getUnit(tag)
+}
+
+// checkSecretsLeader returns an error unless the unit is the leader of
+// its application. Only the leader may manage the application's secrets.
+func (u *UniterAPI) checkSecretsLeader(unit *state.Unit) error {
+	token := u.leadershipChecker.LeadershipCheck(unit.ApplicationName(), unit.Name())
+	return errors.Trace(token.Check(nil))
+}
+
+// SaveSecrets creates or updates secrets owned by the applications of
+// the given units, returning the ids of the secrets.
+func (u *UniterAPI) SaveSecrets(args params.SaveSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		id, err := u.saveSecret(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = id
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) saveSecret(canAccess common.AuthFunc, arg params.SaveSecretArg) (string, error) {
+	unit, err := u.secretsUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return "", err
+	}
+	if err := u.checkSecretsLeader(unit); err != nil {
+		return "", err
+	}
+	secret, err := u.st.SaveSecret(state.SecretParams{
+		Owner:          unit.ApplicationName(),
+		Name:           arg.Name,
+		Value:          arg.Value,
+		Description:    arg.Description,
+		RotateInterval: arg.RotateInterval,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return secret.ID(), nil
+}
+
+// GetSecretValues returns the values of the given secrets, if the
+// applications of the given units may read them.
+func (u *UniterAPI) GetSecretValues(args params.GetSecretValueArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		value, err := u.getSecretValue(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = value
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) getSecretValue(canAccess common.AuthFunc, arg params.GetSecretValueArg) (string, error) {
+	unit, err := u.secretsUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return "", err
+	}
+	secret, err := u.st.Secret(arg.ID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	canRead, err := secret.CanRead(unit.ApplicationName())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !canRead {
+		return "", common.ErrPerm
+	}
+	return secret.Value()
+}
+
+// GrantSecrets gives the remote applications on the given relations
+// access to secrets owned by the applications of the given units.
+func (u *UniterAPI) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(u.grantSecret(canAccess, arg))
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) grantSecret(canAccess common.AuthFunc, arg params.GrantSecretArg) error {
+	unit, err := u.secretsUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return err
+	}
+	if err := u.checkSecretsLeader(unit); err != nil {
+		return err
+	}
+	relTag, err := names.ParseRelationTag(arg.RelationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	secret, err := u.st.Secret(arg.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Owner() != unit.ApplicationName() {
+		return common.ErrPerm
+	}
+	return u.st.GrantSecret(arg.ID, relTag.Id())
+}
+
+// WatchSecretRotations returns a NotifyWatcher for each given unit,
+// which notifies when the secrets owned by the unit's application
+// change.
+func (u *UniterAPI) WatchSecretRotations(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := u.secretsUnit(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := u.st.WatchSecrets(unit.ApplicationName())
+		// Consume the initial event.
+		if _, ok := <-w.Changes(); !ok {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		result.Results[i].NotifyWatcherId = u.resources.Register(w)
+	}
+	return result, nil
+}
+
+// SecretRotations returns when each of the secrets owned by the
+// applications of the given units is next due to be rotated.
+func (u *UniterAPI) SecretRotations(args params.Entities) (params.SecretRotationsResults, error) {
+	result := params.SecretRotationsResults{
+		Results: make([]params.SecretRotationsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretRotationsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := u.secretsUnit(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		secrets, err := u.st.Secrets(unit.ApplicationName())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, secret := range secrets {
+			if next := secret.NextRotateTime(); next != nil {
+				result.Results[i].Rotations = append(result.Results[i].Rotations, params.SecretRotation{
+					ID:             secret.ID(),
+					NextRotateTime: *next,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// SecretsRotated records that the given units have rotated secrets
+// owned by their applications.
+func (u *UniterAPI) SecretsRotated(args params.SecretRotatedArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(u.secretRotated(canAccess, arg))
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) secretRotated(canAccess common.AuthFunc, arg params.SecretRotatedArg) error {
+	unit, err := u.secretsUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return err
+	}
+	if err := u.checkSecretsLeader(unit); err != nil {
+		return err
+	}
+	secret, err := u.st.Secret(arg.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Owner() != unit.ApplicationName() {
+		return common.ErrPerm
+	}
+	return u.st.SecretRotated(arg.ID)
+}
@@ -4,8 +4,13 @@
 package uniter
 
 import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -13,6 +18,7 @@ import (
 // status from different entities, this particular separation from
 // base is because we have a shim to support unit/agent split.
 type StatusAPI struct {
+	st            *state.State
 	agentSetter   *common.StatusSetter
 	unitSetter    *common.StatusSetter
 	unitGetter    *common.StatusGetter
@@ -31,6 +37,7 @@ func NewStatusAPI(st *state.State, getCanModify common.GetAuthFunc) *StatusAPI {
 	serviceGetter := common.NewApplicationStatusGetter(st, getCanModify)
 	agentSetter := common.NewStatusSetter(&common.UnitAgentFinder{st}, getCanModify)
 	return &StatusAPI{
+		st:            st,
 		agentSetter:   agentSetter,
 		unitSetter:    unitSetter,
 		unitGetter:    unitGetter,
@@ -60,6 +67,47 @@ func (s *StatusAPI) SetUnitStatus(args params.SetStatus) (params.ErrorResults, e
 	return s.unitSetter.SetStatus(args)
 }
 
+// SetUnitHealthStatus sets the workload status of the units in args while
+// their health probes are failing. Unlike SetUnitStatus, it accepts the
+// error status, which charms cannot set themselves.
+func (s *StatusAPI) SetUnitHealthStatus(args params.SetStatus) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canModify, err := s.getCanModify()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	now := time.Now()
+	for i, arg := range args.Entities {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canModify(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = unit.SetHealthStatus(status.StatusInfo{
+			Status:  status.Status(arg.Status),
+			Message: arg.Info,
+			Data:    arg.Data,
+			Since:   &now,
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // SetApplicationStatus sets the status for all the Services in args if the given Unit is
 // the leader.
 func (s *StatusAPI) SetApplicationStatus(args params.SetStatus) (params.ErrorResults, error) {
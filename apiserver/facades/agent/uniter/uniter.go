@@ -8,6 +8,7 @@ package uniter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
@@ -64,9 +66,16 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV8 doesn't have the ReadLocalApplicationSettings, HookLimits,
+// UpdateStatusHookInterval, HookRecording, SetDeferredEvents, secrets
+// or SetUnitHealthStatus methods.
+type UniterAPIV8 struct {
+	UniterAPI
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -253,14 +262,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(context facade.Context) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
 func NewUniterAPIV7(context facade.Context) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV8(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
@@ -1028,6 +1048,203 @@ func (u *UniterAPI) ConfigSettings(args params.Entities) (params.ConfigSettingsR
 	return result, nil
 }
 
+// HookLimits returns the resource limits to apply to the hooks of
+// each given unit, as configured on the unit's application.
+func (u *UniterAPI) HookLimits(args params.Entities) (params.HookLimitsResults, error) {
+	result := params.HookLimitsResults{
+		Results: make([]params.HookLimitsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookLimitsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		limits, err := u.oneHookLimits(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = limits
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) oneHookLimits(tag names.UnitTag) (*params.HookLimits, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	limits, err := coreapplication.ParseHookLimits(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.HookLimits{
+		Timeout:    limits.Timeout,
+		MemoryMB:   limits.MemoryMB,
+		CPUPercent: limits.CPUPercent,
+	}, nil
+}
+
+// HookRecording returns the hook recording mode for each given unit,
+// as configured on the unit's application. An empty mode means hooks
+// are not recorded.
+func (u *UniterAPI) HookRecording(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		mode, err := u.oneHookRecording(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = mode
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) oneHookRecording(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	mode, err := coreapplication.ParseHookRecording(config)
+	return mode, errors.Trace(err)
+}
+
+// SetDeferredEvents records the custom events that each given unit's
+// hooks have asked to be delivered to later hooks.
+func (u *UniterAPI) SetDeferredEvents(args params.SetDeferredEventsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		events := make([]state.DeferredEvent, len(entity.Events))
+		for j, event := range entity.Events {
+			events[j] = state.DeferredEvent{Name: event.Name, Time: event.Time}
+		}
+		if err := unit.SetDeferredEvents(events); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// UpdateStatusHookInterval returns the interval at which each given
+// unit should run its update-status hook. The unit's application may
+// override the model's interval, or disable update-status entirely,
+// in which case the interval is zero.
+func (u *UniterAPI) UpdateStatusHookInterval(args params.Entities) (params.UpdateStatusHookIntervalResults, error) {
+	result := params.UpdateStatusHookIntervalResults{
+		Results: make([]params.UpdateStatusHookIntervalResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpdateStatusHookIntervalResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		interval, err := u.oneUpdateStatusHookInterval(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Interval = interval
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) oneUpdateStatusHookInterval(tag names.UnitTag) (time.Duration, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	interval, ok, err := coreapplication.ParseUpdateStatusHookInterval(config)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if ok {
+		return interval, nil
+	}
+	modelConfig, err := u.m.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return modelConfig.UpdateStatusHookInterval(), nil
+}
+
 // CharmArchiveSha256 returns the SHA256 digest of the charm archive
 // (bundle) data for each charm url in the given parameters.
 func (u *UniterAPI) CharmArchiveSha256(args params.CharmURLs) (params.StringResults, error) {
@@ -1414,86 +1631,34 @@ func (u *UniterAPI) ReadSettings(args params.RelationUnits) (params.SettingsResu
 		}
 		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
 		if err == nil {
-			var settings *state.Settings
-			settings, err = relUnit.Settings()
-			if err == nil {
-				result.Results[i].Settings, err = convertRelationSettings(settings.Map())
-			}
-		}
-		result.Results[i].Error = common.ServerError(err)
-	}
-	return result, nil
-}
-
-// ReadRemoteSettings returns the remote settings of each given set of
-// relation/local unit/remote unit.
-func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.SettingsResults, error) {
-	result := params.SettingsResults{
-		Results: make([]params.SettingsResult, len(args.RelationUnitPairs)),
-	}
-	canAccess, err := u.accessUnit()
-	if err != nil {
-		return params.SettingsResults{}, err
-	}
-	for i, arg := range args.RelationUnitPairs {
-		unit, err := names.ParseUnitTag(arg.LocalUnit)
-		if err != nil {
-			result.Results[i].Error = common.ServerError(common.ErrPerm)
-			continue
-		}
-		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
-		if err == nil {
-			// TODO(dfc) rework this logic
-			remoteUnit := ""
-			remoteUnit, err = u.checkRemoteUnit(relUnit, arg.RemoteUnit)
-			if err == nil {
-				var settings map[string]interface{}
-				settings, err = relUnit.ReadSettings(remoteUnit)
-				if err == nil {
-					result.Results[i].Settings, err = convertRelationSettings(settings)
-				}
-			}
+			err = u.updateRelationSettings(relUnit, unit, arg.Settings, arg.ApplicationSettings)
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
-// UpdateSettings persists all changes made to the local settings of
-// all given pairs of relation and unit. Keys with empty values are
-// considered a signal to delete these values.
-func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
-	result := params.ErrorResults{
-		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+// updateRelationSettings applies the changes to the unit's settings in
+// the relation and to the application-level settings of its application
+// in one transaction. Application-level changes are only accepted while
+// the unit is its application's leader.
+func (u *UniterAPI) updateRelationSettings(
+	relUnit *state.RelationUnit, unit names.UnitTag, changes, appChanges params.Settings,
+) error {
+	unitUpdates := make(map[string]interface{})
+	for k, v := range changes {
+		unitUpdates[k] = v
 	}
-	canAccess, err := u.accessUnit()
-	if err != nil {
-		return params.ErrorResults{}, err
-	}
-	for i, arg := range args.RelationUnits {
-		unit, err := names.ParseUnitTag(arg.Unit)
-		if err != nil {
-			result.Results[i].Error = common.ServerError(common.ErrPerm)
-			continue
-		}
-		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
-		if err == nil {
-			var settings *state.Settings
-			settings, err = relUnit.Settings()
-			if err == nil {
-				for k, v := range arg.Settings {
-					if v == "" {
-						settings.Delete(k)
-					} else {
-						settings.Set(k, v)
-					}
-				}
-				_, err = settings.Write()
-			}
+	var token leadership.Token
+	appUpdates := make(map[string]interface{})
+	if len(appChanges) > 0 {
+		appName := relUnit.Endpoint().ApplicationName
+		token = u.leadershipChecker.LeadershipCheck(appName, unit.Id())
+		for k, v := range appChanges {
+			appUpdates[k] = v
 		}
-		result.Results[i].Error = common.ServerError(err)
 	}
-	return result, nil
+	return relUnit.UpdateSettings(unitUpdates, appUpdates, token)
 }
 
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
@@ -1778,7 +1943,11 @@ func (u *UniterAPI) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	var watch state.NotifyWatcher
+	app, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	var addressWatch state.NotifyWatcher
 	if unit.ShouldBeAssigned() {
 		machineId, err := unit.AssignedMachineId()
 		if err != nil {
@@ -1788,10 +1957,14 @@ func (u *UniterAPI) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		watch = machine.WatchAddresses()
+		addressWatch = machine.WatchAddresses()
 	} else {
-		watch = unit.WatchContainerAddresses()
+		addressWatch = unit.WatchContainerAddresses()
 	}
+	// Changing the application's endpoint bindings can change the
+	// addresses the unit should use, so the unit is notified of
+	// those changes too.
+	watch := common.NewMultiNotifyWatcher(addressWatch, app.WatchEndpointBindings())
 	// Consume the initial event. Technically, API
 	// calls to Watch 'transmit' the initial event
 	// in the Watch response. But NotifyWatchers
@@ -2438,6 +2611,42 @@ func (u *UniterAPIV6) NetworkInfo(args params.NetworkInfoParams) (params.Network
 // SetPodSpec isn't on the v7 API.
 func (u *UniterAPIV7) SetPodSpec(_, _ struct{}) {}
 
+// ReadLocalApplicationSettings isn't on the v8 API.
+func (u *UniterAPIV8) ReadLocalApplicationSettings(_, _ struct{}) {}
+
+// HookLimits isn't on the v8 API.
+func (u *UniterAPIV8) HookLimits(_, _ struct{}) {}
+
+// UpdateStatusHookInterval isn't on the v8 API.
+func (u *UniterAPIV8) UpdateStatusHookInterval(_, _ struct{}) {}
+
+// HookRecording isn't on the v8 API.
+func (u *UniterAPIV8) HookRecording(_, _ struct{}) {}
+
+// SetDeferredEvents isn't on the v8 API.
+func (u *UniterAPIV8) SetDeferredEvents(_, _ struct{}) {}
+
+// SaveSecrets isn't on the v8 API.
+func (u *UniterAPIV8) SaveSecrets(_, _ struct{}) {}
+
+// GetSecretValues isn't on the v8 API.
+func (u *UniterAPIV8) GetSecretValues(_, _ struct{}) {}
+
+// GrantSecrets isn't on the v8 API.
+func (u *UniterAPIV8) GrantSecrets(_, _ struct{}) {}
+
+// WatchSecretRotations isn't on the v8 API.
+func (u *UniterAPIV8) WatchSecretRotations(_, _ struct{}) {}
+
+// SecretRotations isn't on the v8 API.
+func (u *UniterAPIV8) SecretRotations(_, _ struct{}) {}
+
+// SecretsRotated isn't on the v8 API.
+func (u *UniterAPIV8) SecretsRotated(_, _ struct{}) {}
+
+// SetUnitHealthStatus isn't on the v8 API.
+func (u *UniterAPIV8) SetUnitHealthStatus(_, _ struct{}) {}
+
 // SetPodSpec sets the pod specs for a set of applications.
 func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -2480,7 +2689,12 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		if _, err := cassProvider.ParsePodSpec(arg.Value); err != nil {
+		spec, err := cassProvider.ParsePodSpec(arg.Value)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
+			continue
+		}
+		if err := u.validatePodSpecTrust(tag, spec); err != nil {
 			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
 			continue
 		}
@@ -2496,6 +2710,24 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 	return results, nil
 }
 
+// validatePodSpecTrust returns an error if the pod spec asks for
+// access which the application has not been trusted with.
+func (u *UniterAPI) validatePodSpecTrust(tag names.ApplicationTag, spec *caas.PodSpec) error {
+	if spec.ValidateTrust(false) == nil {
+		// Nothing in the spec requires trust.
+		return nil
+	}
+	app, err := u.st.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return spec.ValidateTrust(config.GetBool(application.TrustConfigOptionName, false))
+}
+
 // CloudSpec returns the cloud spec used by the model in which the
 // authenticated unit or application resides.
 // A check is made beforehand to ensure that the request is made by an entity
@@ -297,6 +297,39 @@ func (s *uniterSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(statusInfo.Message, gc.Equals, "foobar")
 }
 
+func (s *uniterSuite) TestSetUnitHealthStatus(c *gc.C) {
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "unit-mysql-0", Status: status.Error.String(), Info: "not really"},
+			{Tag: "unit-wordpress-0", Status: status.Error.String(), Info: `health probe "web" failed`},
+			{Tag: "application-wordpress", Status: status.Error.String(), Info: "blah"},
+		}}
+	result, err := s.uniter.SetUnitHealthStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	statusInfo, err := s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Error)
+	c.Assert(statusInfo.Message, gc.Equals, `health probe "web" failed`)
+}
+
+func (s *uniterSuite) TestSetUnitStatusError(c *gc.C) {
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "unit-wordpress-0", Status: status.Error.String(), Info: "broken"},
+		}}
+	result, err := s.uniter.SetUnitStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `cannot set invalid status "error"`)
+}
+
 func (s *uniterSuite) TestLife(c *gc.C) {
 	// Add a relation wordpress-mysql.
 	rel := s.addRelation(c, "wordpress", "mysql")
@@ -1173,6 +1206,206 @@ func (s *uniterSuite) TestConfigSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookLimits(c *gc.C) {
+	conf := map[string]interface{}{
+		"hook-timeout":      "10m",
+		"hook-memory-limit": "512M",
+		"hook-cpu-limit":    "50%",
+	}
+	fields := map[string]environschema.Attr{
+		"hook-timeout":      {Type: environschema.Tstring},
+		"hook-memory-limit": {Type: environschema.Tstring},
+		"hook-cpu-limit":    {Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(conf, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.HookLimits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HookLimitsResults{
+		Results: []params.HookLimitsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.HookLimits{
+				Timeout:    10 * time.Minute,
+				MemoryMB:   512,
+				CPUPercent: 50,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestHookRecording(c *gc.C) {
+	err := s.wordpress.UpdateApplicationConfig(map[string]interface{}{
+		"hook-recording": "failed",
+	}, nil, map[string]environschema.Attr{
+		"hook-recording": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.HookRecording(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "failed"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetDeferredEvents(c *gc.C) {
+	at := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	events := []params.DeferredEvent{{Name: "check-again", Time: at}}
+	args := params.SetDeferredEventsArgs{Entities: []params.EntityDeferredEvents{
+		{Tag: "unit-mysql-0", Events: events},
+		{Tag: "unit-wordpress-0", Events: events},
+		{Tag: "unit-foo-42", Events: events},
+	}}
+	result, err := s.uniter.SetDeferredEvents(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deferred := s.wordpressUnit.DeferredEvents()
+	c.Assert(deferred, gc.HasLen, 1)
+	c.Assert(deferred[0].Name, gc.Equals, "check-again")
+	c.Assert(deferred[0].Time.Equal(at), jc.IsTrue)
+}
+
+func (s *uniterSuite) TestSaveSecrets(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SaveSecretArgs{Args: []params.SaveSecretArg{
+		{UnitTag: "unit-mysql-0", Name: "password", Value: "sekrit"},
+		{UnitTag: "unit-wordpress-0", Name: "password", Value: "sekrit", RotateInterval: time.Hour},
+		{UnitTag: "unit-wordpress-0", Name: "Bad Name", Value: "sekrit"},
+	}}
+	result, err := s.uniter.SaveSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1], gc.DeepEquals, params.StringResult{Result: "wordpress/password"})
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `secret name "Bad Name" not valid`)
+
+	values, err := s.uniter.GetSecretValues(params.GetSecretValueArgs{Args: []params.GetSecretValueArg{
+		{UnitTag: "unit-wordpress-0", ID: "wordpress/password"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{Result: "sekrit"}},
+	})
+
+	rotations, err := s.uniter.SecretRotations(params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotations.Results, gc.HasLen, 1)
+	c.Assert(rotations.Results[0].Rotations, gc.HasLen, 1)
+	c.Assert(rotations.Results[0].Rotations[0].ID, gc.Equals, "wordpress/password")
+}
+
+func (s *uniterSuite) TestSaveSecretsNotLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SaveSecrets(params.SaveSecretArgs{Args: []params.SaveSecretArg{
+		{UnitTag: "unit-wordpress-0", Name: "password", Value: "sekrit"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.NotNil)
+	_, err = s.State.Secret("wordpress/password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *uniterSuite) TestSecretsRotatedNotLeader(c *gc.C) {
+	_, err := s.State.SaveSecret(state.SecretParams{
+		Owner: "wordpress", Name: "password", Value: "sekrit", RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretsRotated(params.SecretRotatedArgs{Args: []params.SecretRotatedArg{
+		{UnitTag: "unit-wordpress-0", ID: "wordpress/password"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.NotNil)
+}
+
+func (s *uniterSuite) TestGetSecretValuesGranted(c *gc.C) {
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "mysql", Name: "password", Value: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.GetSecretValueArgs{Args: []params.GetSecretValueArg{
+		{UnitTag: "unit-wordpress-0", ID: "mysql/password"},
+	}}
+	result, err := s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err = s.State.GrantSecret("mysql/password", rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{Result: "sekrit"}},
+	})
+}
+
+func (s *uniterSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	err := s.wordpress.UpdateApplicationConfig(map[string]interface{}{
+		"update-status-hook-interval": "0",
+	}, nil, map[string]environschema.Attr{
+		"update-status-hook-interval": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UpdateStatusHookInterval(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Interval: 0},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpress.UpdateApplicationConfig(nil, []string{"update-status-hook-interval"}, map[string]environschema.Attr{
+		"update-status-hook-interval": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.UpdateStatusHookInterval(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Interval, gc.Equals, 5*time.Minute)
+}
+
 func (s *uniterSuite) TestWatchUnitRelations(c *gc.C) {
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 
@@ -2466,6 +2699,27 @@ func (s *uniterSuite) TestWatchUnitAddresses(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestWatchUnitAddressesEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("public", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.WatchUnitAddresses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	resource := s.resources.Get(result.Results[0].NotifyWatcherId)
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Rebinding an endpoint notifies the watcher.
+	err = s.wordpress.SetEndpointBindings(map[string]string{"url": "public"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestWatchCAASUnitAddresses(c *gc.C) {
 	_, cm, _, _ := s.setupCAASModel(c)
 	c.Assert(s.resources.Count(), gc.Equals, 0)
@@ -3140,6 +3394,37 @@ func (s *uniterSuite) TestSetPodSpec(c *gc.C) {
 	c.Assert(spec, gc.Equals, podSpec)
 }
 
+var globalServiceAccountPodSpec = podSpec + `
+serviceAccount:
+  global: true
+  rules:
+    - apiGroups: [""]
+      resources: ["pods"]
+      verbs: ["get", "watch", "list"]
+`[1:]
+
+func (s *uniterSuite) TestSetPodSpecGlobalServiceAccountNotTrusted(c *gc.C) {
+	u, _, app, _ := s.setupCAASModel(c)
+	err := u.SetPodSpec(app.Name(), globalServiceAccountPodSpec)
+	c.Assert(err, gc.ErrorMatches, "invalid pod spec: global service account requires the application to be trusted")
+}
+
+func (s *uniterSuite) TestSetPodSpecGlobalServiceAccountTrusted(c *gc.C) {
+	u, cm, app, _ := s.setupCAASModel(c)
+	err := app.UpdateApplicationConfig(map[string]interface{}{
+		"trust": true,
+	}, nil, map[string]environschema.Attr{
+		"trust": {Type: environschema.Tbool},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.SetPodSpec(app.Name(), globalServiceAccountPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := cm.PodSpec(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, globalServiceAccountPodSpec)
+}
+
 type unitMetricBatchesSuite struct {
 	uniterSuiteBase
 	*commontesting.ModelWatcherTest
@@ -65,6 +65,11 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
 	*APIBase
 }
 
@@ -91,6 +96,7 @@ type APIBase struct {
 	stateCharm func(Charm) *state.Charm
 
 	storagePoolManager    poolmanager.PoolManager
+	caasBroker            CAASBroker
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
 }
 
@@ -134,14 +140,26 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 	return &APIv7{api}, nil
 }
 
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -154,7 +172,10 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	blockChecker := common.NewBlockChecker(ctx.State())
 	stateCharm := CharmToStateCharm
 
-	var storagePoolManager poolmanager.PoolManager
+	var (
+		storagePoolManager poolmanager.PoolManager
+		caasBroker         CAASBroker
+	)
 	if model.Type() == state.ModelTypeCAAS {
 		broker, err := stateenvirons.GetNewCAASBrokerFunc(caas.New)(ctx.State())
 		if err != nil {
@@ -162,6 +183,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		}
 		storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(broker)
 		storagePoolManager = poolmanager.New(state.NewStateSettings(ctx.State()), storageProviderRegistry)
+		caasBroker = broker
 	}
 
 	resources := ctx.Resources()
@@ -176,6 +198,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		stateCharm,
 		DeployApplication,
 		storagePoolManager,
+		caasBroker,
 		resources,
 	)
 }
@@ -191,6 +214,7 @@ func NewAPIBase(
 	stateCharm func(Charm) *state.Charm,
 	deployApplication func(ApplicationDeployer, DeployApplicationParams) (Application, error),
 	storagePoolManager poolmanager.PoolManager,
+	caasBroker CAASBroker,
 	resources facade.Resources,
 ) (*APIBase, error) {
 	if !authorizer.AuthClient() {
@@ -206,6 +230,7 @@ func NewAPIBase(
 		stateCharm:            stateCharm,
 		deployApplicationFunc: deployApplication,
 		storagePoolManager:    storagePoolManager,
+		caasBroker:            caasBroker,
 		resources:             resources,
 	}, nil
 }
@@ -363,7 +388,20 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		fields, err := addHookLimitSchema(trustFields)
+		if err != nil {
+			return nil, nil, err
+		}
+		if fields, err = addUpdateStatusSchema(fields); err != nil {
+			return nil, nil, err
+		}
+		if fields, err = addHookRecordingSchema(fields); err != nil {
+			return nil, nil, err
+		}
+		if fields, err = addHookRetrySchema(fields); err != nil {
+			return nil, nil, err
+		}
+		return fields, trustDefaults, nil
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -371,6 +409,18 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	if schema, err = addHookLimitSchema(schema); err != nil {
+		return nil, nil, err
+	}
+	if schema, err = addUpdateStatusSchema(schema); err != nil {
+		return nil, nil, err
+	}
+	if schema, err = addHookRecordingSchema(schema); err != nil {
+		return nil, nil, err
+	}
+	if schema, err = addHookRetrySchema(schema); err != nil {
+		return nil, nil, err
+	}
 	return AddTrustSchemaAndDefaults(schema, defaults)
 }
 
@@ -395,6 +445,15 @@ func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[stri
 			charmConfig[k] = v
 		}
 	}
+	if err := validateHookLimits(appConfigAttrs); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := validateUpdateStatusHookInterval(appConfigAttrs); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := validateHookRecording(appConfigAttrs); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	return appConfigAttrs, charmConfig, nil
 }
 
@@ -475,6 +534,14 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateHookRetryPolicy(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if modelType == state.ModelTypeCAAS {
+		if err := k8s.ValidateConfig(applicationConfig.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var settings = make(charm.Settings)
 	if len(args.ConfigYAML) > 0 {
@@ -1600,6 +1667,9 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := api.checkCanScale(app, appTag); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.ScaleApplicationInfo
 		if err := app.Scale(arg.Scale); err != nil {
 			return nil, errors.Trace(err)
@@ -1619,6 +1689,50 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 	return params.ScaleApplicationResults{results}, nil
 }
 
+// checkCanScale returns an error if the application's pod spec makes
+// it a daemon application, whose units track the nodes of the cluster,
+// or if its config has an autoscaler choose the number of units.
+func (api *APIBase) checkCanScale(app Application, appTag names.ApplicationTag) error {
+	if err := api.checkNotDaemon(appTag); err != nil {
+		return errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if k8s.IsAutoscaled(config) {
+		return errors.NotSupportedf("scaling autoscaled application %q", appTag.Id())
+	}
+	return nil
+}
+
+// checkNotDaemon returns an error if the application's pod spec
+// makes it a daemon application.
+func (api *APIBase) checkNotDaemon(appTag names.ApplicationTag) error {
+	specStr, err := api.backend.PodSpec(appTag)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := environs.Provider(k8s.K8s_ProviderType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caasProvider, ok := provider.(caas.ContainerEnvironProvider)
+	if !ok {
+		return errors.NotValidf("container environ provider %T", provider)
+	}
+	spec, err := caasProvider.ParsePodSpec(specStr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spec.DeploymentType == caas.DeploymentDaemon {
+		return errors.NotSupportedf("scaling daemon application %q", appTag.Id())
+	}
+	return nil
+}
+
 // GetConstraints returns the constraints for a given application.
 func (api *APIBase) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -2056,6 +2170,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateMergedApplicationConfig(api.modelType, app, appConfigAttrs, nil, schema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2077,6 +2194,45 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	return nil
 }
 
+// validateMergedApplicationConfig returns an error if the application
+// config resulting from applying the changes and resetting the keys of
+// the application's stored config is not valid. Some settings are only
+// valid in combination, so they cannot be checked on their own.
+func validateMergedApplicationConfig(
+	modelType state.ModelType,
+	app Application,
+	changes map[string]interface{},
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	merged := make(map[string]interface{})
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		merged[k] = v
+	}
+	for _, k := range reset {
+		delete(merged, k)
+	}
+	config, err := application.NewConfig(merged, schema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateHookRetryPolicy(config.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if modelType != state.ModelTypeCAAS {
+		return nil
+	}
+	return errors.Trace(k8s.ValidateConfig(config.Attributes()))
+}
+
 // UnsetApplicationsConfig isn't on the v5 API.
 func (u *APIv5) UnsetApplicationsConfig(_, _ struct{}) {}
 
@@ -2120,6 +2276,9 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 	}
 
 	if len(appConfigKeys) > 0 {
+		if err := validateMergedApplicationConfig(api.modelType, app, nil, appConfigKeys, schema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(nil, appConfigKeys, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2174,3 +2333,107 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	}
 	return result, nil
 }
+
+// SetBindings isn't on the v8 API.
+func (u *APIv8) SetBindings(_, _ struct{}) {}
+
+// SetBindings changes the endpoint bindings of the given applications.
+// Units in relations on the rebound endpoints see their relation
+// addresses updated, which in turn notifies the related units.
+func (api *APIBase) SetBindings(args params.ApplicationSetBindingsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setBindings(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) setBindings(arg params.ApplicationSetBindings) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetEndpointBindings(arg.Bindings, arg.Force)
+}
+
+// RolloutStatus isn't on the v8 API.
+func (u *APIv8) RolloutStatus(_, _ struct{}) {}
+
+// RolloutStatus returns the progress of replacing the pods of the
+// given Kubernetes applications after their pod specs changed.
+func (api *APIBase) RolloutStatus(args params.Entities) (params.RolloutStatusResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RolloutStatusResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.RolloutStatusResults{}, errors.NotSupportedf("rollout status on a non-container model")
+	}
+	result := params.RolloutStatusResults{
+		Results: make([]params.RolloutStatusResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rollout, err := api.caasBroker.RolloutStatus(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = &params.RolloutStatus{
+			Generation: rollout.Generation,
+			Desired:    rollout.Desired,
+			Updated:    rollout.Updated,
+			Ready:      rollout.Ready,
+			Complete:   rollout.Complete,
+			Failed:     rollout.Failed,
+			Message:    rollout.Message,
+		}
+	}
+	return result, nil
+}
+
+// AbortRollout isn't on the v8 API.
+func (u *APIv8) AbortRollout(_, _ struct{}) {}
+
+// AbortRollout returns the pods of the given Kubernetes applications
+// to their previous pod spec, stopping any rollout in progress.
+func (api *APIBase) AbortRollout(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.ErrorResults{}, errors.NotSupportedf("aborting a rollout on a non-container model")
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.caasBroker.AbortRollout(tag.Id())
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -106,10 +106,11 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv8 {
 		application.CharmToStateCharm,
 		application.DeployApplication,
 		pm,
+		nil,
 		common.NewResources(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv8{api}
+	return &application.APIv8{&application.APIv9{api}}
 }
 
 func (s *applicationSuite) TestGetConfig(c *gc.C) {
@@ -3099,3 +3100,140 @@ func (s *applicationSuite) TestRemoteRelationApplicationNotFound(c *gc.C) {
 	_, err := s.applicationAPI.AddRelation(params.AddRelation{Endpoints: endpoints})
 	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
 }
+
+func (s *applicationSuite) setupRelatedUnits(c *gc.C) (*state.Unit, *state.Unit) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpress0, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysql0, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	for unit, settings := range map[*state.Unit]map[string]interface{}{
+		wordpress0: {"url": "http://wordpress"},
+		mysql0:     {"host": "10.0.0.1"},
+	} {
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(settings)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return wordpress0, mysql0
+}
+
+func (s *applicationSuite) TestApplicationsInfo(c *gc.C) {
+	s.setupRelatedUnits(c)
+	results, err := s.applicationAPI.APIv9.ApplicationsInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-unknown"},
+			{Tag: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	info := results.Results[0].Result
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(info.Tag, gc.Equals, "application-wordpress")
+	c.Assert(info.Charm, gc.Equals, "local:quantal/wordpress-3")
+	c.Assert(info.Principal, jc.IsTrue)
+	c.Assert(info.Life, gc.Equals, "alive")
+	c.Assert(info.Relations, jc.DeepEquals, map[string][]string{
+		"db": {"mysql"},
+	})
+	_, ok := info.EndpointBindings["db"]
+	c.Assert(ok, jc.IsTrue)
+	var endpoints []string
+	for _, ep := range info.Endpoints {
+		endpoints = append(endpoints, ep.Name)
+	}
+	c.Assert(endpoints, jc.SameContents, []string{
+		"cache", "db", "juju-info", "logging-dir", "monitoring-port", "url",
+	})
+
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+}
+
+func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
+	s.setupRelatedUnits(c)
+	results, err := s.applicationAPI.APIv9.UnitsInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-wordpress-0"},
+			{Tag: "unit-wordpress-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+
+	info := results.Results[0].Result
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(info.Tag, gc.Equals, "unit-wordpress-0")
+	c.Assert(info.Charm, gc.Equals, "local:quantal/wordpress-3")
+	c.Assert(info.Machine, gc.Equals, "")
+	c.Assert(info.RelationData, gc.HasLen, 1)
+	data := info.RelationData[0]
+	c.Assert(data.Endpoint, gc.Equals, "db")
+	c.Assert(data.RelatedEndpoint, gc.Equals, "server")
+	c.Assert(data.CrossModel, jc.IsFalse)
+	c.Assert(data.UnitRelationData, jc.DeepEquals, map[string]params.RelationData{
+		"wordpress/0": {InScope: true, UnitData: map[string]interface{}{"url": "http://wordpress"}},
+		"mysql/0":     {InScope: true, UnitData: map[string]interface{}{"host": "10.0.0.1"}},
+	})
+	c.Assert(info.WorkloadStatusHistory, gc.Not(gc.HasLen), 0)
+	c.Assert(info.DeferredEvents, gc.HasLen, 0)
+
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "wordpress/1" not found`)
+}
+
+func (s *applicationSuite) TestUnitsInfoDeferredEvents(c *gc.C) {
+	s.setupRelatedUnits(c)
+	unit, err := s.State.Unit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	at := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	err = unit.SetDeferredEvents([]state.DeferredEvent{{Name: "check-again", Time: at}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.APIv9.UnitsInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	deferred := results.Results[0].Result.DeferredEvents
+	c.Assert(deferred, gc.HasLen, 1)
+	c.Assert(deferred[0].Name, gc.Equals, "check-again")
+	c.Assert(deferred[0].Time.Equal(at), jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetBindings(c *gc.C) {
+	_, err := s.State.AddSpace("public", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	results, err := s.applicationAPI.APIv9.SetBindings(params.ApplicationSetBindingsArgs{
+		Args: []params.ApplicationSetBindings{{
+			ApplicationTag: "application-wordpress",
+			Bindings:       map[string]string{"url": "public"},
+		}, {
+			ApplicationTag: "application-wordpress",
+			Bindings:       map[string]string{"url": "missing"},
+		}, {
+			ApplicationTag: "application-mysql",
+			Bindings:       map[string]string{"server": "public"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot set endpoint bindings for application "wordpress": unknown space "missing" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "mysql" not found`)
+
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["url"], gc.Equals, "public")
+}
@@ -39,6 +39,7 @@ type ApplicationSuite struct {
 	relation           mockRelation
 	application        mockApplication
 	storagePoolManager *mockStoragePoolManager
+	caasBroker         mockCaasBroker
 
 	env          environs.Environ
 	blockChecker mockBlockChecker
@@ -65,10 +66,11 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 			return nil, nil
 		},
 		s.storagePoolManager,
+		&s.caasBroker,
 		common.NewResources(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv8{&application.APIv9{api}}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -184,6 +186,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.caasBroker = mockCaasBroker{}
 	s.setAPIUser(c, names.NewUserTag("admin"))
 }
 
@@ -636,7 +639,61 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "Scale", 5)
+	app.CheckCallNames(c, "ApplicationConfig", "Scale")
+	app.CheckCall(c, 1, "Scale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas": 5,
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results, jc.DeepEquals, params.ScaleApplicationResults{
+		Results: []params.ScaleApplicationResult{{
+			Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: `scaling autoscaled application "postgresql" not supported`,
+			},
+		}},
+	})
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsDaemon(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.podSpecs = map[string]string{
+		"postgresql": `
+deploymentType: daemon
+containers:
+  - name: postgresql
+    image: postgresql/latest
+`[1:],
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results, jc.DeepEquals, params.ScaleApplicationResults{
+		Results: []params.ScaleApplicationResult{{
+			Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: `scaling daemon application "postgresql" not supported`,
+			},
+		}},
+	})
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsIAASModel(c *gc.C) {
@@ -1156,18 +1213,95 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddHookLimitSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddUpdateStatusSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookRecordingSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookRetrySchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 2, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas": 5,
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-autoscaling-min-replicas": "6",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "autoscaling between 6 and 5 replicas not valid")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookRetryBackoff(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"hook-retry-min-backoff": "10m",
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"hook-retry-max-backoff": "5m",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		"hook-retry-min-backoff 10m0s greater than hook-retry-max-backoff 5m0s not valid")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookRetryDuration(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"hook-retry-max-attempts": "3",
+				"hook-retry-min-backoff":  "soon",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `hook-retry-min-backoff "soon" not valid`)
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestUnsetApplicationConfigInvalidAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas":  5,
+		"kubernetes-autoscaling-metric":        "requests-per-second",
+		"kubernetes-autoscaling-metric-target": "100",
+	}
+	result, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
+		Args: []params.ApplicationUnset{{
+			ApplicationName: "postgresql",
+			Options:         []string{"kubernetes-autoscaling-metric-target"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `autoscaling metric "requests-per-second" target "" not valid`)
+	app.CheckCallNames(c, "ApplicationConfig")
 }
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
@@ -1200,17 +1334,25 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddHookLimitSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddUpdateStatusSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookRecordingSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	schema, err = application.AddHookRetrySchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
+	app.CheckCall(c, 2, "UpdateCharmConfig", charm.Settings{"stringVal": nil})
 }
 
 func (s *ApplicationSuite) TestBlockUnsetApplicationConfig(c *gc.C) {
@@ -1348,3 +1490,68 @@ func (s *ApplicationSuite) TestGetLXDProfileUpgradeMessages(c *gc.C) {
 		},
 	})
 }
+
+func (s *ApplicationSuite) TestRolloutStatus(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.caasBroker.rollout = &caas.RolloutStatus{
+		Generation: 4, Desired: 3, Updated: 1, Ready: 3, Message: "1 of 3 pods updated",
+	}
+	s.caasBroker.SetErrors(nil, errors.NotFoundf("pods for application %q", "mysql"))
+	results, err := s.api.APIv9.RolloutStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.RolloutStatusResult{
+		Result: &params.RolloutStatus{
+			Generation: 4, Desired: 3, Updated: 1, Ready: 3, Message: "1 of 3 pods updated",
+		},
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.caasBroker.CheckCalls(c, []testing.StubCall{
+		{"RolloutStatus", []interface{}{"postgresql"}},
+		{"RolloutStatus", []interface{}{"mysql"}},
+	})
+}
+
+func (s *ApplicationSuite) TestRolloutStatusNotSupported(c *gc.C) {
+	_, err := s.api.APIv9.RolloutStatus(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "rollout status on a non-container model not supported")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAbortRollout(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.caasBroker.SetErrors(errors.NotSupportedf("aborting the rollout of application %q which is not stateless", "postgresql"))
+	results, err := s.api.APIv9.AbortRollout(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `aborting the rollout of application "postgresql" which is not stateless not supported`)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	s.caasBroker.CheckCalls(c, []testing.StubCall{
+		{"AbortRollout", []interface{}{"postgresql"}},
+		{"AbortRollout", []interface{}{"mysql"}},
+	})
+}
+
+func (s *ApplicationSuite) TestAbortRolloutBlocked(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.APIv9.AbortRollout(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.caasBroker.CheckNoCalls(c)
+}
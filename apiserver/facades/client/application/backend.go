@@ -11,12 +11,14 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 )
 
@@ -26,6 +28,7 @@ import (
 type Backend interface {
 	AllModelUUIDs() ([]string, error)
 	Application(string) (Application, error)
+	ApplicationLeaders() (map[string]string, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
 	RemoteApplication(string) (RemoteApplication, error)
@@ -43,6 +46,7 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	PodSpec(names.ApplicationTag) (string, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -69,10 +73,18 @@ type Application interface {
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
 	Endpoints() ([]state.Endpoint, error)
+	EndpointBindings() (map[string]string, error)
+	IsExposed() bool
 	IsPrincipal() bool
+	IsRemote() bool
+	Life() state.Life
+	Name() string
+	Relations() ([]Relation, error)
 	Series() string
+	StatusHistory(status.StatusHistoryFilter) ([]status.StatusInfo, error)
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEndpointBindings(map[string]string, bool) error
 	SetExposed() error
 	SetCharmProfile(string) error
 	WatchLXDProfileUpgradeNotifications() (state.NotifyWatcher, error)
@@ -113,9 +125,25 @@ type Relation interface {
 	Tag() names.Tag
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
+	Endpoints() []state.Endpoint
+	Id() int
+	IsCrossModel() (bool, error)
+	RelatedEndpoints(string) ([]state.Endpoint, error)
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
+	Unit(string) (RelationUnit, error)
+	AllRemoteUnits(string) ([]RelationUnit, error)
+}
+
+// RelationUnit defines a subset of the functionality provided by the
+// state.RelationUnit type, as required by the application facade. For
+// details on the methods, see the methods on state.RelationUnit with
+// the same names.
+type RelationUnit interface {
+	UnitName() string
+	InScope() (bool, error)
+	ReadSettings(string) (map[string]interface{}, error)
 }
 
 // Unit defines a subset of the functionality provided by the
@@ -126,11 +154,20 @@ type Unit interface {
 	Name() string
 	Tag() names.Tag
 	UnitTag() names.UnitTag
+	ApplicationName() string
+	CharmURL() (*charm.URL, bool)
 	Destroy() error
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	AgentHistory() status.StatusHistoryGetter
+	OpenedPorts() ([]network.PortRange, error)
+	PublicAddress() (network.Address, error)
+	RelationsInScope() ([]Relation, error)
+	StatusHistory(status.StatusHistoryFilter) ([]status.StatusInfo, error)
+	WorkloadVersion() (string, error)
+	DeferredEvents() []state.DeferredEvent
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
@@ -153,9 +190,18 @@ type Model interface {
 // state.Resources type, as required by the application facade. See
 // the state.Resources type for details on the methods.
 type Resources interface {
+	ListResources(string) (resource.ApplicationResources, error)
 	RemovePendingAppResources(string, map[string]string) error
 }
 
+// CAASBroker defines the functionality of the caas.Broker used by
+// the application facade to follow and abort the rollout of pod
+// spec changes in Kubernetes models.
+type CAASBroker interface {
+	RolloutStatus(appName string) (*caas.RolloutStatus, error)
+	AbortRollout(appName string) error
+}
+
 type stateShim struct {
 	*state.State
 }
@@ -221,6 +267,18 @@ func CharmToStateCharm(ch Charm) *state.Charm {
 	return ch.(stateCharmShim).Charm
 }
 
+func (s stateShim) PodSpec(tag names.ApplicationTag) (string, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return "", err
+	}
+	cm, err := m.CAASModel()
+	if err != nil {
+		return "", err
+	}
+	return cm.PodSpec(tag)
+}
+
 func (s stateShim) Application(name string) (Application, error) {
 	a, err := s.State.Application(name)
 	if err != nil {
@@ -266,7 +324,7 @@ func (s stateShim) AddRelation(eps ...state.Endpoint) (Relation, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error) {
@@ -287,7 +345,7 @@ func (s stateShim) EndpointsRelation(eps ...state.Endpoint) (Relation, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) Relation(id int) (Relation, error) {
@@ -295,7 +353,7 @@ func (s stateShim) Relation(id int) (Relation, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stateRelationShim{r}, nil
+	return stateRelationShim{r, s.State}, nil
 }
 
 func (s stateShim) Machine(name string) (Machine, error) {
@@ -369,6 +427,18 @@ func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	return out, nil
 }
 
+func (a stateApplicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = stateRelationShim{r, a.st}
+	}
+	return out, nil
+}
+
 type stateCharmShim struct {
 	*state.Charm
 }
@@ -379,6 +449,31 @@ type stateMachineShim struct {
 
 type stateRelationShim struct {
 	*state.Relation
+	st *state.State
+}
+
+func (r stateRelationShim) Unit(unitName string) (RelationUnit, error) {
+	u, err := r.st.Unit(unitName)
+	if err != nil {
+		return nil, err
+	}
+	ru, err := r.Relation.Unit(u)
+	if err != nil {
+		return nil, err
+	}
+	return ru, nil
+}
+
+func (r stateRelationShim) AllRemoteUnits(appName string) ([]RelationUnit, error) {
+	rus, err := r.Relation.AllRemoteUnits(appName)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]RelationUnit, len(rus))
+	for i, ru := range rus {
+		out[i] = ru
+	}
+	return out, nil
 }
 
 type stateUnitShim struct {
@@ -394,6 +489,18 @@ func (u stateUnitShim) AssignWithPlacement(placement *instance.Placement) error
 	return u.st.AssignUnitWithPlacement(u.Unit, placement)
 }
 
+func (u stateUnitShim) RelationsInScope() ([]Relation, error) {
+	relations, err := u.Unit.RelationsInScope()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, r := range relations {
+		out[i] = stateRelationShim{r, u.st}
+	}
+	return out, nil
+}
+
 type Subnet interface {
 	CIDR() string
 	VLANTag() int
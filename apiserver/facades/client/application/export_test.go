@@ -9,6 +9,10 @@ var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
 	GetStorageState         = getStorageState
+	AddHookLimitSchema      = addHookLimitSchema
+	AddUpdateStatusSchema   = addUpdateStatusSchema
+	AddHookRecordingSchema  = addHookRecordingSchema
+	AddHookRetrySchema      = addHookRetrySchema
 )
 
 func GetState(st *state.State) Backend {
@@ -56,10 +56,11 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		application.CharmToStateCharm,
 		application.DeployApplication,
 		&mockStoragePoolManager{},
+		nil,
 		common.NewResources(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv8{api}
+	s.applicationAPI = &application.APIv8{&application.APIv9{api}}
 }
 
 func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
@@ -127,7 +128,58 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmoketest(c *gc.C) {
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
-			}},
+			},
+			"hook-timeout": map[string]interface{}{
+				"description": "The maximum time a hook may run for before it is killed, eg 10m",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-memory-limit": map[string]interface{}{
+				"description": "The maximum memory a hook may use before it is killed, eg 512M",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-cpu-limit": map[string]interface{}{
+				"description": "The maximum percentage of a CPU a hook may use, eg 50%",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the update-status hook, overriding the model setting; 0 disables it",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-recording": map[string]interface{}{
+				"description": "Which hooks to record for replay with juju debug-replay: \"failed\" or \"all\"",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The maximum number of times a failed hook is retried automatically; 0 means no limit",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"hook-retry-min-backoff": map[string]interface{}{
+				"description": "The delay before a failed hook is first retried, eg 5s",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-max-backoff": map[string]interface{}{
+				"description": "The longest delay between retries of a failed hook, eg 5m",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds to retry automatically, eg install,config-changed; unset retries all",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-exclude-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds never to retry automatically, eg upgrade-charm",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+		},
 		Series: "quantal",
 	})
 }
@@ -143,6 +195,14 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 
+	schemaFields, err = application.AddHookLimitSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
+	schemaFields, err = application.AddUpdateStatusSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
+	schemaFields, err = application.AddHookRecordingSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
+	schemaFields, err = application.AddHookRetrySchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -195,10 +255,11 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 		application.CharmToStateCharm,
 		application.DeployApplication,
 		&mockStoragePoolManager{},
+		nil,
 		common.NewResources(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{api}
+	apiV8 := &application.APIv8{&application.APIv9{api}}
 
 	results, err := apiV8.Get(params.ApplicationGet{"dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -278,6 +339,56 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "The maximum time a hook may run for before it is killed, eg 10m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-memory-limit": map[string]interface{}{
+				"description": "The maximum memory a hook may use before it is killed, eg 512M",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-cpu-limit": map[string]interface{}{
+				"description": "The maximum percentage of a CPU a hook may use, eg 50%",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the update-status hook, overriding the model setting; 0 disables it",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-recording": map[string]interface{}{
+				"description": "Which hooks to record for replay with juju debug-replay: \"failed\" or \"all\"",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The maximum number of times a failed hook is retried automatically; 0 means no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-min-backoff": map[string]interface{}{
+				"description": "The delay before a failed hook is first retried, eg 5s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-backoff": map[string]interface{}{
+				"description": "The longest delay between retries of a failed hook, eg 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds to retry automatically, eg install,config-changed; unset retries all",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-exclude-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds never to retry automatically, eg upgrade-charm",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -336,6 +447,56 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "The maximum time a hook may run for before it is killed, eg 10m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-memory-limit": map[string]interface{}{
+				"description": "The maximum memory a hook may use before it is killed, eg 512M",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-cpu-limit": map[string]interface{}{
+				"description": "The maximum percentage of a CPU a hook may use, eg 50%",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the update-status hook, overriding the model setting; 0 disables it",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-recording": map[string]interface{}{
+				"description": "Which hooks to record for replay with juju debug-replay: \"failed\" or \"all\"",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The maximum number of times a failed hook is retried automatically; 0 means no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-min-backoff": map[string]interface{}{
+				"description": "The delay before a failed hook is first retried, eg 5s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-backoff": map[string]interface{}{
+				"description": "The longest delay between retries of a failed hook, eg 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds to retry automatically, eg install,config-changed; unset retries all",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-exclude-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds never to retry automatically, eg upgrade-charm",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -353,6 +514,56 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"description": "The maximum time a hook may run for before it is killed, eg 10m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-memory-limit": map[string]interface{}{
+				"description": "The maximum memory a hook may use before it is killed, eg 512M",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-cpu-limit": map[string]interface{}{
+				"description": "The maximum percentage of a CPU a hook may use, eg 50%",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the update-status hook, overriding the model setting; 0 disables it",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-recording": map[string]interface{}{
+				"description": "Which hooks to record for replay with juju debug-replay: \"failed\" or \"all\"",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The maximum number of times a failed hook is retried automatically; 0 means no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-min-backoff": map[string]interface{}{
+				"description": "The delay before a failed hook is first retried, eg 5s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-max-backoff": map[string]interface{}{
+				"description": "The longest delay between retries of a failed hook, eg 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds to retry automatically, eg install,config-changed; unset retries all",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-exclude-hooks": map[string]interface{}{
+				"description": "Comma separated hook kinds never to retry automatically, eg upgrade-charm",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 	},
 }}
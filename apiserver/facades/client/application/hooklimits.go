@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var hookLimitFields = environschema.Fields{
+	application.HookTimeoutConfigOptionName: {
+		Description: "The maximum time a hook may run for before it is killed, eg 10m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookMemoryLimitConfigOptionName: {
+		Description: "The maximum memory a hook may use before it is killed, eg 512M",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookCPULimitConfigOptionName: {
+		Description: "The maximum percentage of a CPU a hook may use, eg 50%",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// addHookLimitSchema adds the hook limit schema fields to an existing
+// set of schema fields. Hook limits have no defaults; unset means
+// unlimited.
+func addHookLimitSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookLimitFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookLimitFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateHookLimits returns an error if any hook limits in the
+// supplied application config attributes cannot be parsed.
+func validateHookLimits(attrs map[string]interface{}) error {
+	_, err := application.ParseHookLimits(application.ConfigAttributes(attrs))
+	return errors.Trace(err)
+}
@@ -0,0 +1,42 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var hookRecordingFields = environschema.Fields{
+	application.HookRecordingConfigOptionName: {
+		Description: `Which hooks to record for replay with juju debug-replay: "failed" or "all"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// addHookRecordingSchema adds the hook recording schema fields to an
+// existing set of schema fields. Hooks are not recorded by default.
+func addHookRecordingSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookRecordingFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookRecordingFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateHookRecording returns an error if the hook recording mode in
+// the supplied application config attributes is not valid.
+func validateHookRecording(attrs map[string]interface{}) error {
+	_, err := application.ParseHookRecording(application.ConfigAttributes(attrs))
+	return errors.Trace(err)
+}
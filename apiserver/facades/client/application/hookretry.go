@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var hookRetryFields = environschema.Fields{
+	application.HookRetryMaxAttemptsConfigOptionName: {
+		Description: "The maximum number of times a failed hook is retried automatically; 0 means no limit",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMinBackoffConfigOptionName: {
+		Description: "The delay before a failed hook is first retried, eg 5s",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxBackoffConfigOptionName: {
+		Description: "The longest delay between retries of a failed hook, eg 5m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryHooksConfigOptionName: {
+		Description: "Comma separated hook kinds to retry automatically, eg install,config-changed; unset retries all",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryExcludeHooksConfigOptionName: {
+		Description: "Comma separated hook kinds never to retry automatically, eg upgrade-charm",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// addHookRetrySchema adds the hook retry policy schema fields to an
+// existing set of schema fields. There are no defaults; unset fields
+// leave the model's retry strategy in place.
+func addHookRetrySchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookRetryFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookRetryFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateHookRetryPolicy returns an error if the hook retry policy in
+// the supplied application config attributes is not valid. The
+// attributes must already be coerced by the application config schema.
+func validateHookRetryPolicy(attrs map[string]interface{}) error {
+	_, err := application.ParseHookRetryPolicy(application.ConfigAttributes(attrs))
+	return errors.Trace(err)
+}
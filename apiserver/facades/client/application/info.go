@@ -0,0 +1,405 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/resource"
+)
+
+// statusHistorySize is the number of recent status history entries
+// returned for each application and unit.
+const statusHistorySize = 10
+
+// ApplicationsInfo isn't on the v8 API.
+func (u *APIv8) ApplicationsInfo(_, _ struct{}) {}
+
+// ApplicationsInfo returns detailed information about the requested
+// applications.
+func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationInfoResults{}, errors.Trace(err)
+	}
+	leaders, err := api.backend.ApplicationLeaders()
+	if err != nil {
+		return params.ApplicationInfoResults{}, errors.Trace(err)
+	}
+	out := make([]params.ApplicationInfoResult, len(in.Entities))
+	for i, one := range in.Entities {
+		info, err := api.applicationInfo(one.Tag, leaders)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].Result = info
+	}
+	return params.ApplicationInfoResults{Results: out}, nil
+}
+
+func (api *APIBase) applicationInfo(tagString string, leaders map[string]string) (*params.ApplicationResult, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ApplicationResult{
+		Tag:       tag.String(),
+		Series:    app.Series(),
+		Channel:   string(app.Channel()),
+		Principal: app.IsPrincipal(),
+		Exposed:   app.IsExposed(),
+		Remote:    app.IsRemote(),
+		Life:      app.Life().String(),
+		Leader:    leaders[app.Name()],
+	}
+	if curl, _ := app.CharmURL(); curl != nil {
+		result.Charm = curl.String()
+	}
+	if result.Constraints, err = app.Constraints(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	endpoints, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, ep := range endpoints {
+		result.Endpoints = append(result.Endpoints, params.CharmRelation{
+			Name:      ep.Name,
+			Role:      string(ep.Role),
+			Interface: ep.Interface,
+			Optional:  ep.Optional,
+			Limit:     ep.Limit,
+			Scope:     string(ep.Scope),
+		})
+	}
+	if result.EndpointBindings, err = app.EndpointBindings(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if result.Relations == nil {
+			result.Relations = make(map[string][]string)
+		}
+		for _, relatedEp := range related {
+			result.Relations[ep.Name] = append(result.Relations[ep.Name], relatedEp.ApplicationName)
+		}
+		sort.Strings(result.Relations[ep.Name])
+	}
+
+	appResources, err := api.applicationResources(app.Name())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Resources = resourceSummaries(appResources.Resources)
+
+	history, err := app.StatusHistory(status.StatusHistoryFilter{Size: statusHistorySize})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.StatusHistory = statusHistoryParams(history, status.KindWorkload)
+	return result, nil
+}
+
+// UnitsInfo isn't on the v8 API.
+func (u *APIv8) UnitsInfo(_, _ struct{}) {}
+
+// UnitsInfo returns detailed information about the requested units,
+// including the relation settings of the units they are related to.
+func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	leaders, err := api.backend.ApplicationLeaders()
+	if err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	out := make([]params.UnitInfoResult, len(in.Entities))
+	for i, one := range in.Entities {
+		info, err := api.unitInfo(one.Tag, leaders)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].Result = info
+	}
+	return params.UnitInfoResults{Results: out}, nil
+}
+
+func (api *APIBase) unitInfo(tagString string, leaders map[string]string) (*params.UnitResult, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appName := unit.ApplicationName()
+	result := &params.UnitResult{
+		Tag:    tag.String(),
+		Life:   unit.Life().String(),
+		Leader: leaders[appName] == unit.Name(),
+	}
+	if curl, _ := unit.CharmURL(); curl != nil {
+		result.Charm = curl.String()
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	result.Machine = machineId
+	if result.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	addr, err := unit.PublicAddress()
+	if err != nil && !network.IsNoAddressError(err) {
+		return nil, errors.Trace(err)
+	}
+	result.PublicAddress = addr.Value
+
+	for _, event := range unit.DeferredEvents() {
+		result.DeferredEvents = append(result.DeferredEvents, params.DeferredEvent{
+			Name: event.Name,
+			Time: event.Time,
+		})
+	}
+
+	ports, err := unit.OpenedPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, port := range ports {
+		result.OpenedPorts = append(result.OpenedPorts, port.String())
+	}
+
+	if result.RelationData, err = api.unitRelationData(unit); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Storage, err = api.unitStorage(tag, machineId); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	appResources, err := api.applicationResources(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, unitResources := range appResources.UnitResources {
+		if unitResources.Tag == tag {
+			result.Resources = resourceSummaries(unitResources.Resources)
+		}
+	}
+
+	filter := status.StatusHistoryFilter{Size: statusHistorySize}
+	workloadHistory, err := unit.StatusHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.WorkloadStatusHistory = statusHistoryParams(workloadHistory, status.KindWorkload)
+	agentHistory, err := unit.AgentHistory().StatusHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.AgentStatusHistory = statusHistoryParams(agentHistory, status.KindUnitAgent)
+	return result, nil
+}
+
+// unitRelationData returns the settings of the unit, and of the units
+// it is related to, in each relation the unit is in scope of.
+func (api *APIBase) unitRelationData(unit Unit) ([]params.EndpointRelationData, error) {
+	relations, err := unit.RelationsInScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appName := unit.ApplicationName()
+	var result []params.EndpointRelationData
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		crossModel, err := rel.IsCrossModel()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ru, err := rel.Unit(unit.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err := ru.ReadSettings(unit.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data := params.EndpointRelationData{
+			RelationId: rel.Id(),
+			Endpoint:   ep.Name,
+			CrossModel: crossModel,
+			UnitRelationData: map[string]params.RelationData{
+				unit.Name(): {InScope: true, UnitData: settings},
+			},
+		}
+
+		related, err := rel.RelatedEndpoints(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, relatedEp := range related {
+			data.RelatedEndpoint = relatedEp.Name
+			relatedUnits, err := api.relatedUnits(rel, relatedEp.ApplicationName, crossModel)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, relatedUnit := range relatedUnits {
+				name := relatedUnit.UnitName()
+				if name == unit.Name() {
+					continue
+				}
+				// Reading the settings through the unit's own relation
+				// unit restricts them to the unit's scope, so units in
+				// other containers are skipped for container scoped
+				// relations.
+				settings, err := ru.ReadSettings(name)
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				inScope, err := relatedUnit.InScope()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				data.UnitRelationData[name] = params.RelationData{
+					InScope:  inScope,
+					UnitData: settings,
+				}
+			}
+		}
+		result = append(result, data)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RelationId < result[j].RelationId
+	})
+	return result, nil
+}
+
+// relatedUnits returns the relation units of the named application in
+// the relation. The units of a remote application are those that have
+// entered the relation's scope.
+func (api *APIBase) relatedUnits(rel Relation, appName string, crossModel bool) ([]RelationUnit, error) {
+	app, err := api.backend.Application(appName)
+	if errors.IsNotFound(err) && crossModel {
+		return rel.AllRemoteUnits(appName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]RelationUnit, 0, len(units))
+	for _, u := range units {
+		ru, err := rel.Unit(u.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, ru)
+	}
+	return result, nil
+}
+
+// unitStorage returns the storage attached to the unit.
+func (api *APIBase) unitStorage(tag names.UnitTag, machineId string) ([]params.StorageAttachmentDetails, error) {
+	attachments, err := api.storageAccess.UnitStorageAttachments(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var machineTag string
+	if machineId != "" {
+		machineTag = names.NewMachineTag(machineId).String()
+	}
+	result := make([]params.StorageAttachmentDetails, len(attachments))
+	for i, a := range attachments {
+		result[i] = params.StorageAttachmentDetails{
+			StorageTag: a.StorageInstance().String(),
+			UnitTag:    tag.String(),
+			MachineTag: machineTag,
+			Life:       params.Life(a.Life().String()),
+		}
+		if machineId == "" {
+			continue
+		}
+		info, err := storagecommon.StorageAttachmentInfo(
+			api.storageAccess,
+			api.storageAccess.VolumeAccess(),
+			api.storageAccess.FilesystemAccess(),
+			a,
+			names.NewMachineTag(machineId),
+		)
+		if err == nil {
+			result[i].Location = info.Location
+		} else if !errors.IsNotProvisioned(err) && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *APIBase) applicationResources(appName string) (resource.ApplicationResources, error) {
+	resources, err := api.backend.Resources()
+	if err != nil {
+		return resource.ApplicationResources{}, errors.Trace(err)
+	}
+	return resources.ListResources(appName)
+}
+
+func resourceSummaries(resources []resource.Resource) []params.ResourceSummary {
+	var result []params.ResourceSummary
+	for _, r := range resources {
+		result = append(result, params.ResourceSummary{
+			Name:     r.Name,
+			Type:     r.Type.String(),
+			Origin:   r.Origin.String(),
+			Revision: r.Revision,
+		})
+	}
+	return result
+}
+
+func statusHistoryParams(history []status.StatusInfo, kind status.HistoryKind) []params.DetailedStatus {
+	result := make([]params.DetailedStatus, len(history))
+	for i, h := range history {
+		result[i] = params.DetailedStatus{
+			Status: string(h.Status),
+			Info:   h.Message,
+			Data:   h.Data,
+			Since:  h.Since,
+			Kind:   string(kind),
+		}
+	}
+	return result
+}
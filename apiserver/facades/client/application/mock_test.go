@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/caas"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
@@ -272,6 +273,7 @@ type mockBackend struct {
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
+	podSpecs                   map[string]string
 }
 
 type mockFilesystemAccess struct {
@@ -513,6 +515,18 @@ func (m *mockBackend) RemoteApplication(name string) (application.RemoteApplicat
 	return app, nil
 }
 
+func (m *mockBackend) PodSpec(tag names.ApplicationTag) (string, error) {
+	m.MethodCall(m, "PodSpec", tag)
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	spec, ok := m.podSpecs[tag.Id()]
+	if !ok {
+		return "", errors.NotFoundf("pod spec for %v", tag)
+	}
+	return spec, nil
+}
+
 func (m *mockBackend) Application(name string) (application.Application, error) {
 	m.MethodCall(m, "Application", name)
 	if err := m.NextErr(); err != nil {
@@ -773,3 +787,21 @@ func (m *mockStoragePoolManager) Get(name string) (*storage.Config, error) {
 	}
 	return storage.NewConfig(name, storageType, map[string]interface{}{"foo": "bar"})
 }
+
+type mockCaasBroker struct {
+	jtesting.Stub
+	rollout *caas.RolloutStatus
+}
+
+func (m *mockCaasBroker) RolloutStatus(appName string) (*caas.RolloutStatus, error) {
+	m.MethodCall(m, "RolloutStatus", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rollout, nil
+}
+
+func (m *mockCaasBroker) AbortRollout(appName string) error {
+	m.MethodCall(m, "AbortRollout", appName)
+	return m.NextErr()
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var updateStatusFields = environschema.Fields{
+	application.UpdateStatusHookIntervalConfigOptionName: {
+		Description: "How often to run the update-status hook, overriding the model setting; 0 disables it",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// addUpdateStatusSchema adds the update-status schema fields to an
+// existing set of schema fields. There is no default; when unset the
+// model's update-status-hook-interval applies.
+func addUpdateStatusSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range updateStatusFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := updateStatusFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateUpdateStatusHookInterval returns an error if the
+// update-status hook interval in the supplied application config
+// attributes is not valid.
+func validateUpdateStatusHookInterval(attrs map[string]interface{}) error {
+	_, _, err := application.ParseUpdateStatusHookInterval(application.ConfigAttributes(attrs))
+	return errors.Trace(err)
+}
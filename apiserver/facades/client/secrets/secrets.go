@@ -0,0 +1,101 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the API for listing the secrets owned by
+// the applications in a model. It never reveals secret values.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods the secrets facade needs.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelUUID() string
+	Secrets(owner string) ([]*state.Secret, error)
+}
+
+// API is the concrete implementation of the secrets API.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new secrets API facade.
+func NewAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	return NewSecretsAPI(st, authorizer)
+}
+
+// NewSecretsAPI returns a new secrets API facade using the given
+// backend.
+func NewSecretsAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// checkCanAdmin returns an error unless the caller is an admin of the
+// model or a controller superuser.
+func (a *API) checkCanAdmin() error {
+	isSuperuser, err := a.authorizer.HasPermission(permission.SuperuserAccess, a.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isSuperuser {
+		return nil
+	}
+	isAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, names.NewModelTag(a.backend.ModelUUID()))
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the metadata of all the secrets in the model.
+// Secret values are never returned.
+func (a *API) ListSecrets() (params.ListSecretResults, error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return params.ListSecretResults{}, err
+	}
+	secrets, err := a.backend.Secrets("")
+	if err != nil {
+		return params.ListSecretResults{}, common.ServerError(err)
+	}
+	result := params.ListSecretResults{
+		Results: make([]params.SecretDetails, len(secrets)),
+	}
+	for i, secret := range secrets {
+		result.Results[i] = params.SecretDetails{
+			ID:             secret.ID(),
+			Owner:          secret.Owner(),
+			Name:           secret.Name(),
+			Description:    secret.Description(),
+			Revision:       secret.Revision(),
+			RotateInterval: secret.RotateInterval(),
+			NextRotateTime: secret.NextRotateTime(),
+			Grants:         secret.Grants(),
+			CreateTime:     secret.CreateTime(),
+			UpdateTime:     secret.UpdateTime(),
+		}
+	}
+	return result, nil
+}
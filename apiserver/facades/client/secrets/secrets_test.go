@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type secretsSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) newAPI(c *gc.C, tag names.Tag) (*secrets.API, error) {
+	auth := apiservertesting.FakeAuthorizer{Tag: tag}
+	return secrets.NewAPI(s.State, common.NewResources(), auth)
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.SaveSecret(state.SecretParams{
+		Owner:          "mysql",
+		Name:           "root-password",
+		Value:          "sekrit",
+		Description:    "the root password",
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := s.newAPI(c, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	details := result.Results[0]
+	c.Assert(details.ID, gc.Equals, "mysql/root-password")
+	c.Assert(details.Owner, gc.Equals, "mysql")
+	c.Assert(details.Name, gc.Equals, "root-password")
+	c.Assert(details.Description, gc.Equals, "the root password")
+	c.Assert(details.Revision, gc.Equals, 1)
+	c.Assert(details.RotateInterval, gc.Equals, time.Hour)
+	c.Assert(details.NextRotateTime, gc.NotNil)
+}
+
+func (s *secretsSuite) TestListSecretsRequiresAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Access: permission.ReadAccess})
+	api, err := s.newAPI(c, user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := s.newAPI(c, names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
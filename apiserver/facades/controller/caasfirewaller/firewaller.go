@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -16,6 +17,7 @@ import (
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
+	*common.ModelWatcher
 	resources facade.Resources
 	state     CAASFirewallerState
 }
@@ -53,8 +55,9 @@ func NewFacade(
 			resources,
 			accessApplication,
 		),
-		resources: resources,
-		state:     st,
+		ModelWatcher: common.NewModelWatcher(st, resources, authorizer),
+		resources:    resources,
+		state:        st,
 	}, nil
 }
 
@@ -123,3 +126,81 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// WatchApplicationsConfig starts a NotifyWatcher for each of the
+// specified applications, notifying when its config changes.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// RelatedApplications returns, for each of the specified applications,
+// the names of the other applications it is related to.
+func (f *Facade) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *Facade) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		endpoints, err := rel.RelatedEndpoints(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range endpoints {
+			if ep.ApplicationName != tag.Id() {
+				related.Add(ep.ApplicationName)
+			}
+		}
+	}
+	return related.SortedValues(), nil
+}
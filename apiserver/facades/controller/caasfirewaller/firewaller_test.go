@@ -6,6 +6,7 @@ package caasfirewaller_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1/workertest"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -26,6 +28,8 @@ type CAASFirewallerSuite struct {
 	st                  *mockState
 	applicationsChanges chan []string
 	appExposedChanges   chan struct{}
+	modelConfigChanges  chan struct{}
+	appConfigChanges    chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -37,17 +41,23 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
+	s.modelConfigChanges = make(chan struct{}, 1)
+	s.appConfigChanges = make(chan struct{}, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
 	s.st = &mockState{
 		application: mockApplication{
-			life:    state.Alive,
-			watcher: appExposedWatcher,
+			life:          state.Alive,
+			watcher:       appExposedWatcher,
+			configWatcher: statetesting.NewMockNotifyWatcher(s.appConfigChanges),
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		appExposedWatcher:   appExposedWatcher,
+		modelConfigWatcher:  statetesting.NewMockNotifyWatcher(s.modelConfigChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.modelConfigWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.configWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -103,6 +113,27 @@ func (s *CAASFirewallerSuite) TestWatchApplication(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.appExposedWatcher)
 }
 
+func (s *CAASFirewallerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.appConfigChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
+
 func (s *CAASFirewallerSuite) TestIsExposed(c *gc.C) {
 	s.st.application.exposed = true
 	results, err := s.facade.IsExposed(params.Entities{
@@ -158,3 +189,54 @@ func (s *CAASFirewallerSuite) TestApplicationConfig(c *gc.C) {
 	})
 	c.Assert(results.Results[0].Config, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 }
+
+func (s *CAASFirewallerSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.relations = []caasfirewaller.Relation{
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "gitlab"}, {ApplicationName: "mariadb"},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "gitlab"}, {ApplicationName: "redis"},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "gitlab", Relation: charm.Relation{Role: charm.RolePeer}},
+		}},
+	}
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mariadb", "redis"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *CAASFirewallerSuite) TestModelConfig(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"kubernetes-network-policies": true,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.modelConfig = cfg
+
+	result, err := s.facade.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["kubernetes-network-policies"], jc.IsTrue)
+}
+
+func (s *CAASFirewallerSuite) TestWatchForModelConfigChanges(c *gc.C) {
+	s.modelConfigChanges <- struct{}{}
+	result, err := s.facade.WatchForModelConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.modelConfigWatcher)
+}
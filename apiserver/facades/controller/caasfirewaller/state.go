@@ -13,6 +13,8 @@ import (
 // CAASUnitProvisionerState provides the subset of global state
 // required by the CAAS operator facade.
 type CAASFirewallerState interface {
+	state.ModelAccessor
+
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Application(string) (Application, error)
 	WatchApplications() state.StringsWatcher
@@ -24,6 +26,14 @@ type Application interface {
 	IsExposed() bool
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
+	WatchApplicationConfig() state.NotifyWatcher
+	Relations() ([]Relation, error)
+}
+
+// Relation provides the subset of relation state
+// required by the CAAS firewaller facade.
+type Relation interface {
+	RelatedEndpoints(applicationName string) ([]state.Endpoint, error)
 }
 
 type stateShim struct {
@@ -31,5 +41,25 @@ type stateShim struct {
 }
 
 func (s stateShim) Application(id string) (Application, error) {
-	return s.State.Application(id)
+	app, err := s.State.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = rel
+	}
+	return result, nil
 }
@@ -109,6 +109,8 @@ type mockApplication struct {
 	life         state.Life
 	scaleWatcher *statetesting.MockNotifyWatcher
 
+	configWatcher *statetesting.MockNotifyWatcher
+
 	tag        names.Tag
 	units      []caasunitprovisioner.Unit
 	ops        *state.UpdateUnitsOperation
@@ -135,11 +137,21 @@ func (a *mockApplication) WatchScale() state.NotifyWatcher {
 	return a.scaleWatcher
 }
 
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
 func (a *mockApplication) GetScale() int {
 	a.MethodCall(a, "GetScale")
 	return 5
 }
 
+func (a *mockApplication) Scale(scale int) error {
+	a.MethodCall(a, "Scale", scale)
+	return a.NextErr()
+}
+
 func (a *mockApplication) GetPlacement() string {
 	a.MethodCall(a, "GetPlacement")
 	return "placement"
@@ -150,6 +150,39 @@ func (f *Facade) watchApplicationScale(tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// WatchApplicationsConfig starts a NotifyWatcher for each of the
+// specified applications, notifying when its config changes.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // WatchPodSpec starts a NotifyWatcher to watch changes to the
 // pod spec for specified units in this model.
 func (f *Facade) WatchPodSpec(args params.Entities) (params.NotifyWatchResults, error) {
@@ -488,6 +521,15 @@ func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs)
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		// The scale is only reported when an autoscaler in the
+		// cloud, or the number of nodes for a daemon application,
+		// decides how many units the application has.
+		if appUpdate.Scale != nil && *appUpdate.Scale != app.GetScale() {
+			if err := app.Scale(*appUpdate.Scale); err != nil {
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
 		err = a.updateUnitsFromCloud(app, appUpdate.Units)
 		if err != nil {
 			// Mask any not found errors as the worker (caller) treats them specially
@@ -522,6 +564,13 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 			Status: status.Idle,
 		}
 		containerStatus = status.Running
+	case status.Waiting:
+		// A pod is running but its containers are not yet ready,
+		// so the workload waits on the container.
+		agentStatus = &status.StatusInfo{
+			Status: status.Idle,
+		}
+		containerStatus = status.Waiting
 	case status.Error:
 		agentStatus = &status.StatusInfo{
 			Status:  status.Error,
@@ -126,8 +126,9 @@ func (s *crossmodelRelationsSuite) assertPublishRelationsChanges(c *gc.C, life p
 					UnitId:   1,
 					Settings: map[string]interface{}{"foo": "bar"},
 				}},
-				DepartedUnits: []int{2},
-				Macaroons:     macaroon.Slice{mac},
+				DepartedUnits:       []int{2},
+				ApplicationSettings: map[string]interface{}{"shared": "value"},
+				Macaroons:           macaroon.Slice{mac},
 			},
 		},
 	})
@@ -158,7 +159,11 @@ func (s *crossmodelRelationsSuite) assertPublishRelationsChanges(c *gc.C, life p
 		ru1.CheckCalls(c, []testing.StubCall{
 			{"LeaveScope", []interface{}{}},
 		})
+		c.Assert(rel.appSettings, gc.HasLen, 0)
 	} else {
+		c.Assert(rel.appSettings, jc.DeepEquals, map[string]map[string]interface{}{
+			"db2": {"shared": "value"},
+		})
 		ru1.CheckCalls(c, []testing.StubCall{
 			{"InScope", []interface{}{}},
 			{"EnterScope", []interface{}{map[string]interface{}{"foo": "bar"}}},
@@ -312,12 +312,14 @@ type mockRelation struct {
 	status          status.Status
 	message         string
 	units           map[string]commoncrossmodel.RelationUnit
+	appSettings     map[string]map[string]interface{}
 }
 
 func newMockRelation(id int) *mockRelation {
 	return &mockRelation{
-		id:    id,
-		units: make(map[string]commoncrossmodel.RelationUnit),
+		id:          id,
+		units:       make(map[string]commoncrossmodel.RelationUnit),
+		appSettings: make(map[string]map[string]interface{}),
 	}
 }
 
@@ -388,6 +390,15 @@ func (r *mockRelation) AllRemoteUnits(appName string) ([]commoncrossmodel.Relati
 	return result, nil
 }
 
+func (r *mockRelation) ReplaceApplicationSettings(appName string, settings map[string]interface{}) error {
+	r.MethodCall(r, "ReplaceApplicationSettings", appName, settings)
+	if err := r.NextErr(); err != nil {
+		return err
+	}
+	r.appSettings[appName] = settings
+	return nil
+}
+
 func (r *mockRelation) Unit(unitId string) (commoncrossmodel.RelationUnit, error) {
 	r.MethodCall(r, "Unit", unitId)
 	if err := r.NextErr(); err != nil {
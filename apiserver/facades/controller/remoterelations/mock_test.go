@@ -227,6 +227,7 @@ type mockRelation struct {
 	remoteUnits           map[string]common.RelationUnit
 	endpoints             []state.Endpoint
 	endpointUnitsWatchers map[string]*mockRelationUnitsWatcher
+	appSettings           map[string]map[string]interface{}
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -236,6 +237,7 @@ func newMockRelation(id int) *mockRelation {
 		units:                 make(map[string]common.RelationUnit),
 		remoteUnits:           make(map[string]common.RelationUnit),
 		endpointUnitsWatchers: make(map[string]*mockRelationUnitsWatcher),
+		appSettings:           make(map[string]map[string]interface{}),
 	}
 }
 
@@ -283,6 +285,24 @@ func (r *mockRelation) RemoteUnit(unitId string) (common.RelationUnit, error) {
 	return u, nil
 }
 
+func (r *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
+	r.MethodCall(r, "Endpoint", appName)
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == appName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", appName)
+}
+
+func (r *mockRelation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	r.MethodCall(r, "ApplicationSettings", appName)
+	if err := r.NextErr(); err != nil {
+		return nil, err
+	}
+	return r.appSettings[appName], nil
+}
+
 func (r *mockRelation) Endpoints() []state.Endpoint {
 	r.MethodCall(r, "Endpoints")
 	return r.endpoints
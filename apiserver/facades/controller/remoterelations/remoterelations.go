@@ -137,41 +137,8 @@ func (api *RemoteRelationsAPI) RelationUnitSettings(relationUnits params.Relatio
 	results := params.SettingsResults{
 		Results: make([]params.SettingsResult, len(relationUnits.RelationUnits)),
 	}
-	one := func(ru params.RelationUnit) (params.Settings, error) {
-		relationTag, err := names.ParseRelationTag(ru.Relation)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		rel, err := api.st.KeyRelation(relationTag.Id())
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		unitTag, err := names.ParseUnitTag(ru.Unit)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		unit, err := rel.Unit(unitTag.Id())
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		settings, err := unit.Settings()
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		paramsSettings := make(params.Settings)
-		for k, v := range settings {
-			vString, ok := v.(string)
-			if !ok {
-				return nil, errors.Errorf(
-					"invalid relation setting %q: expected string, got %T", k, v,
-				)
-			}
-			paramsSettings[k] = vString
-		}
-		return paramsSettings, nil
-	}
 	for i, ru := range relationUnits.RelationUnits {
-		settings, err := one(ru)
+		settings, err := commoncrossmodel.RelationUnitSettings(api.st, ru)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
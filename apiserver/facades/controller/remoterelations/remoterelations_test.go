@@ -267,6 +267,22 @@ func (s *remoteRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	})
 }
 
+func (s *remoteRelationsSuite) TestRelationUnitSettingsApplication(c *gc.C) {
+	db2Relation := newMockRelation(123)
+	db2Relation.endpoints = []state.Endpoint{{ApplicationName: "django"}, {ApplicationName: "db2"}}
+	db2Relation.appSettings["django"] = map[string]interface{}{"key": "value"}
+	s.st.relations["db2:db django:db"] = db2Relation
+	result, err := s.api.RelationUnitSettings(params.RelationUnits{
+		RelationUnits: []params.RelationUnit{
+			{Relation: "relation-db2.db#django.db", Unit: "application-django"},
+			{Relation: "relation-db2.db#django.db", Unit: "application-other"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.SettingsResult{Settings: params.Settings{"key": "value"}})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `endpoint for "other" not found`)
+}
+
 func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
 	s.st.remoteApplications["django"] = newMockRemoteApplication("django", "me/model.riak")
 	result, err := s.api.RemoteApplications(params.Entities{Entities: []params.Entity{{Tag: "application-django"}}})
@@ -374,6 +374,10 @@ type RemoteRelationChangeEvent struct {
 	// the relation since the last change.
 	DepartedUnits []int `json:"departed-units,omitempty"`
 
+	// ApplicationSettings holds the application-level relation settings
+	// of the application identified by ApplicationToken, if they changed.
+	ApplicationSettings map[string]interface{} `json:"application-settings,omitempty"`
+
 	// Macaroons are used for authentication.
 	Macaroons macaroon.Slice `json:"macaroons,omitempty"`
 }
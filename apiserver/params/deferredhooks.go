@@ -0,0 +1,27 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// DeferredEvent holds a custom event that one of a unit's hooks has
+// asked to be delivered to a later hook.
+type DeferredEvent struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+}
+
+// EntityDeferredEvents holds the deferred events of an entity.
+type EntityDeferredEvents struct {
+	Tag    string          `json:"tag"`
+	Events []DeferredEvent `json:"events"`
+}
+
+// SetDeferredEventsArgs holds the parameters for recording the
+// deferred events of a set of units.
+type SetDeferredEventsArgs struct {
+	Entities []EntityDeferredEvents `json:"entities"`
+}
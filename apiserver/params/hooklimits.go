@@ -0,0 +1,27 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// HookLimits holds the resource limits applied to a unit's hooks.
+type HookLimits struct {
+	Timeout    time.Duration `json:"timeout,omitempty"`
+	MemoryMB   uint64        `json:"memory-mb,omitempty"`
+	CPUPercent int           `json:"cpu-percent,omitempty"`
+}
+
+// HookLimitsResult holds HookLimits or an error.
+type HookLimitsResult struct {
+	Error  *Error      `json:"error,omitempty"`
+	Result *HookLimits `json:"result,omitempty"`
+}
+
+// HookLimitsResults holds the bulk operation result of an API call
+// that returns HookLimits or an error.
+type HookLimitsResults struct {
+	Results []HookLimitsResult `json:"results"`
+}
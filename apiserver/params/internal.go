@@ -300,6 +300,11 @@ type RelationUnitSettings struct {
 	Relation string   `json:"relation"`
 	Unit     string   `json:"unit"`
 	Settings Settings `json:"settings"`
+
+	// ApplicationSettings holds changes to the application-level
+	// settings of the unit's application in the relation. Only the
+	// leader may change them.
+	ApplicationSettings Settings `json:"application-settings,omitempty"`
 }
 
 // RelationUnitsSettings holds the arguments for making a EnterScope
@@ -568,6 +573,10 @@ type RelationUnitsChange struct {
 	// latest known settings version for each.
 	Changed map[string]UnitSettings `json:"changed"`
 
+	// AppChanged holds the latest known version of the application
+	// settings of each watched application that has any.
+	AppChanged map[string]int64 `json:"app-changed,omitempty"`
+
 	// Departed holds a set of units that have previously been reported to
 	// be in scope, but which no longer are.
 	Departed []string `json:"departed,omitempty"`
@@ -598,6 +598,7 @@ type UpdateApplicationUnitArgs struct {
 // UpdateApplicationUnits holds unit parameters for a specified application.
 type UpdateApplicationUnits struct {
 	ApplicationTag string                  `json:"application-tag"`
+	Scale          *int                    `json:"scale,omitempty"`
 	Units          []ApplicationUnitParams `json:"units"`
 }
 
@@ -811,6 +812,9 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsKey wraps the keys encrypting each model's secrets.
+	// It is held only by the controllers, never in the database.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -1347,6 +1351,144 @@ type ScaleApplicationInfo struct {
 	Scale int `json:"num-units"`
 }
 
+// ApplicationSetBindingsArgs holds the parameters for the
+// Application.SetBindings call.
+type ApplicationSetBindingsArgs struct {
+	Args []ApplicationSetBindings `json:"args"`
+}
+
+// ApplicationSetBindings holds the endpoint bindings to set for a
+// single application. An empty endpoint name sets the default space
+// for the application's endpoints.
+type ApplicationSetBindings struct {
+	ApplicationTag string            `json:"application-tag"`
+	Bindings       map[string]string `json:"bindings"`
+
+	// Force skips checking that the machines hosting the
+	// application's units are connected to the new spaces.
+	Force bool `json:"force"`
+}
+
+// RolloutStatusResults holds the results of an Application.RolloutStatus call.
+type RolloutStatusResults struct {
+	Results []RolloutStatusResult `json:"results"`
+}
+
+// RolloutStatusResult holds the rollout progress of a single
+// application, or an error.
+type RolloutStatusResult struct {
+	Result *RolloutStatus `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// RolloutStatus describes the progress of replacing the pods of a
+// Kubernetes application after its pod spec changed.
+type RolloutStatus struct {
+	Generation int64  `json:"generation"`
+	Desired    int    `json:"desired"`
+	Updated    int    `json:"updated"`
+	Ready      int    `json:"ready"`
+	Complete   bool   `json:"complete"`
+	Failed     bool   `json:"failed"`
+	Message    string `json:"message,omitempty"`
+}
+
+// ApplicationInfoResults holds the results of an ApplicationsInfo call.
+type ApplicationInfoResults struct {
+	Results []ApplicationInfoResult `json:"results"`
+}
+
+// ApplicationInfoResult holds the information about a single
+// application, or an error.
+type ApplicationInfoResult struct {
+	Result *ApplicationResult `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ApplicationResult holds detailed information about an application,
+// as returned by the ApplicationsInfo call.
+type ApplicationResult struct {
+	Tag              string            `json:"tag"`
+	Charm            string            `json:"charm,omitempty"`
+	Series           string            `json:"series,omitempty"`
+	Channel          string            `json:"channel,omitempty"`
+	Constraints      constraints.Value `json:"constraints,omitempty"`
+	Principal        bool              `json:"principal"`
+	Exposed          bool              `json:"exposed"`
+	Remote           bool              `json:"remote"`
+	Life             string            `json:"life,omitempty"`
+	Leader           string            `json:"leader,omitempty"`
+	Endpoints        []CharmRelation   `json:"endpoints,omitempty"`
+	EndpointBindings map[string]string `json:"endpoint-bindings,omitempty"`
+
+	// Relations maps each endpoint of the application to the names of
+	// the applications related on that endpoint.
+	Relations map[string][]string `json:"relations,omitempty"`
+
+	Resources     []ResourceSummary `json:"resources,omitempty"`
+	StatusHistory []DetailedStatus  `json:"status-history,omitempty"`
+}
+
+// ResourceSummary holds the revision of a resource in use by an
+// application or unit.
+type ResourceSummary struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Origin   string `json:"origin"`
+	Revision int    `json:"revision"`
+}
+
+// UnitInfoResults holds the results of a UnitsInfo call.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
+
+// UnitInfoResult holds the information about a single unit, or an
+// error.
+type UnitInfoResult struct {
+	Result *UnitResult `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// UnitResult holds detailed information about a unit, as returned by
+// the UnitsInfo call.
+type UnitResult struct {
+	Tag             string                     `json:"tag"`
+	Charm           string                     `json:"charm,omitempty"`
+	Machine         string                     `json:"machine,omitempty"`
+	Leader          bool                       `json:"leader"`
+	Life            string                     `json:"life,omitempty"`
+	WorkloadVersion string                     `json:"workload-version,omitempty"`
+	PublicAddress   string                     `json:"public-address,omitempty"`
+	OpenedPorts     []string                   `json:"opened-ports,omitempty"`
+	RelationData    []EndpointRelationData     `json:"relation-data,omitempty"`
+	Storage         []StorageAttachmentDetails `json:"storage,omitempty"`
+	Resources       []ResourceSummary          `json:"resources,omitempty"`
+	DeferredEvents  []DeferredEvent            `json:"deferred-events,omitempty"`
+
+	WorkloadStatusHistory []DetailedStatus `json:"workload-status-history,omitempty"`
+	AgentStatusHistory    []DetailedStatus `json:"agent-status-history,omitempty"`
+}
+
+// EndpointRelationData holds the settings of the units in a relation
+// the unit takes part in, as seen from the unit's endpoint.
+type EndpointRelationData struct {
+	RelationId      int    `json:"relation-id"`
+	Endpoint        string `json:"endpoint"`
+	CrossModel      bool   `json:"cross-model"`
+	RelatedEndpoint string `json:"related-endpoint"`
+
+	// UnitRelationData holds the settings of the unit itself and of
+	// each unit on the other side of the relation, keyed by unit name.
+	UnitRelationData map[string]RelationData `json:"unit-relation-data"`
+}
+
+// RelationData holds the relation settings of a single unit.
+type RelationData struct {
+	InScope  bool                   `json:"in-scope"`
+	UnitData map[string]interface{} `json:"data"`
+}
+
 // DumpModelRequest wraps the request for a dump-model call.
 // A simplified dump will not contain a complete export, but instead
 // a reduced set that is determined by the server.
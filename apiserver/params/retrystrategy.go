@@ -15,6 +15,18 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxAttempts is the maximum number of automatic retries of a
+	// failed hook; 0 means no limit.
+	MaxAttempts int `json:"max-attempts,omitempty"`
+
+	// RetryHooks holds the hook kinds that are retried automatically.
+	// If empty, all hook kinds not in ExcludeHooks are retried.
+	RetryHooks []string `json:"retry-hooks,omitempty"`
+
+	// ExcludeHooks holds the hook kinds that are never retried
+	// automatically.
+	ExcludeHooks []string `json:"exclude-hooks,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
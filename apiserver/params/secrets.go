@@ -0,0 +1,98 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// SaveSecretArg holds a secret to create or update, owned by the
+// application of the given unit.
+type SaveSecretArg struct {
+	UnitTag        string        `json:"unit-tag"`
+	Name           string        `json:"name"`
+	Value          string        `json:"value"`
+	Description    string        `json:"description,omitempty"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+}
+
+// SaveSecretArgs holds the parameters for saving secrets.
+type SaveSecretArgs struct {
+	Args []SaveSecretArg `json:"args"`
+}
+
+// GetSecretValueArg identifies a secret whose value the given
+// unit wants to read.
+type GetSecretValueArg struct {
+	UnitTag string `json:"unit-tag"`
+	ID      string `json:"id"`
+}
+
+// GetSecretValueArgs holds the parameters for reading secret values.
+type GetSecretValueArgs struct {
+	Args []GetSecretValueArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret, owned by the application of the
+// given unit, to which the remote application on the given relation
+// is to be granted access.
+type GrantSecretArg struct {
+	UnitTag     string `json:"unit-tag"`
+	ID          string `json:"id"`
+	RelationTag string `json:"relation-tag"`
+}
+
+// GrantSecretArgs holds the parameters for granting access to secrets.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// SecretRotatedArg records that the given unit has rotated a secret.
+type SecretRotatedArg struct {
+	UnitTag string `json:"unit-tag"`
+	ID      string `json:"id"`
+}
+
+// SecretRotatedArgs holds the parameters for recording secret rotations.
+type SecretRotatedArgs struct {
+	Args []SecretRotatedArg `json:"args"`
+}
+
+// SecretRotation holds when a secret is next due to be rotated.
+type SecretRotation struct {
+	ID             string    `json:"id"`
+	NextRotateTime time.Time `json:"next-rotate-time"`
+}
+
+// SecretRotationsResult holds the rotation times of the secrets owned
+// by an entity's application.
+type SecretRotationsResult struct {
+	Rotations []SecretRotation `json:"rotations,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// SecretRotationsResults holds SecretRotationsResult structs.
+type SecretRotationsResults struct {
+	Results []SecretRotationsResult `json:"results"`
+}
+
+// SecretDetails holds the metadata of a secret. It never holds the
+// secret's value.
+type SecretDetails struct {
+	ID             string        `json:"id"`
+	Owner          string        `json:"owner"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description,omitempty"`
+	Revision       int           `json:"revision"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `json:"next-rotate-time,omitempty"`
+	Grants         []string      `json:"grants,omitempty"`
+	CreateTime     time.Time     `json:"create-time"`
+	UpdateTime     time.Time     `json:"update-time"`
+}
+
+// ListSecretResults holds the metadata of a model's secrets.
+type ListSecretResults struct {
+	Results []SecretDetails `json:"results"`
+}
@@ -0,0 +1,22 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// UpdateStatusHookIntervalResult holds the interval at which a unit
+// should run its update-status hook, or an error. A zero interval
+// means update-status is disabled.
+type UpdateStatusHookIntervalResult struct {
+	Error    *Error        `json:"error,omitempty"`
+	Interval time.Duration `json:"interval"`
+}
+
+// UpdateStatusHookIntervalResults holds the bulk operation result of
+// an API call that returns update-status hook intervals.
+type UpdateStatusHookIntervalResults struct {
+	Results []UpdateStatusHookIntervalResult `json:"results"`
+}
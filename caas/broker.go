@@ -137,6 +137,14 @@ type Broker interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureNetworkPolicy creates or updates the network policy
+	// restricting ingress to the pods of the specified application.
+	EnsureNetworkPolicy(appName string, resourceTags map[string]string, policy NetworkPolicy) error
+
+	// DeleteNetworkPolicy deletes the network policy of the specified
+	// application, leaving ingress to its pods unrestricted.
+	DeleteNetworkPolicy(appName string) error
+
 	// WatchUnits returns a watcher which notifies when there
 	// are changes to units of the specified application.
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
@@ -146,6 +154,12 @@ type Broker interface {
 	// via volumes bound to the unit.
 	Units(appName string) ([]Unit, error)
 
+	// DaemonScale returns the number of nodes the pods of the
+	// specified daemon application are to run on, which decides how
+	// many units it has. A NotFound error is returned if the
+	// application is not deployed as a daemon.
+	DaemonScale(appName string) (int, error)
+
 	// WatchOperator returns a watcher which notifies when there
 	// are changes to the operator of the specified application.
 	WatchOperator(string) (watcher.NotifyWatcher, error)
@@ -153,6 +167,25 @@ type Broker interface {
 	// Operator returns an Operator with current status and life details.
 	Operator(string) (*Operator, error)
 
+	// RolloutStatus returns the progress of replacing the pods of
+	// the specified application after its pod spec changed.
+	RolloutStatus(appName string) (*RolloutStatus, error)
+
+	// AbortRollout stops the current rollout of the specified
+	// application, returning its pods to the previous pod spec.
+	AbortRollout(appName string) error
+
+	// AutoscalerStatus returns the number of pods chosen for the
+	// specified application by its autoscaler. A NotFound error is
+	// returned if the application is not autoscaled.
+	AutoscalerStatus(appName string) (*AutoscalerStatus, error)
+
+	// ApplicationWarning returns the status of the specified application
+	// when the substrate cannot create its pods, eg because a resource
+	// quota is exceeded. A NotFound error is returned if there is no
+	// such problem.
+	ApplicationWarning(appName string) (*status.StatusInfo, error)
+
 	// ProviderRegistry is an interface for obtaining storage providers.
 	storage.ProviderRegistry
 
@@ -211,6 +244,61 @@ type Operator struct {
 	Status status.StatusInfo
 }
 
+// NetworkPolicy describes where ingress to the pods of an
+// application is allowed from. The application's own pods and
+// its operator are always allowed.
+type NetworkPolicy struct {
+	// AllowedApplications are the applications, usually those
+	// related to the application, whose pods may connect to it.
+	AllowedApplications []string
+
+	// AllowAll allows ingress from anywhere, as when the
+	// application is exposed.
+	AllowAll bool
+}
+
+// RolloutStatus represents the progress of replacing the pods of an
+// application after its pod spec changed.
+type RolloutStatus struct {
+	// Generation increments each time the pod spec or the number
+	// of pods of the application changes.
+	Generation int64
+
+	// Desired is the number of pods the application should have.
+	Desired int
+
+	// Updated is the number of pods running the latest pod spec.
+	Updated int
+
+	// Ready is the number of pods which are ready.
+	Ready int
+
+	// Complete is true once all pods run the latest pod
+	// spec and are ready.
+	Complete bool
+
+	// Failed is true if the rollout stopped making progress.
+	Failed bool
+
+	// Message describes the progress of the rollout.
+	Message string
+}
+
+// AutoscalerStatus represents the number of pods an autoscaler
+// has chosen for an application.
+type AutoscalerStatus struct {
+	// MinReplicas and MaxReplicas bound the number of pods.
+	MinReplicas int
+	MaxReplicas int
+
+	// CurrentReplicas is the number of pods currently running.
+	CurrentReplicas int
+
+	// DesiredReplicas is the number of pods the autoscaler
+	// has decided the application should have.
+	DesiredReplicas int
+}
+
 // CharmStorageParams defines parameters used to create storage
 // for operators to use for charm state.
 type CharmStorageParams struct {
@@ -22,6 +22,10 @@ const (
 
 	// JujuDefaultApplicationPath is the default value for juju-application-path.
 	JujuDefaultApplicationPath = "/"
+
+	// NamespaceConfigKey is the model config attribute naming an existing
+	// namespace which a CAAS model adopts instead of creating its own.
+	NamespaceConfigKey = "namespace"
 )
 
 var configFields = environschema.Fields{
@@ -4,6 +4,10 @@
 package caas
 
 import (
+	"fmt"
+	"regexp"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 )
@@ -30,9 +34,53 @@ type ImageDetails struct {
 	Password  string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// EnvFromSource populates a container's environment from
+// a secret or config map declared in the pod spec.
+// Exactly one of Secret and ConfigMap is set.
+type EnvFromSource struct {
+	Secret    string `yaml:"secret,omitempty" json:"secret,omitempty"`
+	ConfigMap string `yaml:"configMap,omitempty" json:"configMap,omitempty"`
+}
+
+// Validate returns an error if the source is not valid.
+func (src *EnvFromSource) Validate() error {
+	return validateObjectReference(src.Secret, src.ConfigMap)
+}
+
+// ObjectMount mounts a secret or config map declared in
+// the pod spec into a container.
+// Exactly one of Secret and ConfigMap is set.
+type ObjectMount struct {
+	Secret    string `yaml:"secret,omitempty" json:"secret,omitempty"`
+	ConfigMap string `yaml:"configMap,omitempty" json:"configMap,omitempty"`
+	MountPath string `yaml:"mountPath" json:"mountPath"`
+}
+
+// Validate returns an error if the mount is not valid.
+func (m *ObjectMount) Validate() error {
+	if err := validateObjectReference(m.Secret, m.ConfigMap); err != nil {
+		return errors.Trace(err)
+	}
+	if m.MountPath == "" {
+		return errors.Errorf("mount path is missing for mount of %q", m.Secret+m.ConfigMap)
+	}
+	return nil
+}
+
+func validateObjectReference(secret, configMap string) error {
+	if (secret == "") == (configMap == "") {
+		return errors.New("exactly one of secret or config map must be specified")
+	}
+	return nil
+}
+
 // ProviderContainer defines a provider specific container.
 type ProviderContainer interface {
 	Validate() error
+
+	// RequiresTrust returns true if the container asks for access
+	// which is only granted to trusted applications.
+	RequiresTrust() bool
 }
 
 // ContainerSpec defines the data values used to configure
@@ -51,16 +99,250 @@ type ContainerSpec struct {
 	Config map[string]interface{} `yaml:"config,omitempty"`
 	Files  []FileSet              `yaml:"files,omitempty"`
 
+	// EnvFrom and Mounts refer to secrets and config maps
+	// declared in the pod spec.
+	EnvFrom []EnvFromSource `yaml:"envFrom,omitempty"`
+	Mounts  []ObjectMount   `yaml:"mounts,omitempty"`
+
 	// ProviderContainer defines config which is specific to a substrate, eg k8s
 	ProviderContainer `yaml:"-"`
 }
 
+// DeploymentType defines how the pods of an application are
+// deployed on the CAAS substrate.
+type DeploymentType string
+
+const (
+	// DeploymentStateless deploys a scalable set of identical pods.
+	DeploymentStateless DeploymentType = "stateless"
+
+	// DeploymentStateful deploys a scalable set of pods with
+	// stable identities and storage.
+	DeploymentStateful DeploymentType = "stateful"
+
+	// DeploymentDaemon deploys one pod on each node of the
+	// cluster. The number of units tracks the number of nodes
+	// and cannot be scaled.
+	DeploymentDaemon DeploymentType = "daemon"
+)
+
+// Validate returns an error if the deployment type is not valid.
+func (dt DeploymentType) Validate() error {
+	switch dt {
+	case "", DeploymentStateless, DeploymentStateful, DeploymentDaemon:
+		return nil
+	}
+	return errors.NotValidf("deployment type %q", dt)
+}
+
 // PodSpec defines the data values used to configure
 // a pod on the CAAS substrate.
 type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
+	InitContainers            []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
+	DeploymentType            DeploymentType             `yaml:"deploymentType,omitempty"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+	ServiceAccount            *ServiceAccountSpec        `yaml:"serviceAccount,omitempty"`
+	Secrets                   []Secret                   `yaml:"secrets,omitempty"`
+	ConfigMaps                []ConfigMap                `yaml:"configMaps,omitempty"`
+	UpdateStrategy            *UpdateStrategy            `yaml:"updateStrategy,omitempty"`
+}
+
+// UpdateStrategyType defines how the pods of an application
+// are replaced when its pod spec changes.
+type UpdateStrategyType string
+
+const (
+	// RollingUpdate replaces pods a few at a time. It is the default.
+	RollingUpdate UpdateStrategyType = "RollingUpdate"
+
+	// Recreate removes all pods before creating new ones.
+	// It is only supported for stateless applications.
+	Recreate UpdateStrategyType = "Recreate"
+
+	// OnDelete only replaces pods when they are deleted.
+	// It is only supported for stateful and daemon applications.
+	OnDelete UpdateStrategyType = "OnDelete"
+)
+
+// UpdateStrategy defines how the pods of an application are
+// replaced when its pod spec changes.
+type UpdateStrategy struct {
+	Type UpdateStrategyType `yaml:"type,omitempty"`
+
+	// MaxUnavailable is the number, or percentage eg 25%, of pods
+	// which may be unavailable during a rolling update of a
+	// stateless or daemon application.
+	MaxUnavailable string `yaml:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the number, or percentage, of pods which may be
+	// created above the desired number during a rolling update of
+	// a stateless application.
+	MaxSurge string `yaml:"maxSurge,omitempty"`
+
+	// Partition is the ordinal at or above which the pods of a stateful
+	// application are updated; pods below it keep the previous spec.
+	Partition *int32 `yaml:"partition,omitempty"`
+
+	// ProgressDeadlineSeconds is how long a rollout of a stateless
+	// application may make no progress before it is reported as failed.
+	ProgressDeadlineSeconds *int32 `yaml:"progressDeadlineSeconds,omitempty"`
+}
+
+var intOrPercentRegexp = regexp.MustCompile(`^[0-9]+%?$`)
+
+// Validate returns an error if the update strategy is not valid
+// for the specified deployment type.
+func (us *UpdateStrategy) Validate(deploymentType DeploymentType) error {
+	switch us.Type {
+	case "", RollingUpdate:
+	case Recreate:
+		if deploymentType == DeploymentStateful || deploymentType == DeploymentDaemon {
+			return errors.NotValidf("update strategy %q for %s application", us.Type, deploymentType)
+		}
+	case OnDelete:
+		if deploymentType == DeploymentStateless {
+			return errors.NotValidf("update strategy %q for %s application", us.Type, deploymentType)
+		}
+	default:
+		return errors.NotValidf("update strategy %q", us.Type)
+	}
+	for name, value := range map[string]string{
+		"maxUnavailable": us.MaxUnavailable,
+		"maxSurge":       us.MaxSurge,
+	} {
+		if value != "" && !intOrPercentRegexp.MatchString(value) {
+			return errors.NotValidf("%s %q", name, value)
+		}
+	}
+	if us.MaxSurge != "" && (deploymentType == DeploymentStateful || deploymentType == DeploymentDaemon) {
+		return errors.NotValidf("maxSurge for %s application", deploymentType)
+	}
+	if us.MaxUnavailable != "" && deploymentType == DeploymentStateful {
+		return errors.NotValidf("maxUnavailable for %s application", deploymentType)
+	}
+	if us.Partition != nil {
+		if *us.Partition < 0 {
+			return errors.NotValidf("negative partition %d", *us.Partition)
+		}
+		if deploymentType == DeploymentStateless || deploymentType == DeploymentDaemon {
+			return errors.NotValidf("partition for %s application", deploymentType)
+		}
+	}
+	if us.ProgressDeadlineSeconds != nil {
+		if *us.ProgressDeadlineSeconds <= 0 {
+			return errors.NotValidf("progressDeadlineSeconds %d", *us.ProgressDeadlineSeconds)
+		}
+		if deploymentType == DeploymentStateful || deploymentType == DeploymentDaemon {
+			return errors.NotValidf("progressDeadlineSeconds for %s application", deploymentType)
+		}
+	}
+	return nil
+}
+
+// SecretType defines the type of a secret declared in a pod spec.
+type SecretType string
+
+const (
+	// SecretOpaque holds arbitrary data. It is the default.
+	SecretOpaque SecretType = "Opaque"
+
+	// SecretTLS holds a certificate and its key, in the
+	// tls.crt and tls.key entries.
+	SecretTLS SecretType = "kubernetes.io/tls"
+)
+
+// Secret defines a secret created for the application, which
+// containers refer to by name to use as environment or files.
+// The secret is owned by the application and removed with it.
+type Secret struct {
+	Name string            `yaml:"name" json:"name"`
+	Type SecretType        `yaml:"type,omitempty" json:"type,omitempty"`
+	Data map[string]string `yaml:"data" json:"data"`
+}
+
+// Validate returns an error if the secret is not valid.
+// The error never includes the secret's data.
+func (s *Secret) Validate() error {
+	if s.Name == "" {
+		return errors.New("secret name is missing")
+	}
+	switch s.Type {
+	case "", SecretOpaque:
+	case SecretTLS:
+		for _, key := range []string{"tls.crt", "tls.key"} {
+			if _, ok := s.Data[key]; !ok {
+				return errors.NotValidf("tls secret %q without %q", s.Name, key)
+			}
+		}
+	default:
+		return errors.NotValidf("secret %q type %q", s.Name, s.Type)
+	}
+	return nil
+}
+
+// ConfigMap defines a config map created for the application, which
+// containers refer to by name to use as environment or files.
+// The config map is owned by the application and removed with it.
+type ConfigMap struct {
+	Name string            `yaml:"name" json:"name"`
+	Data map[string]string `yaml:"data" json:"data"`
+}
+
+// Validate returns an error if the config map is not valid.
+func (cm *ConfigMap) Validate() error {
+	if cm.Name == "" {
+		return errors.New("config map name is missing")
+	}
+	return nil
+}
+
+// PolicyRule defines the access granted to the application's
+// service account for a set of resources.
+type PolicyRule struct {
+	APIGroups       []string `yaml:"apiGroups,omitempty" json:"apiGroups,omitempty"`
+	Resources       []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+	ResourceNames   []string `yaml:"resourceNames,omitempty" json:"resourceNames,omitempty"`
+	NonResourceURLs []string `yaml:"nonResourceURLs,omitempty" json:"nonResourceURLs,omitempty"`
+	Verbs           []string `yaml:"verbs" json:"verbs"`
+}
+
+// Validate returns an error if the rule is not valid.
+func (r *PolicyRule) Validate() error {
+	if len(r.Verbs) == 0 {
+		return errors.NotValidf("rule with no verbs")
+	}
+	if len(r.Resources) == 0 && len(r.NonResourceURLs) == 0 {
+		return errors.NotValidf("rule with no resources or non resource URLs")
+	}
+	return nil
+}
+
+// ServiceAccountSpec defines the service account the application's
+// pods run as, and the access control rules granted to it.
+type ServiceAccountSpec struct {
+	// Global is true if the rules apply across the whole cluster
+	// rather than just the model's namespace.
+	Global                       bool         `yaml:"global,omitempty" json:"global,omitempty"`
+	AutomountServiceAccountToken *bool        `yaml:"automountServiceAccountToken,omitempty" json:"automountServiceAccountToken,omitempty"`
+	Rules                        []PolicyRule `yaml:"rules" json:"rules"`
+}
+
+// Validate returns an error if the service account spec is not valid.
+func (sa *ServiceAccountSpec) Validate() error {
+	if len(sa.Rules) == 0 {
+		return errors.NotValidf("service account with no rules")
+	}
+	for _, r := range sa.Rules {
+		if err := r.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if len(r.NonResourceURLs) > 0 && !sa.Global {
+			return errors.NotValidf("non resource URLs for service account which is not global")
+		}
+	}
+	return nil
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -96,16 +378,120 @@ func (crd *CustomResourceDefinition) Validate() error {
 
 // Validate returns an error if the spec is not valid.
 func (spec *PodSpec) Validate() error {
+	if err := spec.DeploymentType.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	for _, c := range spec.Containers {
 		if err := c.Validate(); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	for _, c := range spec.InitContainers {
+		if err := c.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	for _, crd := range spec.CustomResourceDefinitions {
 		if err := crd.Validate(); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.UpdateStrategy != nil {
+		if err := spec.UpdateStrategy.Validate(spec.DeploymentType); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(spec.validateObjects())
+}
+
+// validateObjects checks the secrets and config maps declared in
+// the spec, and that containers only refer to declared ones.
+// Secret names must not clash with container names, nor config map
+// names with file set names, as the resources created for them
+// share a naming scheme.
+func (spec *PodSpec) validateObjects() error {
+	containerNames := set.NewStrings()
+	fileSetNames := set.NewStrings()
+	for _, c := range append(spec.Containers, spec.InitContainers...) {
+		containerNames.Add(c.Name)
+		for _, fs := range c.Files {
+			fileSetNames.Add(fs.Name)
+		}
+	}
+
+	secretNames := set.NewStrings()
+	for _, s := range spec.Secrets {
+		if err := s.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if secretNames.Contains(s.Name) {
+			return errors.NotValidf("duplicate secret %q", s.Name)
+		}
+		if containerNames.Contains(s.Name) {
+			return errors.NotValidf("secret %q with the same name as a container", s.Name)
+		}
+		secretNames.Add(s.Name)
+	}
+	configMapNames := set.NewStrings()
+	for _, cm := range spec.ConfigMaps {
+		if err := cm.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if configMapNames.Contains(cm.Name) {
+			return errors.NotValidf("duplicate config map %q", cm.Name)
+		}
+		if fileSetNames.Contains(cm.Name) {
+			return errors.NotValidf("config map %q with the same name as a file set", cm.Name)
+		}
+		configMapNames.Add(cm.Name)
+	}
+
+	checkRef := func(container, secret, configMap string) error {
+		if secret != "" && !secretNames.Contains(secret) {
+			return errors.NotFoundf("secret %q used by container %q", secret, container)
+		}
+		if configMap != "" && !configMapNames.Contains(configMap) {
+			return errors.NotFoundf("config map %q used by container %q", configMap, container)
+		}
+		return nil
+	}
+	for _, c := range append(spec.Containers, spec.InitContainers...) {
+		for _, src := range c.EnvFrom {
+			if err := checkRef(c.Name, src.Secret, src.ConfigMap); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, m := range c.Mounts {
+			if err := checkRef(c.Name, m.Secret, m.ConfigMap); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateTrust returns an error if the spec asks for access which
+// is only granted to trusted applications, and trusted is false.
+// A global service account has access beyond the model's namespace,
+// and a container may ask for privileges on the node it runs on, so
+// both require the application to be trusted.
+func (spec *PodSpec) ValidateTrust(trusted bool) error {
+	if trusted {
+		return nil
+	}
+	if spec.ServiceAccount != nil && spec.ServiceAccount.Global {
+		return errors.NewNotValid(nil, "global service account requires the application to be trusted")
+	}
+	for _, c := range append(spec.Containers, spec.InitContainers...) {
+		if c.ProviderContainer != nil && c.ProviderContainer.RequiresTrust() {
+			return errors.NewNotValid(nil, fmt.Sprintf("container %q requires the application to be trusted", c.Name))
+		}
+	}
 	return nil
 }
 
@@ -125,6 +511,16 @@ func (spec *ContainerSpec) Validate() error {
 			return errors.Errorf("mount path is missing for file set %q", fs.Name)
 		}
 	}
+	for _, src := range spec.EnvFrom {
+		if err := src.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, m := range spec.Mounts {
+		if err := m.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if spec.ProviderContainer != nil {
 		return spec.ProviderContainer.Validate()
 	}
@@ -0,0 +1,158 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	autoscaling "k8s.io/api/autoscaling/v2beta1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+// autoscalingConfig holds the autoscaler settings from the application config.
+type autoscalingConfig struct {
+	minReplicas int32
+	maxReplicas int32
+	metrics     []autoscaling.MetricSpec
+}
+
+// parseAutoscalingConfig returns the autoscaler settings from the
+// application config, or nil if the config does not set a maximum
+// number of replicas.
+func parseAutoscalingConfig(config application.ConfigAttributes) (*autoscalingConfig, error) {
+	maxReplicas := config.GetInt(autoscalingMaxReplicasKey, 0)
+	if maxReplicas < 0 {
+		return nil, errors.NotValidf("%s %d", autoscalingMaxReplicasKey, maxReplicas)
+	}
+	if maxReplicas == 0 {
+		return nil, nil
+	}
+	minReplicas := config.GetInt(autoscalingMinReplicasKey, 1)
+	if minReplicas < 1 || minReplicas > maxReplicas {
+		return nil, errors.NotValidf("autoscaling between %d and %d replicas", minReplicas, maxReplicas)
+	}
+
+	// Without any metrics Kubernetes targets an average CPU
+	// utilisation of 80%.
+	var metrics []autoscaling.MetricSpec
+	targetCPU := config.GetInt(autoscalingTargetCPUKey, 0)
+	if targetCPU < 0 {
+		return nil, errors.NotValidf("%s %d", autoscalingTargetCPUKey, targetCPU)
+	}
+	if targetCPU > 0 {
+		utilization := int32(targetCPU)
+		metrics = append(metrics, autoscaling.MetricSpec{
+			Type: autoscaling.ResourceMetricSourceType,
+			Resource: &autoscaling.ResourceMetricSource{
+				Name:                     core.ResourceCPU,
+				TargetAverageUtilization: &utilization,
+			},
+		})
+	}
+	metric := config.GetString(autoscalingMetricKey, "")
+	target := config.GetString(autoscalingMetricTargetKey, "")
+	if metric == "" && target != "" {
+		return nil, errors.NotValidf("%s without %s", autoscalingMetricTargetKey, autoscalingMetricKey)
+	}
+	if metric != "" {
+		value, err := resource.ParseQuantity(target)
+		if err != nil {
+			return nil, errors.NotValidf("autoscaling metric %q target %q", metric, target)
+		}
+		metrics = append(metrics, autoscaling.MetricSpec{
+			Type: autoscaling.PodsMetricSourceType,
+			Pods: &autoscaling.PodsMetricSource{
+				MetricName:         metric,
+				TargetAverageValue: value,
+			},
+		})
+	}
+	return &autoscalingConfig{
+		minReplicas: int32(minReplicas),
+		maxReplicas: int32(maxReplicas),
+		metrics:     metrics,
+	}, nil
+}
+
+// IsAutoscaled returns true if the application config enables
+// autoscaling, so the number of units is chosen by the autoscaler.
+func IsAutoscaled(config application.ConfigAttributes) bool {
+	return config.GetInt(autoscalingMaxReplicasKey, 0) > 0
+}
+
+// configureAutoscaler creates or updates the horizontal pod autoscaler
+// of the application from its config. The autoscaler is removed if the
+// config does not set a maximum number of replicas.
+func (k *kubernetesClient) configureAutoscaler(
+	appName, kind string, labels map[string]string, config application.ConfigAttributes,
+) error {
+	autoscalingConfig, err := parseAutoscalingConfig(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if autoscalingConfig == nil {
+		return k.deleteAutoscaler(appName)
+	}
+	spec := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: labels,
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName(appName),
+			},
+			MinReplicas: &autoscalingConfig.minReplicas,
+			MaxReplicas: autoscalingConfig.maxReplicas,
+			Metrics:     autoscalingConfig.metrics,
+		},
+	}
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	_, err = autoscalers.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteAutoscaler(appName string) error {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	err := autoscalers.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// AutoscalerStatus returns the number of pods chosen for the
+// specified application by its horizontal pod autoscaler.
+func (k *kubernetesClient) AutoscalerStatus(appName string) (*caas.AutoscalerStatus, error) {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	hpa, err := autoscalers.Get(deploymentName(appName), v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("autoscaler for application %q", appName)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &caas.AutoscalerStatus{
+		MinReplicas:     1,
+		MaxReplicas:     int(hpa.Spec.MaxReplicas),
+		CurrentReplicas: int(hpa.Status.CurrentReplicas),
+		DesiredReplicas: int(hpa.Status.DesiredReplicas),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		result.MinReplicas = int(*hpa.Spec.MinReplicas)
+	}
+	return result, nil
+}
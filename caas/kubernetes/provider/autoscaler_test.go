@@ -0,0 +1,219 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithAutoscaler(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(4),
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	autoscalerArg := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels,
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "juju-app-name",
+			},
+			MinReplicas: int32Ptr(2),
+			MaxReplicas: 5,
+			Metrics: []autoscalingv2beta1.MetricSpec{{
+				Type: autoscalingv2beta1.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta1.ResourceMetricSource{
+					Name:                     core.ResourceCPU,
+					TargetAverageUtilization: int32Ptr(70),
+				},
+			}, {
+				Type: autoscalingv2beta1.PodsMetricSourceType,
+				Pods: &autoscalingv2beta1.PodsMetricSource{
+					MetricName:         "requests-per-second",
+					TargetAverageValue: resource.MustParse("100"),
+				},
+			}},
+		},
+	}
+
+	withPodTemplateHash(c, deploymentArg)
+	// The autoscaler has scaled the deployment out to 4 replicas,
+	// which is kept rather than the 2 units asked for.
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deploymentArg, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Update(autoscalerArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockHorizontalAutoscalers.EXPECT().Create(autoscalerArg).Times(1).
+			Return(autoscalerArg, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":              "nodeIP",
+		"kubernetes-service-loadbalancer-ip":   "10.0.0.1",
+		"kubernetes-service-externalname":      "ext-name",
+		"kubernetes-autoscaling-min-replicas":  2,
+		"kubernetes-autoscaling-max-replicas":  5,
+		"kubernetes-autoscaling-target-cpu":    70,
+		"kubernetes-autoscaling-metric":        "requests-per-second",
+		"kubernetes-autoscaling-metric-target": "100",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestAutoscalerStatus(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	hpa := &autoscalingv2beta1.HorizontalPodAutoscaler{
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			MinReplicas: int32Ptr(2),
+			MaxReplicas: 5,
+		},
+		Status: autoscalingv2beta1.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+		},
+	}
+	gomock.InOrder(
+		s.mockHorizontalAutoscalers.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(hpa, nil),
+	)
+
+	result, err := s.broker.AutoscalerStatus("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &caas.AutoscalerStatus{
+		MinReplicas:     2,
+		MaxReplicas:     5,
+		CurrentReplicas: 3,
+		DesiredReplicas: 4,
+	})
+}
+
+func (s *K8sBrokerSuite) TestAutoscalerStatusNotAutoscaled(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockHorizontalAutoscalers.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	_, err := s.broker.AutoscalerStatus("app-name")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestValidateAutoscalingConfig(c *gc.C) {
+	for i, test := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-min-replicas": 5,
+		},
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-min-replicas":  2,
+			"kubernetes-autoscaling-max-replicas":  5,
+			"kubernetes-autoscaling-metric":        "requests-per-second",
+			"kubernetes-autoscaling-metric-target": "100",
+		},
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-min-replicas": 6,
+			"kubernetes-autoscaling-max-replicas": 5,
+		},
+		err: "autoscaling between 6 and 5 replicas not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-replicas": -1,
+		},
+		err: "kubernetes-autoscaling-max-replicas -1 not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-replicas": 5,
+			"kubernetes-autoscaling-target-cpu":   -10,
+		},
+		err: "kubernetes-autoscaling-target-cpu -10 not valid",
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-replicas": 5,
+			"kubernetes-autoscaling-metric":       "requests-per-second",
+		},
+		err: `autoscaling metric "requests-per-second" target "" not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-autoscaling-max-replicas":  5,
+			"kubernetes-autoscaling-metric-target": "100",
+		},
+		err: "kubernetes-autoscaling-metric-target without kubernetes-autoscaling-metric not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := provider.ValidateConfig(test.config)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
@@ -30,6 +30,10 @@ type BaseSuite struct {
 
 	cfg *config.Config
 
+	// modelConfigAttrs are merged into the model config
+	// of the broker under test.
+	modelConfigAttrs testing.Attrs
+
 	k8sClient                  *mocks.MockInterface
 	mockNamespaces             *mocks.MockNamespaceInterface
 	mockApps                   *mocks.MockAppsV1Interface
@@ -37,14 +41,24 @@ type BaseSuite struct {
 	mockSecrets                *mocks.MockSecretInterface
 	mockDeployments            *mocks.MockDeploymentInterface
 	mockStatefulSets           *mocks.MockStatefulSetInterface
+	mockDaemonSets             *mocks.MockDaemonSetInterface
+	mockReplicaSets            *mocks.MockReplicaSetInterface
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
+	mockEvents                 *mocks.MockEventInterface
 	mockPersistentVolumes      *mocks.MockPersistentVolumeInterface
 	mockPersistentVolumeClaims *mocks.MockPersistentVolumeClaimInterface
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
+	mockHorizontalAutoscalers  *mocks.MockHorizontalPodAutoscalerInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
+	mockClusterRoles           *mocks.MockClusterRoleInterface
+	mockClusterRoleBindings    *mocks.MockClusterRoleBindingInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -53,6 +67,11 @@ type BaseSuite struct {
 
 const testNamespace = "test"
 
+func (s *BaseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.modelConfigAttrs = nil
+}
+
 func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
 		"username":              "fred",
@@ -67,7 +86,7 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	}
 	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
 		config.NameKey: testNamespace,
-	}))
+	}).Merge(s.modelConfigAttrs))
 	c.Assert(err, jc.ErrorIsNil)
 	s.cfg = cfg
 
@@ -93,6 +112,9 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockConfigMaps = mocks.NewMockConfigMapInterface(ctrl)
 	mockCoreV1.EXPECT().ConfigMaps(testNamespace).AnyTimes().Return(s.mockConfigMaps)
 
+	s.mockEvents = mocks.NewMockEventInterface(ctrl)
+	mockCoreV1.EXPECT().Events(testNamespace).AnyTimes().Return(s.mockEvents)
+
 	s.mockPersistentVolumes = mocks.NewMockPersistentVolumeInterface(ctrl)
 	mockCoreV1.EXPECT().PersistentVolumes().AnyTimes().Return(s.mockPersistentVolumes)
 
@@ -102,22 +124,50 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockSecrets = mocks.NewMockSecretInterface(ctrl)
 	mockCoreV1.EXPECT().Secrets(testNamespace).AnyTimes().Return(s.mockSecrets)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
 	s.mockDeployments = mocks.NewMockDeploymentInterface(ctrl)
+	s.mockDaemonSets = mocks.NewMockDaemonSetInterface(ctrl)
+	s.mockReplicaSets = mocks.NewMockReplicaSetInterface(ctrl)
 	s.mockIngressInterface = mocks.NewMockIngressInterface(ctrl)
 	s.k8sClient.EXPECT().ExtensionsV1beta1().AnyTimes().Return(s.mockExtensions)
 	s.k8sClient.EXPECT().AppsV1().AnyTimes().Return(s.mockApps)
 	s.mockApps.EXPECT().StatefulSets(testNamespace).AnyTimes().Return(s.mockStatefulSets)
 	s.mockApps.EXPECT().Deployments(testNamespace).AnyTimes().Return(s.mockDeployments)
+	s.mockApps.EXPECT().DaemonSets(testNamespace).AnyTimes().Return(s.mockDaemonSets)
+	s.mockApps.EXPECT().ReplicaSets(testNamespace).AnyTimes().Return(s.mockReplicaSets)
 	s.mockExtensions.EXPECT().Ingresses(testNamespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockNetworkingV1 := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworkingV1)
+	mockNetworkingV1.EXPECT().NetworkPolicies(testNamespace).AnyTimes().Return(s.mockNetworkPolicies)
+
+	mockAutoscalingV2beta1 := mocks.NewMockAutoscalingV2beta1Interface(ctrl)
+	s.mockHorizontalAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta1().AnyTimes().Return(mockAutoscalingV2beta1)
+	mockAutoscalingV2beta1.EXPECT().HorizontalPodAutoscalers(testNamespace).AnyTimes().Return(s.mockHorizontalAutoscalers)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	mockRbacV1 := mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.mockClusterRoles = mocks.NewMockClusterRoleInterface(ctrl)
+	s.mockClusterRoleBindings = mocks.NewMockClusterRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(mockRbacV1)
+	mockRbacV1.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	mockRbacV1.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+	mockRbacV1.EXPECT().ClusterRoles().AnyTimes().Return(s.mockClusterRoles)
+	mockRbacV1.EXPECT().ClusterRoleBindings().AnyTimes().Return(s.mockClusterRoleBindings)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
@@ -4,9 +4,15 @@
 package provider
 
 import (
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/core/application"
 )
 
 const (
@@ -27,6 +33,28 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	// trustConfigKey is the application config attribute set by
+	// "juju trust".
+	trustConfigKey = "trust"
+
+	ingressHostsKey            = "kubernetes-ingress-hosts"
+	ingressPathsKey            = "kubernetes-ingress-paths"
+	ingressAnnotationsKey      = "kubernetes-ingress-annotations"
+	ingressTLSSecretKey        = "kubernetes-ingress-tls-secret"
+	ingressTLSIssuerKey        = "kubernetes-ingress-tls-issuer"
+	ingressTLSClusterIssuerKey = "kubernetes-ingress-tls-cluster-issuer"
+
+	// The annotations cert-manager looks for on an ingress to
+	// issue a certificate for its TLS hosts.
+	certManagerIssuerAnnotation        = "certmanager.k8s.io/issuer"
+	certManagerClusterIssuerAnnotation = "certmanager.k8s.io/cluster-issuer"
+
+	autoscalingMinReplicasKey  = "kubernetes-autoscaling-min-replicas"
+	autoscalingMaxReplicasKey  = "kubernetes-autoscaling-max-replicas"
+	autoscalingTargetCPUKey    = "kubernetes-autoscaling-target-cpu"
+	autoscalingMetricKey       = "kubernetes-autoscaling-metric"
+	autoscalingMetricTargetKey = "kubernetes-autoscaling-metric-target"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +108,61 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ingressHostsKey: {
+		Description: "additional hostnames the ingress routes to the application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressPathsKey: {
+		Description: "the path prefixes the ingress routes to the application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressAnnotationsKey: {
+		Description: "additional annotations for the ingress, as a YAML map",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSSecretKey: {
+		Description: "the name of the secret holding the ingress TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSIssuerKey: {
+		Description: "the cert-manager issuer of the ingress TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSClusterIssuerKey: {
+		Description: "the cert-manager cluster issuer of the ingress TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMinReplicasKey: {
+		Description: "the minimum number of pods the autoscaler will run",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMaxReplicasKey: {
+		Description: "the maximum number of pods; setting it enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingTargetCPUKey: {
+		Description: "the average CPU utilisation percentage the autoscaler aims for",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMetricKey: {
+		Description: "the name of a custom pod metric for the autoscaler",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	autoscalingMetricTargetKey: {
+		Description: "the average custom pod metric value the autoscaler aims for",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -101,3 +184,35 @@ func ConfigSchema() environschema.Fields {
 func ConfigDefaults() schema.Defaults {
 	return schemaDefaults
 }
+
+// ValidateConfig returns an error if the supplied application config,
+// coerced to the types in the config schema, is not valid.
+func ValidateConfig(config application.ConfigAttributes) error {
+	if _, err := parseAutoscalingConfig(config); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := parseIngressAnnotations(config); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// parseIngressAnnotations returns the additional ingress annotations
+// in the config. They are set as a YAML map, as annotation values
+// often hold commas, spaces or several lines.
+func parseIngressAnnotations(config application.ConfigAttributes) (map[string]string, error) {
+	value := config.GetString(ingressAnnotationsKey, "")
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var annotations map[string]string
+	if err := yaml.Unmarshal([]byte(value), &annotations); err != nil {
+		return nil, errors.Annotatef(err, "invalid %s", ingressAnnotationsKey)
+	}
+	for key := range annotations {
+		if key == "" {
+			return nil, errors.Errorf("invalid %s: empty annotation name", ingressAnnotationsKey)
+		}
+	}
+	return annotations, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/juju/juju/core/status"
+)
+
+// Reasons given by Kubernetes for problems with the
+// pods and containers of an application.
+const (
+	reasonFailedScheduling           = "FailedScheduling"
+	reasonFailedCreate               = "FailedCreate"
+	reasonFailedMount                = "FailedMount"
+	reasonFailedAttachVolume         = "FailedAttachVolume"
+	reasonFailed                     = "Failed"
+	reasonBackOff                    = "BackOff"
+	reasonErrImagePull               = "ErrImagePull"
+	reasonImagePullBackOff           = "ImagePullBackOff"
+	reasonInvalidImageName           = "InvalidImageName"
+	reasonCrashLoopBackOff           = "CrashLoopBackOff"
+	reasonCreateContainerConfigError = "CreateContainerConfigError"
+	reasonOOMKilled                  = "OOMKilled"
+)
+
+// podEventStatus maps the reasons of warning events about a pod
+// to the status of the unit running in the pod.
+var podEventStatus = map[string]status.Status{
+	reasonFailedScheduling:   status.Blocked,
+	reasonFailedMount:        status.Blocked,
+	reasonFailedAttachVolume: status.Blocked,
+	reasonFailed:             status.Error,
+	reasonBackOff:            status.Error,
+}
+
+// containerWaitingStatus maps the reasons a container is
+// waiting to the status of the unit running the container.
+var containerWaitingStatus = map[string]status.Status{
+	reasonErrImagePull:               status.Error,
+	reasonImagePullBackOff:           status.Error,
+	reasonInvalidImageName:           status.Error,
+	reasonCrashLoopBackOff:           status.Error,
+	reasonCreateContainerConfigError: status.Error,
+}
+
+// containerProblem returns the status of the first container of the pod
+// which cannot run, because its image cannot be pulled, it keeps
+// crashing, or it was killed for running out of memory.
+func containerProblem(pod core.Pod) (string, status.Status, time.Time, bool) {
+	var containers []core.ContainerStatus
+	containers = append(containers, pod.Status.InitContainerStatuses...)
+	containers = append(containers, pod.Status.ContainerStatuses...)
+	for _, c := range containers {
+		if terminated := c.State.Terminated; terminated != nil && terminated.Reason == reasonOOMKilled {
+			return fmt.Sprintf("container %q: %s", c.Name, reasonOOMKilled), status.Error, terminated.FinishedAt.Time, true
+		}
+		waiting := c.State.Waiting
+		if waiting == nil {
+			continue
+		}
+		if terminated := c.LastTerminationState.Terminated; terminated != nil && terminated.Reason == reasonOOMKilled {
+			return fmt.Sprintf("container %q: %s", c.Name, reasonOOMKilled), status.Error, terminated.FinishedAt.Time, true
+		}
+		jujuStatus, ok := containerWaitingStatus[waiting.Reason]
+		if !ok {
+			continue
+		}
+		message := fmt.Sprintf("container %q: %s", c.Name, waiting.Reason)
+		if waiting.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, waiting.Message)
+		}
+		return message, jujuStatus, time.Time{}, true
+	}
+	return "", "", time.Time{}, false
+}
+
+// latestEvent returns the most recent of the events, or nil if there are none.
+func latestEvent(events []core.Event) *core.Event {
+	var latest *core.Event
+	for i, evt := range events {
+		if latest == nil || !evt.LastTimestamp.Time.Before(latest.LastTimestamp.Time) {
+			latest = &events[i]
+		}
+	}
+	return latest
+}
+
+// podEvents returns the events about the named pod.
+func (k *kubernetesClient) podEvents(podName string) ([]core.Event, error) {
+	events := k.CoreV1().Events(k.namespace)
+	eventList, err := events.List(v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.name", podName),
+			fields.OneTermEqualSelector("involvedObject.kind", "Pod"),
+		).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return eventList.Items, nil
+}
+
+// isApplicationObject returns true if the object may be the deployment,
+// stateful set or daemon set running the pods of the application, or
+// one of its replica sets or pods. Replica sets and pods are named with
+// the name of the workload as a prefix, which is shared by applications
+// whose names start with the application name, so they may also belong
+// to another application.
+func isApplicationObject(appName string, obj core.ObjectReference) bool {
+	name := deploymentName(appName)
+	switch obj.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		return obj.Name == name
+	case "ReplicaSet", "Pod":
+		return strings.HasPrefix(obj.Name, name+"-")
+	}
+	return false
+}
+
+// applicationEventFilter returns a function which selects the warning
+// events about the objects running the application. The objects are
+// matched on their names alone, so the watcher may also notify about
+// an application whose name starts with the application name; the
+// units are checked when the watcher fires anyway.
+func applicationEventFilter(appName string) func(*core.Event) bool {
+	return func(evt *core.Event) bool {
+		return evt.Type == core.EventTypeWarning && isApplicationObject(appName, evt.InvolvedObject)
+	}
+}
+
+// ApplicationWarning returns the status of the specified application
+// when the substrate reports it cannot create the pods of the application,
+// eg because the namespace resource quota is exceeded.
+func (k *kubernetesClient) ApplicationWarning(appName string) (*status.StatusInfo, error) {
+	events := k.CoreV1().Events(k.namespace)
+	eventList, err := events.List(v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("type", core.EventTypeWarning),
+			fields.OneTermEqualSelector("reason", reasonFailedCreate),
+		).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	latestByObject := make(map[core.ObjectReference]core.Event)
+	var hasReplicaSets bool
+	for _, evt := range eventList.Items {
+		obj := core.ObjectReference{Kind: evt.InvolvedObject.Kind, Name: evt.InvolvedObject.Name}
+		if obj.Kind == "Pod" || !isApplicationObject(appName, obj) {
+			continue
+		}
+		if latest, ok := latestByObject[obj]; ok && evt.LastTimestamp.Time.Before(latest.LastTimestamp.Time) {
+			continue
+		}
+		latestByObject[obj] = evt
+		hasReplicaSets = hasReplicaSets || obj.Kind == "ReplicaSet"
+	}
+	if len(latestByObject) == 0 {
+		return nil, errors.NotFoundf("warning for application %q", appName)
+	}
+
+	// Replica sets whose names match may belong to another application,
+	// so only those with the application label are considered.
+	appReplicaSets := set.NewStrings()
+	if hasReplicaSets {
+		replicaSets, err := k.AppsV1().ReplicaSets(k.namespace).List(v1.ListOptions{
+			LabelSelector: applicationSelector(appName),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rs := range replicaSets.Items {
+			appReplicaSets.Add(rs.Name)
+		}
+	}
+
+	// A warning is resolved by a more recent normal
+	// event reporting the object is working again.
+	normalList, err := events.List(v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector:        fields.OneTermEqualSelector("type", core.EventTypeNormal).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	latestNormal := make(map[core.ObjectReference]time.Time)
+	for _, evt := range normalList.Items {
+		obj := core.ObjectReference{Kind: evt.InvolvedObject.Kind, Name: evt.InvolvedObject.Name}
+		if at := evt.LastTimestamp.Time; at.After(latestNormal[obj]) {
+			latestNormal[obj] = at
+		}
+	}
+
+	var warnings []core.Event
+	for obj, evt := range latestByObject {
+		if obj.Kind == "ReplicaSet" && !appReplicaSets.Contains(obj.Name) {
+			continue
+		}
+		if latestNormal[obj].After(evt.LastTimestamp.Time) {
+			continue
+		}
+		warnings = append(warnings, evt)
+	}
+	warning := latestEvent(warnings)
+	if warning == nil {
+		return nil, errors.NotFoundf("warning for application %q", appName)
+	}
+	since := warning.LastTimestamp.Time
+	return &status.StatusInfo{
+		Status:  status.Blocked,
+		Message: warning.Message,
+		Since:   &since,
+	}, nil
+}
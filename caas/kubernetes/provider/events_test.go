@@ -0,0 +1,195 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+func unitPod(podStatus core.PodStatus) core.Pod {
+	return core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name-0",
+			UID:    "uuid",
+			Labels: map[string]string{"juju-application": "app-name"},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "test"}},
+		},
+		Status: podStatus,
+	}
+}
+
+func (s *K8sBrokerSuite) assertUnitStatus(c *gc.C, expected status.Status, message string) {
+	units, err := s.broker.Units("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Status.Status, gc.Equals, expected)
+	c.Assert(units[0].Status.Message, gc.Equals, message)
+}
+
+func (s *K8sBrokerSuite) TestUnitsImagePullBackOff(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := unitPod(core.PodStatus{
+		Phase: core.PodPending,
+		ContainerStatuses: []core.ContainerStatus{{
+			Name: "test",
+			State: core.ContainerState{Waiting: &core.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "missing/image"`,
+			}},
+		}},
+	})
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{pod}}, nil)
+
+	s.assertUnitStatus(c, status.Error, `container "test": ImagePullBackOff: Back-off pulling image "missing/image"`)
+}
+
+func (s *K8sBrokerSuite) TestUnitsOOMKilled(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := unitPod(core.PodStatus{
+		Phase: core.PodRunning,
+		ContainerStatuses: []core.ContainerStatus{{
+			Name: "test",
+			State: core.ContainerState{Waiting: &core.ContainerStateWaiting{
+				Reason: "CrashLoopBackOff",
+			}},
+			LastTerminationState: core.ContainerState{Terminated: &core.ContainerStateTerminated{
+				Reason:     "OOMKilled",
+				ExitCode:   137,
+				FinishedAt: v1.NewTime(time.Now()),
+			}},
+		}},
+	})
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{pod}}, nil)
+
+	s.assertUnitStatus(c, status.Error, `container "test": OOMKilled`)
+}
+
+func (s *K8sBrokerSuite) TestUnitsFailedScheduling(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := unitPod(core.PodStatus{
+		Phase:   core.PodPending,
+		Message: "pending",
+	})
+	now := time.Now()
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        "involvedObject.name=juju-app-name-0,involvedObject.kind=Pod",
+		}).Times(1).
+			Return(&core.EventList{Items: []core.Event{{
+				Type:          core.EventTypeWarning,
+				Reason:        "FailedScheduling",
+				Message:       "0/1 nodes are available: 1 Insufficient memory.",
+				LastTimestamp: v1.NewTime(now),
+			}, {
+				Type:          core.EventTypeNormal,
+				Reason:        "Scheduled",
+				Message:       "Successfully assigned juju-app-name-0",
+				LastTimestamp: v1.NewTime(now.Add(-time.Minute)),
+			}}}, nil),
+	)
+
+	s.assertUnitStatus(c, status.Blocked, "0/1 nodes are available: 1 Insufficient memory.")
+}
+
+const (
+	warningSelector = "type=Warning,reason=FailedCreate"
+	normalSelector  = "type=Normal"
+)
+
+func (s *K8sBrokerSuite) TestApplicationWarning(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	warning := core.Event{
+		InvolvedObject: core.ObjectReference{Kind: "ReplicaSet", Name: "juju-app-name-5d8f7b4c9"},
+		Type:           core.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        "exceeded quota: compute-resources",
+		LastTimestamp:  v1.NewTime(now),
+	}
+	s.mockEvents.EXPECT().List(v1.ListOptions{IncludeUninitialized: true, FieldSelector: warningSelector}).Times(1).
+		Return(&core.EventList{Items: []core.Event{warning, {
+			InvolvedObject: core.ObjectReference{Kind: "ReplicaSet", Name: "juju-other-app-5d8f7b4c9"},
+			Type:           core.EventTypeWarning,
+			Reason:         "FailedCreate",
+			Message:        "another application",
+			LastTimestamp:  v1.NewTime(now.Add(time.Minute)),
+		}, {
+			InvolvedObject: core.ObjectReference{Kind: "ReplicaSet", Name: "juju-app-name-bar-6c9d8e5f1"},
+			Type:           core.EventTypeWarning,
+			Reason:         "FailedCreate",
+			Message:        "an application with a longer name",
+			LastTimestamp:  v1.NewTime(now.Add(time.Minute)),
+		}}}, nil)
+	s.mockReplicaSets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+		Return(&apps.ReplicaSetList{Items: []apps.ReplicaSet{{ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name-5d8f7b4c9",
+			Labels: map[string]string{"juju-application": "app-name"},
+		}}}}, nil)
+	s.mockEvents.EXPECT().List(v1.ListOptions{IncludeUninitialized: true, FieldSelector: normalSelector}).Times(1).
+		Return(&core.EventList{Items: []core.Event{{
+			InvolvedObject: core.ObjectReference{Kind: "ReplicaSet", Name: "juju-app-name-bar-6c9d8e5f1"},
+			Type:           core.EventTypeNormal,
+			Reason:         "SuccessfulCreate",
+			Message:        "another replica set",
+			LastTimestamp:  v1.NewTime(now.Add(time.Minute)),
+		}}}, nil)
+
+	warningStatus, err := s.broker.ApplicationWarning("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(warningStatus.Status, gc.Equals, status.Blocked)
+	c.Assert(warningStatus.Message, gc.Equals, "exceeded quota: compute-resources")
+}
+
+func (s *K8sBrokerSuite) TestApplicationWarningResolved(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	warning := core.Event{
+		InvolvedObject: core.ObjectReference{Kind: "StatefulSet", Name: "juju-app-name"},
+		Type:           core.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        "exceeded quota: compute-resources",
+		LastTimestamp:  v1.NewTime(now.Add(-time.Minute)),
+	}
+	gomock.InOrder(
+		s.mockEvents.EXPECT().List(v1.ListOptions{IncludeUninitialized: true, FieldSelector: warningSelector}).Times(1).
+			Return(&core.EventList{Items: []core.Event{warning}}, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{IncludeUninitialized: true, FieldSelector: normalSelector}).Times(1).
+			Return(&core.EventList{Items: []core.Event{{
+				InvolvedObject: core.ObjectReference{Kind: "StatefulSet", Name: "juju-app-name"},
+				Type:           core.EventTypeNormal,
+				Reason:         "SuccessfulCreate",
+				Message:        "create Pod juju-app-name-0 in StatefulSet juju-app-name successful",
+				LastTimestamp:  v1.NewTime(now),
+			}}}, nil),
+	)
+
+	_, err := s.broker.ApplicationWarning("app-name")
+	c.Assert(err, gc.ErrorMatches, `warning for application "app-name" not found`)
+}
@@ -18,6 +18,7 @@ var (
 	ExtractRegistryURL     = extractRegistryURL
 	CreateDockerConfigJSON = createDockerConfigJSON
 	NewStorageConfig       = newStorageConfig
+	PodTemplateHash        = podTemplateHash
 )
 
 func PodSpec(u *unitSpec) core.PodSpec {
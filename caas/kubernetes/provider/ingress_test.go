@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+var ingressService = &core.Service{
+	ObjectMeta: v1.ObjectMeta{
+		Name: "juju-gitlab",
+	},
+	Spec: core.ServiceSpec{
+		Ports: []core.ServicePort{{
+			Port:       80,
+			TargetPort: intstr.FromInt(8080),
+		}},
+	},
+}
+
+func ingressPaths(paths ...string) *v1beta1.HTTPIngressRuleValue {
+	result := &v1beta1.HTTPIngressRuleValue{}
+	for _, path := range paths {
+		result.Paths = append(result.Paths, v1beta1.HTTPIngressPath{
+			Path: path,
+			Backend: v1beta1.IngressBackend{
+				ServiceName: "juju-gitlab", ServicePort: intstr.FromInt(8080)},
+		})
+	}
+	return result
+}
+
+func defaultIngressAnnotations() map[string]string {
+	return map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    "false",
+		"kubernetes.io/ingress.class":           "nginx",
+		"kubernetes.io/ingress.allow-http":      "false",
+		"ingress.kubernetes.io/ssl-passthrough": "false",
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	ingressArg := &v1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "juju-gitlab",
+			Labels:      map[string]string{"juju-application": "gitlab"},
+			Annotations: defaultIngressAnnotations(),
+		},
+		Spec: v1beta1.IngressSpec{
+			Rules: []v1beta1.IngressRule{{
+				Host:             "gitlab.example.com",
+				IngressRuleValue: v1beta1.IngressRuleValue{HTTP: ingressPaths("/")},
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-gitlab", v1.GetOptions{}).Times(1).
+			Return(ingressService, nil),
+		s.mockIngressInterface.EXPECT().Update(ingressArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingressArg).Times(1).
+			Return(ingressArg, nil),
+	)
+
+	err := s.broker.ExposeService("gitlab", map[string]string{"juju-application": "gitlab"}, application.ConfigAttributes{
+		"juju-external-hostname": "gitlab.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceCustomIngress(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	annotations := defaultIngressAnnotations()
+	annotations["certmanager.k8s.io/cluster-issuer"] = "letsencrypt"
+	annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "8m"
+	annotations["nginx.ingress.kubernetes.io/whitelist-source-range"] = "10.0.0.0/8,192.168.0.0/16"
+	annotations["kubernetes.io/ingress.class"] = "traefik"
+	ingressArg := &v1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "juju-gitlab",
+			Labels:      map[string]string{"juju-application": "gitlab"},
+			Annotations: annotations,
+		},
+		Spec: v1beta1.IngressSpec{
+			Rules: []v1beta1.IngressRule{{
+				Host:             "gitlab.example.com",
+				IngressRuleValue: v1beta1.IngressRuleValue{HTTP: ingressPaths("/gitlab", "/api")},
+			}, {
+				Host:             "git.example.com",
+				IngressRuleValue: v1beta1.IngressRuleValue{HTTP: ingressPaths("/gitlab", "/api")},
+			}},
+			TLS: []v1beta1.IngressTLS{{
+				Hosts:      []string{"gitlab.example.com", "git.example.com"},
+				SecretName: "juju-gitlab-tls",
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-gitlab", v1.GetOptions{}).Times(1).
+			Return(ingressService, nil),
+		s.mockIngressInterface.EXPECT().Update(ingressArg).Times(1).
+			Return(ingressArg, nil),
+	)
+
+	err := s.broker.ExposeService("gitlab", map[string]string{"juju-application": "gitlab"}, application.ConfigAttributes{
+		"juju-external-hostname":                "gitlab.example.com",
+		"kubernetes-ingress-hosts":              "git.example.com, gitlab.example.com",
+		"kubernetes-ingress-paths":              "$appname,api",
+		"kubernetes-ingress-tls-cluster-issuer": "letsencrypt",
+		"kubernetes-ingress-annotations": `
+nginx.ingress.kubernetes.io/proxy-body-size: 8m
+nginx.ingress.kubernetes.io/whitelist-source-range: 10.0.0.0/8,192.168.0.0/16
+kubernetes.io/ingress.class: traefik
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceInvalidAnnotations(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("gitlab", nil, application.ConfigAttributes{
+		"juju-external-hostname":         "gitlab.example.com",
+		"kubernetes-ingress-annotations": "no-value",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid kubernetes-ingress-annotations: .*`)
+}
+
+func (s *K8sBrokerSuite) TestValidateConfigInvalidAnnotations(c *gc.C) {
+	err := provider.ValidateConfig(application.ConfigAttributes{
+		"kubernetes-ingress-annotations": "nginx.ingress.kubernetes.io/proxy-body-size=8m",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid kubernetes-ingress-annotations: .*`)
+
+	err = provider.ValidateConfig(application.ConfigAttributes{
+		"kubernetes-ingress-annotations": "nginx.ingress.kubernetes.io/proxy-body-size: 8m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
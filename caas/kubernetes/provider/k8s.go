@@ -13,6 +13,7 @@ import (
 	"sync"
 	"text/template"
 	"time"
+	"unicode"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -58,6 +59,12 @@ const (
 	labelApplication = "juju-application"
 	labelModel       = "juju-model"
 
+	// labelNamespaceOwner records on a model's namespace whether Juju
+	// created the namespace, or adopted one created by someone else.
+	labelNamespaceOwner    = "juju-namespace-owner"
+	namespaceOwnerJuju     = "juju"
+	namespaceOwnerExternal = "external"
+
 	defaultOperatorStorageClassName = "juju-operator-storage"
 
 	gpuAffinityNodeSelectorKey = "gpu"
@@ -73,6 +80,10 @@ type kubernetesClient struct {
 	// creating k8s resources.
 	namespace string
 
+	// adoptNamespace is true if the namespace was created
+	// outside of Juju, and is not removed with the model.
+	adoptNamespace bool
+
 	lock   sync.Mutex
 	envCfg *config.Config
 
@@ -83,10 +94,13 @@ type kubernetesClient struct {
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
-//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv2beta1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta1 AutoscalingV2beta1Interface,HorizontalPodAutoscalerInterface
+//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface,DaemonSetInterface,ReplicaSetInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,ServiceAccountInterface,EventInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleBindingInterface,RoleInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -105,10 +119,17 @@ func NewK8sBroker(cloudSpec environs.CloudSpec, cfg *config.Config, newClient Ne
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	namespace := newCfg.Name()
+	adoptNamespace := false
+	if existing, _ := newCfg.UnknownAttrs()[caas.NamespaceConfigKey].(string); existing != "" {
+		namespace = existing
+		adoptNamespace = true
+	}
 	return &kubernetesClient{
 		Interface:           k8sClient,
 		apiextensionsClient: apiextensionsClient,
-		namespace:           newCfg.Name(),
+		namespace:           namespace,
+		adoptNamespace:      adoptNamespace,
 		envCfg:              newCfg,
 		modelUUID:           newCfg.UUID(),
 	}, nil
@@ -229,8 +250,19 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 
 // Destroy is part of the Broker interface.
 func (k *kubernetesClient) Destroy(context.ProviderCallContext) error {
-	if err := k.deleteNamespace(); err != nil {
-		return errors.Annotate(err, "deleting model namespace")
+	if k.adoptNamespace {
+		if err := k.releaseNamespace(); err != nil {
+			return errors.Annotate(err, "releasing adopted model namespace")
+		}
+	} else {
+		if err := k.deleteNamespace(); err != nil {
+			return errors.Annotate(err, "deleting model namespace")
+		}
+		// Delete any cluster roles and bindings created for the model's
+		// applications; like storage classes, they are not namespaced.
+		if err := k.deleteModelClusterRoles(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	// Delete any storage classes created as part of this model.
 	// Storage classes live outside the namespace so need to be deleted separately.
@@ -260,15 +292,13 @@ func (k *kubernetesClient) Namespaces() ([]string, error) {
 	return result, nil
 }
 
-// EnsureNamespace ensures this broker's namespace is created.
+// EnsureNamespace ensures this broker's namespace is created, or
+// that the existing namespace adopted by the model can be used by it.
 func (k *kubernetesClient) EnsureNamespace() error {
-	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: k.namespace}}
-	namespaces := k.CoreV1().Namespaces()
-	_, err := namespaces.Update(ns)
-	if k8serrors.IsNotFound(err) {
-		_, err = namespaces.Create(ns)
+	if k.adoptNamespace {
+		return errors.Trace(k.adoptExistingNamespace())
 	}
-	return errors.Trace(err)
+	return errors.Trace(k.ensureJujuNamespace())
 }
 
 func (k *kubernetesClient) deleteNamespace() error {
@@ -297,8 +327,6 @@ func (k *kubernetesClient) ensureSecret(imageSecretName, appName string, imageDe
 	if err != nil {
 		return errors.Trace(err)
 	}
-	secrets := k.CoreV1().Secrets(k.namespace)
-
 	newSecret := &core.Secret{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      imageSecretName,
@@ -309,10 +337,14 @@ func (k *kubernetesClient) ensureSecret(imageSecretName, appName string, imageDe
 			core.DockerConfigJsonKey: secretData,
 		},
 	}
+	return errors.Trace(k.updateOrCreateSecret(newSecret))
+}
 
-	_, err = secrets.Update(newSecret)
+func (k *kubernetesClient) updateOrCreateSecret(spec *core.Secret) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	_, err := secrets.Update(spec)
 	if k8serrors.IsNotFound(err) {
-		_, err = secrets.Create(newSecret)
+		_, err = secrets.Create(spec)
 	}
 	return errors.Trace(err)
 }
@@ -353,6 +385,12 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 		return errors.Annotatef(err, "ensuring operator namespace %v", k.namespace)
 	}
 
+	tags := make(map[string]string)
+	for k, v := range config.ResourceTags {
+		tags[k] = v
+	}
+	tags[labelOperator] = appName
+
 	// TODO(caas) use secrets for storing agent password?
 	if config.AgentConf == nil {
 		// We expect that the config map already exists,
@@ -363,7 +401,7 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 			return errors.Annotatef(err, "config map for %q should already exist", appName)
 		}
 	} else {
-		if err := k.ensureConfigMap(operatorConfigMap(appName, config)); err != nil {
+		if err := k.ensureConfigMap(operatorConfigMap(appName, tags, config)); err != nil {
 			return errors.Annotate(err, "creating or updating ConfigMap")
 		}
 	}
@@ -374,12 +412,6 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 	}
 	storageTags[labelOperator] = appName
 
-	tags := make(map[string]string)
-	for k, v := range config.ResourceTags {
-		tags[k] = v
-	}
-	tags[labelOperator] = appName
-
 	// Set up the parameters for creating charm storage.
 	volStorageLabel := fmt.Sprintf("%s-operator-storage", appName)
 	params := volumeParams{
@@ -726,6 +758,9 @@ func (k *kubernetesClient) Service(appName string) (*caas.Service, error) {
 func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	logger.Debugf("deleting application %s", appName)
 
+	if err := k.deleteAutoscaler(appName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
@@ -736,6 +771,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteDaemonSet(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -760,7 +798,13 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return nil
+	if err := k.deletePodSpecConfigMaps(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.DeleteNetworkPolicy(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteServiceAccountAndRoles(appName))
 }
 
 // EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
@@ -830,6 +874,11 @@ func (k *kubernetesClient) EnsureService(
 		return errors.Errorf("number of units must be >= 0")
 	}
 	if numUnits == 0 {
+		// Remove any autoscaler first so it does not
+		// scale the pods back up.
+		if err := k.deleteAutoscaler(appName); err != nil {
+			return errors.Trace(err)
+		}
 		return k.deleteAllPods(appName)
 	}
 	if params == nil || params.PodSpec == nil {
@@ -838,6 +887,15 @@ func (k *kubernetesClient) EnsureService(
 	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) == 0 {
 		return errors.Errorf("kubernetes service is required when using storage")
 	}
+	// The application may no longer be trusted with the access the pod
+	// spec asks for, eg after "juju trust --remove"; revoke the cluster
+	// wide access granted when it was trusted.
+	if err := params.PodSpec.ValidateTrust(config.GetBool(trustConfigKey, false)); err != nil {
+		if err := k.deleteClusterRoleAndBinding(appName); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(err)
+	}
 
 	var cleanups []func()
 	defer func() {
@@ -881,7 +939,7 @@ func (k *kubernetesClient) EnsureService(
 		resourceTags[k] = v
 	}
 	resourceTags[labelApplication] = appName
-	for _, c := range params.PodSpec.Containers {
+	for _, c := range append(params.PodSpec.Containers, params.PodSpec.InitContainers...) {
 		if c.ImageDetails.Password == "" {
 			continue
 		}
@@ -892,9 +950,51 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName) })
 	}
 
+	objCleanups, err := k.ensurePodSpecObjects(appName, resourceTags, params.PodSpec)
+	cleanups = append(cleanups, objCleanups...)
+	if err != nil {
+		return errors.Annotatef(err, "creating secrets and config maps for %s", appName)
+	}
+
+	if sa := params.PodSpec.ServiceAccount; sa != nil {
+		saCleanups, err := k.configureServiceAccount(appName, resourceTags, sa)
+		cleanups = append(cleanups, saCleanups...)
+		if err != nil {
+			return errors.Annotatef(err, "configuring service account for %s", appName)
+		}
+		unitSpec.Pod.ServiceAccountName = serviceAccountName(appName)
+		unitSpec.Pod.AutomountServiceAccountToken = sa.AutomountServiceAccountToken
+	} else if err := k.removeServiceAccount(appName); err != nil {
+		return errors.Annotatef(err, "removing service account for %s", appName)
+	}
+
+	// A daemon set runs a pod on every node, so the number of units is
+	// decided by the cluster rather than numUnits.
+	if params.PodSpec.DeploymentType == caas.DeploymentDaemon {
+		if len(params.Filesystems) > 0 {
+			return errors.NotSupportedf("storage for daemon application %s", appName)
+		}
+		if IsAutoscaled(config) {
+			return errors.NotSupportedf("autoscaling daemon application %s", appName)
+		}
+		if err := k.configureDaemonSet(appName, resourceTags, unitSpec, params.PodSpec, params.PodSpec.UpdateStrategy); err != nil {
+			return errors.Annotate(err, "creating or updating DaemonSet")
+		}
+		cleanups = append(cleanups, func() { k.deleteDaemonSet(deploymentName(appName)) })
+		// Remove the workload of an application switched to the
+		// daemon deployment type.
+		if err := k.deleteDeployment(deploymentName(appName)); err != nil {
+			return errors.Trace(err)
+		}
+		if err := k.deleteStatefulSet(deploymentName(appName)); err != nil {
+			return errors.Trace(err)
+		}
+		return k.configureServiceFrontend(appName, unitSpec, params, resourceTags, config)
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
-	useStatefulSet := len(params.Filesystems) > 0
+	useStatefulSet := len(params.Filesystems) > 0 || params.PodSpec.DeploymentType == caas.DeploymentStateful
 	if !useStatefulSet {
 		statefulsets := k.AppsV1().StatefulSets(k.namespace)
 		_, err := statefulsets.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
@@ -908,18 +1008,53 @@ func (k *kubernetesClient) EnsureService(
 	}
 
 	numPods := int32(numUnits)
+	replicas := &numPods
+	if IsAutoscaled(config) {
+		// The autoscaler decides the number of replicas, so
+		// keep whatever it last chose rather than numUnits.
+		replicas = nil
+	}
+	kind := "Deployment"
 	if useStatefulSet {
-		if err := k.configureStatefulSet(appName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		kind = "StatefulSet"
+		if err := k.configureStatefulSet(appName, resourceTags, unitSpec, params.PodSpec, replicas, params.Filesystems, params.PodSpec.UpdateStrategy); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	} else {
-		if err := k.configureDeployment(appName, deploymentName(appName), resourceTags, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+		if err := k.configureDeployment(appName, deploymentName(appName), resourceTags, unitSpec, params.PodSpec, replicas, params.PodSpec.UpdateStrategy); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
+	// Remove the workload of an application switched from another
+	// deployment type.
+	if useStatefulSet {
+		if err := k.deleteDeployment(deploymentName(appName)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := k.deleteDaemonSet(deploymentName(appName)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.configureServiceFrontend(appName, unitSpec, params, resourceTags, config); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.configureAutoscaler(appName, kind, resourceTags, config); err != nil {
+		return errors.Annotatef(err, "configuring autoscaler for %s", appName)
+	}
+	return nil
+}
 
+// configureServiceFrontend creates or updates the service in front of
+// the application's pods, unless the pod spec asks for it to be omitted.
+func (k *kubernetesClient) configureServiceFrontend(
+	appName string, unitSpec *unitSpec, params *caas.ServiceParams,
+	resourceTags map[string]string, config application.ConfigAttributes,
+) error {
+	if params.PodSpec.OmitServiceFrontend {
+		return nil
+	}
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
 		for _, p := range c.Ports {
@@ -929,10 +1064,8 @@ func (k *kubernetesClient) EnsureService(
 			ports = append(ports, p)
 		}
 	}
-	if !params.PodSpec.OmitServiceFrontend {
-		if err := k.configureService(appName, ports, resourceTags, config); err != nil {
-			return errors.Annotatef(err, "creating or updating service for %v", appName)
-		}
+	if err := k.configureService(appName, ports, resourceTags, config); err != nil {
+		return errors.Annotatef(err, "creating or updating service for %v", appName)
 	}
 	return nil
 }
@@ -952,15 +1085,17 @@ func (k *kubernetesClient) deleteAllPods(appName string) error {
 
 	deployments := k.AppsV1().Deployments(k.namespace)
 	deployment, err := deployments.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
-	if k8serrors.IsNotFound(err) {
-		return nil
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
 	}
-	if err != nil {
+	if err == nil {
+		deployment.Spec.Replicas = &zero
+		_, err = deployments.Update(deployment)
 		return errors.Trace(err)
 	}
-	deployment.Spec.Replicas = &zero
-	_, err = deployments.Update(deployment)
-	return errors.Trace(err)
+
+	// A daemon set cannot be scaled down, so remove it instead.
+	return errors.Trace(k.deleteDaemonSet(deploymentName(appName)))
 }
 
 func (k *kubernetesClient) configureStorage(
@@ -1057,31 +1192,40 @@ func (k *kubernetesClient) configureConstraint(unitSpec *unitSpec, constraint, v
 
 type configMapNameFunc func(fileSetName string) string
 
-func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers []caas.ContainerSpec, cfgMapName configMapNameFunc) error {
-	for i, container := range containers {
-		for _, fileSet := range container.Files {
-			cfgName := cfgMapName(fileSet.Name)
-			vol := core.Volume{Name: cfgName}
-			if err := k.ensureConfigMap(filesetConfigMap(cfgName, &fileSet)); err != nil {
-				return errors.Annotatef(err, "creating or updating ConfigMap for file set %v", cfgName)
-			}
-			vol.ConfigMap = &core.ConfigMapVolumeSource{
-				LocalObjectReference: core.LocalObjectReference{
-					Name: cfgName,
-				},
+func (k *kubernetesClient) configurePodFiles(
+	podSpec *core.PodSpec, spec *caas.PodSpec, cfgMapName configMapNameFunc, labels map[string]string,
+) error {
+	mount := func(podContainers []core.Container, containers []caas.ContainerSpec) error {
+		for i, container := range containers {
+			for _, fileSet := range container.Files {
+				cfgName := cfgMapName(fileSet.Name)
+				vol := core.Volume{Name: cfgName}
+				if err := k.ensureConfigMap(filesetConfigMap(cfgName, labels, &fileSet)); err != nil {
+					return errors.Annotatef(err, "creating or updating ConfigMap for file set %v", cfgName)
+				}
+				vol.ConfigMap = &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{
+						Name: cfgName,
+					},
+				}
+				podSpec.Volumes = append(podSpec.Volumes, vol)
+				podContainers[i].VolumeMounts = append(podContainers[i].VolumeMounts, core.VolumeMount{
+					Name:      cfgName,
+					MountPath: fileSet.MountPath,
+				})
 			}
-			podSpec.Volumes = append(podSpec.Volumes, vol)
-			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, core.VolumeMount{
-				Name:      cfgName,
-				MountPath: fileSet.MountPath,
-			})
 		}
+		return nil
 	}
-	return nil
+	if err := mount(podSpec.Containers, spec.Containers); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(mount(podSpec.InitContainers, spec.InitContainers))
 }
 
 func (k *kubernetesClient) configureDeployment(
-	appName, deploymentName string, labels map[string]string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32,
+	appName, deploymentName string, labels map[string]string, unitSpec *unitSpec, spec *caas.PodSpec, replicas *int32,
+	updateStrategy *caas.UpdateStrategy,
 ) error {
 	logger.Debugf("creating/updating deployment for %s", appName)
 
@@ -1090,7 +1234,7 @@ func (k *kubernetesClient) configureDeployment(
 		return applicationConfigMapName(appName, fileSetName)
 	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(&podSpec, spec, cfgName, labels); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -1112,15 +1256,42 @@ func (k *kubernetesClient) configureDeployment(
 			},
 		},
 	}
+	if err := applyDeploymentStrategy(&deployment.Spec, updateStrategy); err != nil {
+		return errors.Trace(err)
+	}
 	return k.ensureDeployment(deployment)
 }
 
 func (k *kubernetesClient) ensureDeployment(spec *apps.Deployment) error {
+	hash, err := podTemplateHash(spec.Spec.Template)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	spec.Annotations[podTemplateHashAnnotation] = hash
+
 	deployments := k.AppsV1().Deployments(k.namespace)
-	_, err := deployments.Update(spec)
+	existing, err := deployments.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
 	if k8serrors.IsNotFound(err) {
 		_, err = deployments.Create(spec)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Spec.Replicas == nil {
+		// The number of replicas is decided by an autoscaler.
+		spec.Spec.Replicas = existing.Spec.Replicas
 	}
+	if existing.Annotations[abortedPodTemplateHashAnnotation] == hash {
+		// The rollout of this pod template was aborted, so keep the
+		// previous one until the charm sets a different pod spec.
+		logger.Infof("not rolling out aborted pod spec of %s again", spec.Name)
+		spec.Spec.Template = existing.Spec.Template
+		spec.Annotations[abortedPodTemplateHashAnnotation] = hash
+	}
+	_, err = deployments.Update(spec)
 	return errors.Trace(err)
 }
 
@@ -1137,7 +1308,8 @@ func (k *kubernetesClient) deleteDeployment(name string) error {
 
 func (k *kubernetesClient) configureStatefulSet(
 	appName string, labels map[string]string, unitSpec *unitSpec,
-	containers []caas.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	spec *caas.PodSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	updateStrategy *caas.UpdateStrategy,
 ) error {
 	logger.Debugf("creating/updating stateful set for %s", appName)
 
@@ -1162,8 +1334,11 @@ func (k *kubernetesClient) configureStatefulSet(
 			PodManagementPolicy: apps.ParallelPodManagement,
 		},
 	}
+	if err := applyStatefulSetStrategy(&statefulset.Spec, updateStrategy); err != nil {
+		return errors.Trace(err)
+	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(&podSpec, spec, cfgName, labels); err != nil {
 		return errors.Trace(err)
 	}
 	existingPodSpec := podSpec
@@ -1178,6 +1353,16 @@ func (k *kubernetesClient) configureStatefulSet(
 
 func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPodSpec core.PodSpec) error {
 	statefulsets := k.AppsV1().StatefulSets(k.namespace)
+	if spec.Spec.Replicas == nil {
+		// The number of replicas is decided by an autoscaler,
+		// so keep the number of the existing stateful set.
+		existing, err := statefulsets.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
+		if err == nil {
+			spec.Spec.Replicas = existing.Spec.Replicas
+		} else if !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
 	_, err := statefulsets.Update(spec)
 	if k8serrors.IsNotFound(err) {
 		_, err = statefulsets.Create(spec)
@@ -1196,6 +1381,7 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	// TODO(caas) - allow extra storage to be added
 	existing.Spec.Replicas = spec.Spec.Replicas
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
+	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
 	_, err = statefulsets.Update(existing)
 	return errors.Trace(err)
 }
@@ -1211,6 +1397,78 @@ func (k *kubernetesClient) deleteStatefulSet(name string) error {
 	return errors.Trace(err)
 }
 
+func (k *kubernetesClient) configureDaemonSet(
+	appName string, labels map[string]string, unitSpec *unitSpec, spec *caas.PodSpec,
+	updateStrategy *caas.UpdateStrategy,
+) error {
+	logger.Debugf("creating/updating daemon set for %s", appName)
+
+	// Add the specified file to the pod spec.
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(appName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, spec, cfgName, labels); err != nil {
+		return errors.Trace(err)
+	}
+
+	daemonSet := &apps.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: labels},
+		Spec: apps.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: deploymentName(appName) + "-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	if err := applyDaemonSetStrategy(&daemonSet.Spec, updateStrategy); err != nil {
+		return errors.Trace(err)
+	}
+	return k.ensureDaemonSet(daemonSet)
+}
+
+func (k *kubernetesClient) ensureDaemonSet(spec *apps.DaemonSet) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	_, err := daemonSets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = daemonSets.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteDaemonSet(name string) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	err := daemonSets.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DaemonScale returns the number of nodes the daemon set of the
+// specified application is to run its pods on.
+func (k *kubernetesClient) DaemonScale(appName string) (int, error) {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	daemonSet, err := daemonSets.Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return 0, errors.NotFoundf("daemon set for application %q", appName)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int(daemonSet.Status.DesiredNumberScheduled), nil
+}
+
 func (k *kubernetesClient) deleteVolumeClaims(appName string, p *core.Pod) ([]string, error) {
 	volumesByName := make(map[string]core.Volume)
 	for _, pv := range p.Spec.Volumes {
@@ -1327,12 +1585,50 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
 	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
 	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
-	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
-	if httpPath == "$appname" {
-		httpPath = appName
+	httpPaths := splitConfigList(config.GetString(ingressPathsKey, ""))
+	if len(httpPaths) == 0 {
+		httpPaths = []string{config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)}
 	}
-	if !strings.HasPrefix(httpPath, "/") {
-		httpPath = "/" + httpPath
+	for i, httpPath := range httpPaths {
+		if httpPath == "$appname" {
+			httpPath = appName
+		}
+		if !strings.HasPrefix(httpPath, "/") {
+			httpPath = "/" + httpPath
+		}
+		httpPaths[i] = httpPath
+	}
+	hosts := []string{host}
+	for _, h := range splitConfigList(config.GetString(ingressHostsKey, "")) {
+		if h != host {
+			hosts = append(hosts, h)
+		}
+	}
+
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
+		"kubernetes.io/ingress.class":           ingressClass,
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
+	}
+	tlsSecret := config.GetString(ingressTLSSecretKey, "")
+	if issuer := config.GetString(ingressTLSIssuerKey, ""); issuer != "" {
+		annotations[certManagerIssuerAnnotation] = issuer
+	}
+	if issuer := config.GetString(ingressTLSClusterIssuerKey, ""); issuer != "" {
+		annotations[certManagerClusterIssuerAnnotation] = issuer
+	}
+	if tlsSecret == "" && (annotations[certManagerIssuerAnnotation] != "" || annotations[certManagerClusterIssuerAnnotation] != "") {
+		// cert-manager stores the certificate it issues in this secret.
+		tlsSecret = deploymentName(appName) + "-tls"
+	}
+	extraAnnotations, err := parseIngressAnnotations(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for k, v := range extraAnnotations {
+		annotations[k] = v
 	}
 
 	svc, err := k.CoreV1().Services(k.namespace).Get(deploymentName(appName), v1.GetOptions{})
@@ -1342,34 +1638,46 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+	var paths []v1beta1.HTTPIngressPath
+	for _, httpPath := range httpPaths {
+		paths = append(paths, v1beta1.HTTPIngressPath{
+			Path: httpPath,
+			Backend: v1beta1.IngressBackend{
+				ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
+		})
+	}
 	spec := &v1beta1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName(appName),
-			Labels: resourceTags,
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
-		},
-		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{{
-				Host: host,
-				IngressRuleValue: v1beta1.IngressRuleValue{
-					HTTP: &v1beta1.HTTPIngressRuleValue{
-						Paths: []v1beta1.HTTPIngressPath{{
-							Path: httpPath,
-							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
-						}}},
-				}}},
+			Name:        deploymentName(appName),
+			Labels:      resourceTags,
+			Annotations: annotations,
 		},
 	}
+	for _, h := range hosts {
+		spec.Spec.Rules = append(spec.Spec.Rules, v1beta1.IngressRule{
+			Host: h,
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{Paths: paths},
+			},
+		})
+	}
+	if tlsSecret != "" {
+		spec.Spec.TLS = []v1beta1.IngressTLS{{
+			Hosts:      hosts,
+			SecretName: tlsSecret,
+		}}
+	}
 	return k.ensureIngress(spec)
 }
 
+// splitConfigList splits a config value holding a list
+// separated by commas or whitespace.
+func splitConfigList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
@@ -1415,7 +1723,18 @@ func (k *kubernetesClient) WatchUnits(appName string) (watcher.NotifyWatcher, er
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return newKubernetesWatcher(w, appName)
+	// Warnings about the pods, eg that an image cannot be pulled,
+	// change the status of units without changing the pods.
+	events := k.CoreV1().Events(k.namespace)
+	eventsWatcher, err := events.Watch(v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", core.EventTypeWarning).String(),
+		Watch:         true,
+	})
+	if err != nil {
+		w.Stop()
+		return nil, errors.Trace(err)
+	}
+	return newKubernetesEventWatcher(w, eventsWatcher, applicationEventFilter(appName), appName)
 }
 
 // WatchOperator returns a watcher which notifies when there
@@ -1438,6 +1757,8 @@ var jujuPVNameRegexp = regexp.MustCompile(`^juju-(?P<storageName>\D+)-\d+$`)
 
 // Units returns all units and any associated filesystems of the specified application.
 // Filesystems are mounted via volumes bound to the unit.
+// The units of a daemon application are the pods of its daemon set, one
+// on each node, so they come and go as nodes join and leave the cluster.
 func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
@@ -1617,20 +1938,49 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 		}
 	}
 
-	if statusMessage == "" {
+	// A running pod whose containers have not passed their
+	// readiness probes is not yet ready for the workload.
+	if jujuStatus == status.Running {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != core.PodReady || cond.Status != core.ConditionFalse {
+				continue
+			}
+			jujuStatus = status.Waiting
+			if cond.Message != "" {
+				statusMessage = cond.Message
+				since = cond.LastTransitionTime.Time
+			}
+			break
+		}
+	}
+
+	// A container which cannot run explains why the
+	// unit is not running better than the pod does.
+	if message, containerStatus, at, ok := containerProblem(pod); ok && !terminated {
+		if !at.IsZero() {
+			since = at
+		}
+		return message, containerStatus, since, nil
+	}
+
+	pending := pod.Status.Phase == core.PodPending && !terminated
+	if statusMessage == "" || pending {
 		// If there are any events for this pod we can use the
-		// most recent to set the status.
-		events := k.CoreV1().Events(k.namespace)
-		eventList, err := events.List(v1.ListOptions{
-			IncludeUninitialized: true,
-			FieldSelector:        fields.OneTermEqualSelector("involvedObject.name", pod.Name).String(),
-		})
+		// most recent to set the status. Warnings about a pending
+		// pod, eg that it cannot be scheduled, also set the status.
+		events, err := k.podEvents(pod.Name)
 		if err != nil {
 			return "", "", time.Time{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList.Items); count > 0 {
-			statusMessage = eventList.Items[count-1].Message
+		if evt := latestEvent(events); evt != nil {
+			eventStatus, ok := podEventStatus[evt.Reason]
+			if pending && ok && evt.Type == core.EventTypeWarning {
+				jujuStatus = eventStatus
+				statusMessage = evt.Message
+				since = evt.LastTimestamp.Time
+			} else if statusMessage == "" {
+				statusMessage = evt.Message
+			}
 		}
 	}
 
@@ -1683,10 +2033,11 @@ func (k *kubernetesClient) jujuVolumeStatus(pvPhase core.PersistentVolumePhase)
 
 // filesetConfigMap returns a *core.ConfigMap for a pod
 // of the specified unit, with the specified files.
-func filesetConfigMap(configMapName string, files *caas.FileSet) *core.ConfigMap {
+func filesetConfigMap(configMapName string, labels map[string]string, files *caas.FileSet) *core.ConfigMap {
 	result := &core.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
-			Name: configMapName,
+			Name:   configMapName,
+			Labels: labels,
 		},
 		Data: map[string]string{},
 	}
@@ -1757,11 +2108,12 @@ func operatorPod(appName, agentPath, operatorImagePath, version string, tags map
 
 // operatorConfigMap returns a *core.ConfigMap for the operator pod
 // of the specified application, with the specified configuration.
-func operatorConfigMap(appName string, config *caas.OperatorConfig) *core.ConfigMap {
+func operatorConfigMap(appName string, labels map[string]string, config *caas.OperatorConfig) *core.ConfigMap {
 	configMapName := operatorConfigMapName(appName)
 	return &core.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
-			Name: configMapName,
+			Name:   configMapName,
+			Labels: labels,
 		},
 		Data: map[string]string{
 			appName + "-agent.conf": string(config.AgentConf),
@@ -1817,18 +2169,54 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 	var unitSpec unitSpec
 	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(unitSpecString), len(unitSpecString))
 	if err := decoder.Decode(&unitSpec); err != nil {
-		logger.Errorf("unable to parse %q pod spec: %+v\n%v", appName, *podSpec, unitSpecString)
+		// Only log the rendered containers, as the pod spec may hold secrets.
+		logger.Errorf("unable to parse %q pod spec:\n%v", appName, unitSpecString)
 		return nil, errors.Trace(err)
 	}
 
-	var imageSecretNames []core.LocalObjectReference
+	if len(podSpec.InitContainers) > 0 {
+		// Init containers use the same template, with the results
+		// moved across to the pod's init containers.
+		buf.Reset()
+		if err := tmpl.Execute(&buf, &caas.PodSpec{Containers: podSpec.InitContainers}); err != nil {
+			return nil, errors.Trace(err)
+		}
+		initSpecString := buf.String()
+		var initSpec unitSpec
+		decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(initSpecString), len(initSpecString))
+		if err := decoder.Decode(&initSpec); err != nil {
+			logger.Errorf("unable to parse %q init containers:\n%v", appName, initSpecString)
+			return nil, errors.Trace(err)
+		}
+		unitSpec.Pod.InitContainers = initSpec.Pod.Containers
+	}
+
 	// Now fill in the hard bits progamatically.
-	for i, c := range podSpec.Containers {
+	imageSecretNames, err := populateContainerDetails(appName, unitSpec.Pod.Containers, podSpec.Containers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	initImageSecretNames, err := populateContainerDetails(appName, unitSpec.Pod.InitContainers, podSpec.InitContainers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitSpec.Pod.ImagePullSecrets = append(imageSecretNames, initImageSecretNames...)
+	mountPodSpecObjects(appName, &unitSpec.Pod, podSpec)
+	return &unitSpec, nil
+}
+
+// populateContainerDetails fills in the parts of the pod containers
+// which are not rendered by the template, returning references to
+// the secrets needed to pull the container images.
+func populateContainerDetails(
+	appName string, podContainers []core.Container, containers []caas.ContainerSpec,
+) (imageSecretNames []core.LocalObjectReference, _ error) {
+	for i, c := range containers {
 		if c.Image != "" {
 			logger.Warningf("Image parameter deprecated, use ImageDetails")
-			unitSpec.Pod.Containers[i].Image = c.Image
+			podContainers[i].Image = c.Image
 		} else {
-			unitSpec.Pod.Containers[i].Image = c.ImageDetails.ImagePath
+			podContainers[i].Image = c.ImageDetails.ImagePath
 		}
 		if c.ImageDetails.Password != "" {
 			imageSecretNames = append(imageSecretNames, core.LocalObjectReference{Name: appSecretName(appName, c.Name)})
@@ -1841,16 +2229,19 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 		if !ok {
 			return nil, errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
 		}
-		unitSpec.Pod.Containers[i].ImagePullPolicy = spec.ImagePullPolicy
+		podContainers[i].ImagePullPolicy = spec.ImagePullPolicy
 		if spec.LivenessProbe != nil {
-			unitSpec.Pod.Containers[i].LivenessProbe = spec.LivenessProbe
+			podContainers[i].LivenessProbe = spec.LivenessProbe
 		}
 		if spec.ReadinessProbe != nil {
-			unitSpec.Pod.Containers[i].ReadinessProbe = spec.ReadinessProbe
+			podContainers[i].ReadinessProbe = spec.ReadinessProbe
+		}
+		podContainers[i].Resources = spec.Resources
+		if spec.SecurityContext != nil {
+			podContainers[i].SecurityContext = spec.SecurityContext
 		}
 	}
-	unitSpec.Pod.ImagePullSecrets = imageSecretNames
-	return &unitSpec, nil
+	return imageSecretNames, nil
 }
 
 func operatorName(appName string) string {
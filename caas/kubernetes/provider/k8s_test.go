@@ -11,6 +11,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -83,6 +84,97 @@ func (s *K8sSuite) TestMakeUnitSpecNoConfigConfig(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestMakeUnitSpecInitContainers(c *gc.C) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{core.ResourceCPU: resource.MustParse("100m")},
+		Limits:   core.ResourceList{core.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	securityContext := &core.SecurityContext{RunAsNonRoot: boolPtr(true)}
+	podSpec := caas.PodSpec{
+		InitContainers: []caas.ContainerSpec{{
+			Name:         "test-init",
+			ImageDetails: caas.ImageDetails{ImagePath: "juju/init", Username: "fred", Password: "secret"},
+			Command:      []string{"sh", "-c", "migrate"},
+		}},
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+			Image: "juju/image",
+			ProviderContainer: &provider.K8sContainerSpec{
+				Resources:       resources,
+				SecurityContext: securityContext,
+			},
+		}},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		ImagePullSecrets: []core.LocalObjectReference{{Name: "juju-app-name-test-init-secret"}},
+		InitContainers: []core.Container{{
+			Name:    "test-init",
+			Image:   "juju/init",
+			Command: []string{"sh", "-c", "migrate"},
+		}},
+		Containers: []core.Container{{
+			Name:            "test",
+			Image:           "juju/image",
+			Ports:           []core.ContainerPort{{ContainerPort: int32(80), Protocol: core.ProtocolTCP}},
+			Resources:       resources,
+			SecurityContext: securityContext,
+		}},
+	})
+}
+
+func (s *K8sSuite) TestMakeUnitSpecSecretsAndConfigMaps(c *gc.C) {
+	podSpec := caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:    "test",
+			Image:   "juju/image",
+			EnvFrom: []caas.EnvFromSource{{Secret: "creds"}, {ConfigMap: "settings"}},
+			Mounts:  []caas.ObjectMount{{Secret: "creds", MountPath: "/etc/creds"}},
+		}, {
+			Name:   "test2",
+			Image:  "juju/image2",
+			Mounts: []caas.ObjectMount{{Secret: "creds", MountPath: "/creds"}},
+		}},
+		Secrets:    []caas.Secret{{Name: "creds", Data: map[string]string{"password": "s3cret"}}},
+		ConfigMaps: []caas.ConfigMap{{Name: "settings", Data: map[string]string{"foo": "bar"}}},
+	}
+	c.Assert(podSpec.Validate(), jc.ErrorIsNil)
+	spec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		Containers: []core.Container{{
+			Name:  "test",
+			Image: "juju/image",
+			EnvFrom: []core.EnvFromSource{{
+				SecretRef: &core.SecretEnvSource{
+					LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-creds-secret"},
+				},
+			}, {
+				ConfigMapRef: &core.ConfigMapEnvSource{
+					LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-settings-config"},
+				},
+			}},
+			VolumeMounts: []core.VolumeMount{{
+				Name: "juju-app-name-creds-secret", MountPath: "/etc/creds", ReadOnly: true,
+			}},
+		}, {
+			Name:  "test2",
+			Image: "juju/image2",
+			VolumeMounts: []core.VolumeMount{{
+				Name: "juju-app-name-creds-secret", MountPath: "/creds", ReadOnly: true,
+			}},
+		}},
+		Volumes: []core.Volume{{
+			Name: "juju-app-name-creds-secret",
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{SecretName: "juju-app-name-creds-secret"},
+			},
+		}},
+	})
+}
+
 var basicPodspec = &caas.PodSpec{
 	Containers: []caas.ContainerSpec{{
 		Name:         "test",
@@ -235,16 +327,28 @@ func (s *K8sBrokerSuite) TestSetConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func namespaceArg() *core.Namespace {
+	return &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name: "test",
+		Labels: map[string]string{
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "juju",
+		},
+	}}
+}
+
 func (s *K8sBrokerSuite) TestEnsureNamespace(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
-	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+	ns := namespaceArg()
 	gomock.InOrder(
-		s.mockNamespaces.EXPECT().Update(ns).Times(1).
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockNamespaces.EXPECT().Create(ns).Times(1),
 		// Idempotent check.
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(namespaceArg(), nil),
 		s.mockNamespaces.EXPECT().Update(ns).Times(1),
 	)
 
@@ -280,6 +384,16 @@ func (s *K8sBrokerSuite) TestDestroy(c *gc.C) {
 	gomock.InOrder(
 		s.mockNamespaces.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model-uuid==" + testing.ModelTag.Id()},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model-uuid==" + testing.ModelTag.Id()},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().DeleteCollection(
 			s.deleteOptions(v1.DeletePropagationForeground),
 			v1.ListOptions{LabelSelector: "juju-model==test"},
@@ -413,164 +527,1026 @@ func unitStatefulSetArg(numUnits int32, scName string, podSpec core.PodSpec) *ap
 			PodManagementPolicy: apps.ParallelPodManagement,
 		},
 	}
-}
-
-func (s *K8sBrokerSuite) TestEnsureOperator(c *gc.C) {
-	ctrl := s.setupBroker(c)
-	defer ctrl.Finish()
-
-	configMapArg := &core.ConfigMap{
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperator(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-operator-test-config",
+			Labels: map[string]string{"juju-operator": "test", "fred": "mary"},
+		},
+		Data: map[string]string{
+			"test-agent.conf": "agent-conf-data",
+		},
+	}
+	statefulSetArg := operatorStatefulSetArg(1, "test-juju-operator-storage")
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(namespaceArg(), nil),
+		s.mockNamespaces.EXPECT().Update(namespaceArg()).Times(1),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1),
+		s.mockStorageClass.EXPECT().Get("test-juju-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-juju-operator-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		ResourceTags:      map[string]string{"fred": "mary"},
+		CharmStorage: caas.CharmStorageParams{
+			Size:         uint64(10),
+			Provider:     "kubernetes",
+			ResourceTags: map[string]string{"foo": "bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfig(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	statefulSetArg := operatorStatefulSetArg(1, "test-juju-operator-storage")
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(namespaceArg(), nil),
+		s.mockNamespaces.EXPECT().Update(namespaceArg()).Times(1),
+		s.mockConfigMaps.EXPECT().Get("juju-operator-test-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, nil),
+		s.mockStorageClass.EXPECT().Get("test-juju-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-juju-operator-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		ResourceTags:      map[string]string{"fred": "mary"},
+		CharmStorage: caas.CharmStorageParams{
+			Size:         uint64(10),
+			Provider:     "kubernetes",
+			ResourceTags: map[string]string{"foo": "bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfigMissingConfigMap(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(namespaceArg(), nil),
+		s.mockNamespaces.EXPECT().Update(namespaceArg()).Times(1),
+		s.mockConfigMaps.EXPECT().Get("juju-operator-test-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		CharmStorage: caas.CharmStorageParams{
+			Size:     uint64(10),
+			Provider: "kubernetes",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `config map for "test" should already exist:  "test" not found`)
+}
+
+func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// Delete operations below return a not found to ensure it's treated as a no-op.
+	gomock.InOrder(
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).
+			Return(&core.PodList{Items: []core.Pod{}}, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.SecretList{Items: []core.Secret{{
+				ObjectMeta: v1.ObjectMeta{Name: "secret"},
+			}}}, nil),
+		s.mockSecrets.EXPECT().Delete("secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+			Return(&core.ConfigMapList{Items: []core.ConfigMap{{
+				ObjectMeta: v1.ObjectMeta{Name: "juju-test-settings-config"},
+			}}}, nil),
+		s.mockConfigMaps.EXPECT().Delete("juju-test-settings-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.DeleteService("test")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	two := int32(2)
+	dc := &apps.Deployment{ObjectMeta: v1.ObjectMeta{Name: "juju-unit-storage"}, Spec: apps.DeploymentSpec{Replicas: &two}}
+	zero := int32(0)
+	emptyDc := dc
+	emptyDc.Spec.Replicas = &zero
+	gomock.InOrder(
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(dc, nil),
+		s.mockDeployments.EXPECT().Update(emptyDc).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{}
+	err := s.broker.EnsureService("app-name", nil, params, 0, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoUnitsDaemonSet(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	params := &caas.ServiceParams{}
+	err := s.broker.EnsureService("app-name", nil, params, 0, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSet(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.DeploymentType = caas.DeploymentDaemon
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	daemonSetArg := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: map[string]string{"juju-application": "app-name"}},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       map[string]string{"juju-application": "app-name"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	// The number of units is ignored for a daemon set.
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Update(daemonSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Create(daemonSetArg).Times(1).
+			Return(nil, nil),
+		// The application was previously deployed as a deployment.
+		s.mockDeployments.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockStatefulSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 3, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSetWithStorage(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.DeploymentType = caas.DeploymentDaemon
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Delete("juju-app-name-test-secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+		}},
+	}
+	statusCallback := func(appName string, st status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}
+	err := s.broker.EnsureService("app-name", statusCallback, params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, "storage for daemon application app-name not supported")
+}
+
+func (s *K8sBrokerSuite) TestDaemonScale(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	daemonSet := &appsv1.DaemonSet{
+		Status: appsv1.DaemonSetStatus{
+			CurrentNumberScheduled: 2,
+			DesiredNumberScheduled: 3,
+		},
+	}
+	s.mockDaemonSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(daemonSet, nil)
+
+	scale, err := s.broker.DaemonScale("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 3)
+}
+
+func (s *K8sBrokerSuite) TestDaemonScaleNotDaemon(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockDaemonSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.DaemonScale("app-name")
+	c.Assert(err, gc.ErrorMatches, `daemon set for application "app-name" not found`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-app-name",
+			Labels: map[string]string{
+				"juju-application": "app-name",
+				"fred":             "mary",
+			}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels: map[string]string{
+						"juju-application": "app-name",
+						"fred":             "mary",
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-app-name",
+			Labels: map[string]string{
+				"juju-application": "app-name",
+				"fred":             "mary",
+			}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "app-name"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+			LoadBalancerIP: "10.0.0.1",
+			ExternalName:   "ext-name",
+		},
+	}
+
+	secretArg := s.secretArg(c, map[string]string{"fred": "mary"})
+	withPodTemplateHash(c, deploymentArg)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:      basicPodspec,
+		ResourceTags: map[string]string{"fred": "mary"},
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceInitContainerFiles(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Image: "juju/image",
+			Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+		}},
+		InitContainers: []caas.ContainerSpec{{
+			Name:  "test-init",
+			Image: "juju/image-init",
+			Files: []caas.FileSet{{
+				Name:      "migrations",
+				MountPath: "/var/lib/migrations",
+				Files:     map[string]string{"001.sql": "create table foo;"},
+			}},
+		}},
+	}
+	numUnits := int32(1)
+	unitSpec, err := provider.MakeUnitSpec("app-name", spec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.Volumes = []core.Volume{{
+		Name: "juju-app-name-migrations-config",
+		VolumeSource: core.VolumeSource{
+			ConfigMap: &core.ConfigMapVolumeSource{
+				LocalObjectReference: core.LocalObjectReference{
+					Name: "juju-app-name-migrations-config",
+				},
+			},
+		},
+	}}
+	podSpec.InitContainers[0].VolumeMounts = []core.VolumeMount{{
+		Name:      "juju-app-name-migrations-config",
+		MountPath: "/var/lib/migrations",
+	}}
+
+	labels := map[string]string{"juju-application": "app-name"}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name-migrations-config",
+			Labels: labels,
+		},
+		Data: map[string]string{"001.sql": "create table foo;"},
+	}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "app-name"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+			},
+		},
+	}
+
+	withPodTemplateHash(c, deploymentArg)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMapArg).Times(1).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err = s.broker.EnsureService("app-name", nil, &caas.ServiceParams{PodSpec: spec}, 1, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	automount := false
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.ServiceAccountName = "juju-app-name"
+	podSpec.AutomountServiceAccountToken = &automount
+
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: &automount,
+	}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "juju-app-name",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-app-name",
+			Namespace: "test",
+		}},
+	}
+
+	withPodTemplateHash(c, deploymentArg)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithSecretsAndConfigMaps(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.Containers = make([]caas.ContainerSpec, len(basicPodspec.Containers))
+	copy(basicPodSpec.Containers, basicPodspec.Containers)
+	basicPodSpec.Containers[0].EnvFrom = []caas.EnvFromSource{{Secret: "creds"}}
+	basicPodSpec.Containers[1].Mounts = []caas.ObjectMount{{ConfigMap: "settings", MountPath: "/etc/settings"}}
+	basicPodSpec.Secrets = []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "s3cret"},
+	}}
+	basicPodSpec.ConfigMaps = []caas.ConfigMap{{
+		Name: "settings",
+		Data: map[string]string{"foo": "bar"},
+	}}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name-creds-secret",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("s3cret")},
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name-settings-config",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Data: map[string]string{"foo": "bar"},
+	}
+
+	withPodTemplateHash(c, deploymentArg)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMapArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithGlobalServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+		Rules: []caas.PolicyRule{{
+			NonResourceURLs: []string{"/healthz"},
+			Verbs:           []string{"get"},
+		}},
+	}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.ServiceAccountName = "juju-app-name"
+
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
+	clusterRoleArg := &rbacv1.ClusterRole{
 		ObjectMeta: v1.ObjectMeta{
-			Name: "juju-operator-test-config",
+			Name:   "test-juju-app-name",
+			Labels: labels,
 		},
-		Data: map[string]string{
-			"test-agent.conf": "agent-conf-data",
+		Rules: []rbacv1.PolicyRule{{
+			NonResourceURLs: []string{"/healthz"},
+			Verbs:           []string{"get"},
+		}},
+	}
+	clusterRoleBindingArg := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "test-juju-app-name",
+			Labels: labels,
 		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-juju-app-name",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-app-name",
+			Namespace: "test",
+		}},
 	}
-	statefulSetArg := operatorStatefulSetArg(1, "test-juju-operator-storage")
 
+	withPodTemplateHash(c, deploymentArg)
 	gomock.InOrder(
-		s.mockNamespaces.EXPECT().Update(&core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}).Times(1),
-		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1),
-		s.mockStorageClass.EXPECT().Get("test-juju-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
-			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-juju-operator-storage"}}, nil),
-		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoles.EXPECT().Update(clusterRoleArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Update(clusterRoleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deploymentArg, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
-	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
-		OperatorImagePath: "/path/to/image",
-		Version:           version.MustParse("2.99.0"),
-		AgentConf:         []byte("agent-conf-data"),
-		ResourceTags:      map[string]string{"fred": "mary"},
-		CharmStorage: caas.CharmStorageParams{
-			Size:         uint64(10),
-			Provider:     "kubernetes",
-			ResourceTags: map[string]string{"foo": "bar"},
-		},
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+		"trust":                              true,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfig(c *gc.C) {
+func (s *K8sBrokerSuite) TestEnsureServiceWithGlobalServiceAccountNotTrusted(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
-	statefulSetArg := operatorStatefulSetArg(1, "test-juju-operator-storage")
+	basicPodSpec := *basicPodspec
+	basicPodSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+	}
 
 	gomock.InOrder(
-		s.mockNamespaces.EXPECT().Update(&core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}).Times(1),
-		s.mockConfigMaps.EXPECT().Get("juju-operator-test-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(nil, nil),
-		s.mockStorageClass.EXPECT().Get("test-juju-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
-			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-juju-operator-storage"}}, nil),
-		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
-			Return(nil, s.k8sNotFoundError()),
-		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
-			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoles.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
 	)
 
-	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
-		OperatorImagePath: "/path/to/image",
-		Version:           version.MustParse("2.99.0"),
-		ResourceTags:      map[string]string{"fred": "mary"},
-		CharmStorage: caas.CharmStorageParams{
-			Size:         uint64(10),
-			Provider:     "kubernetes",
-			ResourceTags: map[string]string{"foo": "bar"},
-		},
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err := s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
 	})
-	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(err, gc.ErrorMatches, "global service account requires the application to be trusted")
 }
 
-func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfigMissingConfigMap(c *gc.C) {
+func (s *K8sBrokerSuite) TestEnsureServiceRemovesServiceAccount(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
-	gomock.InOrder(
-		s.mockNamespaces.EXPECT().Update(&core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test"}}).Times(1),
-		s.mockConfigMaps.EXPECT().Get("juju-operator-test-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(nil, s.k8sNotFoundError()),
-	)
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
 
-	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
-		OperatorImagePath: "/path/to/image",
-		Version:           version.MustParse("2.99.0"),
-		CharmStorage: caas.CharmStorageParams{
-			Size:     uint64(10),
-			Provider: "kubernetes",
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
 		},
-	})
-	c.Assert(err, gc.ErrorMatches, `config map for "test" should already exist:  "test" not found`)
-}
-
-func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
-	ctrl := s.setupBroker(c)
-	defer ctrl.Finish()
+	}
+	serviceAccount := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
 
-	// Delete operations below return a not found to ensure it's treated as a no-op.
+	withPodTemplateHash(c, deploymentArg)
 	gomock.InOrder(
-		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
-			Return(s.k8sNotFoundError()),
-		s.mockStatefulSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
-			Return(s.k8sNotFoundError()),
-		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		// The service account was in a previous pod spec.
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(serviceAccount, nil),
+		s.mockRoleBindings.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockRoles.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
-		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).
-			Return(&core.PodList{Items: []core.Pod{}}, nil),
-		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
-			Return(&core.SecretList{Items: []core.Secret{{
-				ObjectMeta: v1.ObjectMeta{Name: "secret"},
-			}}}, nil),
-		s.mockSecrets.EXPECT().Delete("secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		s.mockClusterRoles.EXPECT().Delete("test-juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
-	)
-
-	err := s.broker.DeleteService("test")
-	c.Assert(err, jc.ErrorIsNil)
-}
-
-func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
-	ctrl := s.setupBroker(c)
-	defer ctrl.Finish()
-
-	two := int32(2)
-	dc := &apps.Deployment{ObjectMeta: v1.ObjectMeta{Name: "juju-unit-storage"}, Spec: apps.DeploymentSpec{Replicas: &two}}
-	zero := int32(0)
-	emptyDc := dc
-	emptyDc.Spec.Replicas = &zero
-	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
 		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
-			Return(dc, nil),
-		s.mockDeployments.EXPECT().Update(emptyDc).Times(1).
+			Return(deploymentArg, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
-	params := &caas.ServiceParams{}
-	err := s.broker.EnsureService("app-name", nil, params, 0, nil)
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
+func (s *K8sBrokerSuite) TestEnsureServiceDeletesStalePodSpecObjects(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
+	basicPodSpec := *basicPodspec
+	basicPodSpec.Secrets = []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "secret"},
+	}}
+
 	numUnits := int32(2)
-	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
 	c.Assert(err, jc.ErrorIsNil)
 	podSpec := provider.PodSpec(unitSpec)
 
+	labels := map[string]string{"juju-application": "app-name"}
 	deploymentArg := &appsv1.Deployment{
 		ObjectMeta: v1.ObjectMeta{
-			Name: "juju-app-name",
-			Labels: map[string]string{
-				"juju-application": "app-name",
-				"fred":             "mary",
-			}},
+			Name:   "juju-app-name",
+			Labels: labels},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &numUnits,
 			Selector: &v1.LabelSelector{
@@ -579,55 +1555,66 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Template: core.PodTemplateSpec{
 				ObjectMeta: v1.ObjectMeta{
 					GenerateName: "juju-app-name-",
-					Labels: map[string]string{
-						"juju-application": "app-name",
-						"fred":             "mary",
-					},
+					Labels:       labels,
 				},
 				Spec: podSpec,
 			},
 		},
 	}
-	serviceArg := &core.Service{
+	secretArg := &core.Secret{
 		ObjectMeta: v1.ObjectMeta{
-			Name: "juju-app-name",
-			Labels: map[string]string{
-				"juju-application": "app-name",
-				"fred":             "mary",
-			}},
-		Spec: core.ServiceSpec{
-			Selector: map[string]string{"juju-application": "app-name"},
-			Type:     "nodeIP",
-			Ports: []core.ServicePort{
-				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
-				{Port: 8080, Protocol: "TCP", Name: "fred"},
-			},
-			LoadBalancerIP: "10.0.0.1",
-			ExternalName:   "ext-name",
+			Name:      "juju-app-name-creds-secret",
+			Namespace: "test",
+			Labels:    labels,
 		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("secret")},
 	}
 
-	secretArg := s.secretArg(c, map[string]string{"fred": "mary"})
+	withPodTemplateHash(c, deploymentArg)
 	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
 		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
 			Return(nil, nil),
+		// The secret and config map dropped from a previous pod
+		// spec are deleted.
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{Items: []core.Secret{
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-app-name-test-secret"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-app-name-creds-secret"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-app-name-old-secret"}},
+			}}, nil),
+		s.mockSecrets.EXPECT().Delete("juju-app-name-old-secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{Items: []core.ConfigMap{
+				{ObjectMeta: v1.ObjectMeta{Name: "juju-app-name-old-config"}},
+			}}, nil),
+		s.mockConfigMaps.EXPECT().Delete("juju-app-name-old-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deploymentArg, nil),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
-			Return(nil, s.k8sNotFoundError()),
-		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
-		PodSpec:      basicPodspec,
-		ResourceTags: map[string]string{"fred": "mary"},
+		PodSpec: &basicPodSpec,
 	}
 	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
@@ -862,6 +1849,12 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	gomock.InOrder(
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -870,12 +1863,18 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockDeployments.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -937,21 +1936,32 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 		},
 	}
 
+	withPodTemplateHash(c, deploymentArg)
 	gomock.InOrder(
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -999,6 +2009,12 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	gomock.InOrder(
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1007,12 +2023,18 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockDeployments.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -1066,6 +2088,12 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 	gomock.InOrder(
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1074,12 +2102,18 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockDeployments.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -1120,6 +2154,12 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithPlacement(c *gc.C) {
 	gomock.InOrder(
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1128,12 +2168,18 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithPlacement(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockDeployments.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -1173,6 +2219,11 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	gomock.InOrder(
 		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
 			Return(&core.PodList{Items: []core.Pod{opPod}}, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        "involvedObject.name=juju-operator-test,involvedObject.kind=Pod",
+		}).Times(1).
+			Return(&core.EventList{}, nil),
 	)
 
 	operator, err := s.broker.Operator("test")
@@ -1194,3 +2245,42 @@ func (s *K8sBrokerSuite) TestOperatorNoPodFound(c *gc.C) {
 	_, err := s.broker.Operator("test")
 	c.Assert(err, gc.ErrorMatches, "operator pod for application \"test\" not found")
 }
+
+func (s *K8sBrokerSuite) TestUnitsNotReady(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name-0",
+			UID:    "uuid",
+			Labels: map[string]string{"juju-application": "app-name"},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "test"}},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			PodIP: "10.0.0.1",
+			Conditions: []core.PodCondition{{
+				Type:   core.PodScheduled,
+				Status: core.ConditionTrue,
+			}, {
+				Type:    core.PodReady,
+				Status:  core.ConditionFalse,
+				Message: "containers with unready status: [test]",
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+	)
+
+	units, err := s.broker.Units("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Id, gc.Equals, "uuid")
+	c.Assert(units[0].Status.Status, gc.Equals, status.Waiting)
+	c.Assert(units[0].Status.Message, gc.Equals, "containers with unready status: [test]")
+}
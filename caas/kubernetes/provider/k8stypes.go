@@ -24,15 +24,18 @@ type k8sContainer struct {
 }
 
 type k8sContainers struct {
-	Containers []k8sContainer `json:"containers"`
+	Containers     []k8sContainer `json:"containers"`
+	InitContainers []k8sContainer `json:"initContainers"`
 }
 
 // K8sContainerSpec is a subset of v1.Container which defines
 // attributes we expose for charms to set.
 type K8sContainerSpec struct {
-	LivenessProbe   *core.Probe     `json:"livenessProbe,omitempty"`
-	ReadinessProbe  *core.Probe     `json:"readinessProbe,omitempty"`
-	ImagePullPolicy core.PullPolicy `json:"imagePullPolicy,omitempty"`
+	LivenessProbe   *core.Probe               `json:"livenessProbe,omitempty"`
+	ReadinessProbe  *core.Probe               `json:"readinessProbe,omitempty"`
+	ImagePullPolicy core.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	Resources       core.ResourceRequirements `json:"resources,omitempty"`
+	SecurityContext *core.SecurityContext     `json:"securityContext,omitempty"`
 }
 
 // Validate is defined on ProviderContainer.
@@ -40,6 +43,26 @@ func (*K8sContainerSpec) Validate() error {
 	return nil
 }
 
+// RequiresTrust is defined on ProviderContainer. A container which
+// runs privileged, may escalate its privileges, adds capabilities or
+// runs as root may gain access to the node, so it must be trusted.
+func (spec *K8sContainerSpec) RequiresTrust() bool {
+	sc := spec.SecurityContext
+	if sc == nil {
+		return false
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		return true
+	}
+	if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+		return true
+	}
+	if sc.Capabilities != nil && len(sc.Capabilities.Add) > 0 {
+		return true
+	}
+	return sc.RunAsUser != nil && *sc.RunAsUser == 0
+}
+
 var boolValues = set.NewStrings(
 	strings.Split("y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF", "|")...)
 
@@ -64,8 +87,26 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		return nil, errors.New("require at least one container spec")
 	}
 
+	// Compose the result.
+	var err error
+	if spec.Containers, err = toContainerSpecs(containers.Containers); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if spec.InitContainers, err = toContainerSpecs(containers.InitContainers); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
+
+// toContainerSpecs converts the parsed k8s containers
+// into container specs.
+func toContainerSpecs(containers []k8sContainer) ([]caas.ContainerSpec, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
 	// Any string config values that could be interpreted as bools need to be quoted.
-	for _, container := range containers.Containers {
+	for _, container := range containers {
 		for k, v := range container.Config {
 			strValue, ok := v.(string)
 			if !ok {
@@ -77,13 +118,12 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		}
 	}
 
-	// Compose the result.
-	spec.Containers = make([]caas.ContainerSpec, len(containers.Containers))
-	for i, c := range containers.Containers {
+	result := make([]caas.ContainerSpec, len(containers))
+	for i, c := range containers {
 		if err := c.Validate(); err != nil {
 			return nil, errors.Trace(err)
 		}
-		spec.Containers[i] = caas.ContainerSpec{
+		result[i] = caas.ContainerSpec{
 			ImageDetails: c.ImageDetails,
 			Name:         c.Name,
 			Image:        c.Image,
@@ -93,10 +133,12 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 			WorkingDir:   c.WorkingDir,
 			Config:       c.Config,
 			Files:        c.Files,
+			EnvFrom:      c.EnvFrom,
+			Mounts:       c.Mounts,
 		}
 		if c.K8sContainerSpec != nil {
-			spec.Containers[i].ProviderContainer = c.K8sContainerSpec
+			result[i].ProviderContainer = c.K8sContainerSpec
 		}
 	}
-	return &spec, nil
+	return result, nil
 }
@@ -4,6 +4,8 @@
 package provider_test
 
 import (
+	"fmt"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
@@ -98,6 +100,12 @@ customResourceDefinition:
                   type: integer
                   minimum: 1
                   maximum: 1
+serviceAccount:
+  automountServiceAccountToken: true
+  rules:
+    - apiGroups: [""]
+      resources: ["pods"]
+      verbs: ["get", "watch", "list"]
 `[1:]
 
 	expectedFileContent := `
@@ -214,6 +222,14 @@ foo: bar
 				},
 			},
 		},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			AutomountServiceAccountToken: boolPtr(true),
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "watch", "list"},
+			}},
+		},
 	})
 }
 
@@ -221,6 +237,10 @@ func float64Ptr(f float64) *float64 {
 	return &f
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func (s *ContainersSuite) TestValidateMissingContainers(c *gc.C) {
 
 	specStr := `
@@ -295,3 +315,387 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *ContainersSuite) TestValidateServiceAccountMissingVerbs(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  rules:
+    - resources: ["pods"]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `rule with no verbs not valid`)
+}
+
+func (s *ContainersSuite) TestValidateServiceAccountNonResourceURLs(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  rules:
+    - nonResourceURLs: ["/healthz"]
+      verbs: ["get"]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `non resource URLs for service account which is not global not valid`)
+
+	spec.ServiceAccount.Global = true
+	err = spec.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ContainersSuite) TestValidateTrust(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  rules:
+    - resources: ["pods"]
+      verbs: ["get"]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.ValidateTrust(false), jc.ErrorIsNil)
+
+	spec.ServiceAccount.Global = true
+	err = spec.ValidateTrust(false)
+	c.Assert(err, gc.ErrorMatches, `global service account requires the application to be trusted`)
+	c.Assert(spec.ValidateTrust(true), jc.ErrorIsNil)
+}
+
+func (s *ContainersSuite) TestValidateTrustSecurityContext(c *gc.C) {
+	for i, securityContext := range []string{
+		"privileged: true",
+		"allowPrivilegeEscalation: true",
+		"capabilities:\n        add: [NET_ADMIN]",
+		"runAsUser: 0",
+	} {
+		c.Logf("test %d: %s", i, securityContext)
+		specStr := fmt.Sprintf(`
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    securityContext:
+      %s
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      runAsNonRoot: true
+`[1:], securityContext)
+
+		spec, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		err = spec.ValidateTrust(false)
+		c.Assert(err, gc.ErrorMatches, `container "gitlab-init" requires the application to be trusted`)
+		c.Assert(spec.ValidateTrust(true), jc.ErrorIsNil)
+	}
+}
+
+func (s *ContainersSuite) TestParseDeploymentType(c *gc.C) {
+
+	specStr := `
+deploymentType: daemon
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.DeploymentType, gc.Equals, caas.DeploymentDaemon)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ContainersSuite) TestValidateDeploymentType(c *gc.C) {
+
+	specStr := `
+deploymentType: replicated
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `deployment type "replicated" not valid`)
+}
+
+func (s *ContainersSuite) TestParseInitContainersAndResources(c *gc.C) {
+
+	specStr := `
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    command: ["sh", "-c", "migrate"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      requests:
+        cpu: 100m
+      limits:
+        memory: 256Mi
+    securityContext:
+      runAsNonRoot: true
+      readOnlyRootFilesystem: true
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.InitContainers, jc.DeepEquals, []caas.ContainerSpec{{
+		Name:    "gitlab-init",
+		Image:   "gitlab-init/latest",
+		Command: []string{"sh", "-c", "migrate"},
+	}})
+	c.Assert(spec.Containers, gc.HasLen, 1)
+	k8sSpec, ok := spec.Containers[0].ProviderContainer.(*provider.K8sContainerSpec)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(k8sSpec.Resources.Requests.Cpu().String(), gc.Equals, "100m")
+	c.Assert(k8sSpec.Resources.Limits.Memory().String(), gc.Equals, "256Mi")
+	c.Assert(k8sSpec.SecurityContext, jc.DeepEquals, &core.SecurityContext{
+		RunAsNonRoot:           boolPtr(true),
+		ReadOnlyRootFilesystem: boolPtr(true),
+	})
+}
+
+func (s *ContainersSuite) TestValidateInitContainerMissingName(c *gc.C) {
+
+	specStr := `
+initContainers:
+  - image: gitlab-init/latest
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, "spec name is missing")
+}
+
+func (s *ContainersSuite) TestParseSecretsAndConfigMaps(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - secret: creds
+      - configMap: settings
+    mounts:
+      - secret: tls
+        mountPath: /etc/tls
+secrets:
+  - name: creds
+    data:
+      password: s3cret
+  - name: tls
+    type: kubernetes.io/tls
+    data:
+      tls.crt: cert
+      tls.key: key
+configMaps:
+  - name: settings
+    data:
+      foo: bar
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.Containers[0].EnvFrom, jc.DeepEquals, []caas.EnvFromSource{
+		{Secret: "creds"}, {ConfigMap: "settings"},
+	})
+	c.Assert(spec.Containers[0].Mounts, jc.DeepEquals, []caas.ObjectMount{
+		{Secret: "tls", MountPath: "/etc/tls"},
+	})
+	c.Assert(spec.Secrets, jc.DeepEquals, []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "s3cret"},
+	}, {
+		Name: "tls",
+		Type: caas.SecretTLS,
+		Data: map[string]string{"tls.crt": "cert", "tls.key": "key"},
+	}})
+	c.Assert(spec.ConfigMaps, jc.DeepEquals, []caas.ConfigMap{{
+		Name: "settings",
+		Data: map[string]string{"foo": "bar"},
+	}})
+}
+
+func (s *ContainersSuite) TestValidateSecretsAndConfigMaps(c *gc.C) {
+	for i, t := range []struct {
+		specStr string
+		err     string
+	}{{
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - secret: creds
+`[1:],
+		err: `secret "creds" used by container "gitlab" not found`,
+	}, {
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    mounts:
+      - configMap: settings
+`[1:],
+		err: `mount path is missing for mount of "settings"`,
+	}, {
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - secret: creds
+        configMap: settings
+`[1:],
+		err: `exactly one of secret or config map must be specified`,
+	}, {
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+secrets:
+  - name: tls
+    type: kubernetes.io/tls
+    data:
+      tls.crt: cert
+`[1:],
+		err: `tls secret "tls" without "tls.key" not valid`,
+	}, {
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+secrets:
+  - name: gitlab
+    data:
+      password: s3cret
+`[1:],
+		err: `secret "gitlab" with the same name as a container not valid`,
+	}, {
+		specStr: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    files:
+      - name: settings
+        mountPath: /var/lib/foo
+        files:
+          file1: foo
+configMaps:
+  - name: settings
+    data:
+      foo: bar
+`[1:],
+		err: `config map "settings" with the same name as a file set not valid`,
+	}} {
+		c.Logf("test %d", i)
+		spec, err := provider.ParseK8sPodSpec(t.specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(spec.Validate(), gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ContainersSuite) TestParseUpdateStrategy(c *gc.C) {
+
+	specStr := `
+deploymentType: stateful
+updateStrategy:
+  partition: 2
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	partition := int32(2)
+	c.Assert(spec.UpdateStrategy, jc.DeepEquals, &caas.UpdateStrategy{
+		Partition: &partition,
+	})
+}
+
+func (s *ContainersSuite) TestValidateUpdateStrategy(c *gc.C) {
+	for i, t := range []struct {
+		specStr string
+		err     string
+	}{{
+		specStr: `
+updateStrategy:
+  type: Sometimes
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:],
+		err: `update strategy "Sometimes" not valid`,
+	}, {
+		specStr: `
+deploymentType: stateless
+updateStrategy:
+  type: OnDelete
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:],
+		err: `update strategy "OnDelete" for stateless application not valid`,
+	}, {
+		specStr: `
+updateStrategy:
+  maxSurge: lots
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:],
+		err: `maxSurge "lots" not valid`,
+	}, {
+		specStr: `
+deploymentType: daemon
+updateStrategy:
+  maxSurge: 1
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:],
+		err: `maxSurge for daemon application not valid`,
+	}, {
+		specStr: `
+deploymentType: stateful
+updateStrategy:
+  partition: -1
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:],
+		err: `negative partition -1 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		spec, err := provider.ParseK8sPodSpec(t.specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(spec.Validate(), gc.ErrorMatches, t.err)
+	}
+}
@@ -27,13 +27,29 @@ type kubernetesWatcher struct {
 	out       chan struct{}
 	name      string
 	k8watcher watch.Interface
+
+	// eventWatcher, if set, reports kubernetes Events, of which
+	// those selected by eventFilter also generate a notification.
+	eventWatcher watch.Interface
+	eventFilter  func(*core.Event) bool
 }
 
 func newKubernetesWatcher(wi watch.Interface, name string) (*kubernetesWatcher, error) {
+	return newKubernetesEventWatcher(wi, nil, nil, name)
+}
+
+// newKubernetesEventWatcher returns a watcher which also notifies
+// when Events selected by the filter are reported about the
+// watched resources, eg that a pod cannot be scheduled.
+func newKubernetesEventWatcher(
+	wi, events watch.Interface, filter func(*core.Event) bool, name string,
+) (*kubernetesWatcher, error) {
 	w := &kubernetesWatcher{
-		out:       make(chan struct{}),
-		k8watcher: wi,
-		name:      name,
+		out:          make(chan struct{}),
+		k8watcher:    wi,
+		eventWatcher: events,
+		eventFilter:  filter,
+		name:         name,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -48,6 +64,12 @@ func (w *kubernetesWatcher) loop() error {
 	defer close(w.out)
 	defer w.k8watcher.Stop()
 
+	var eventsCh <-chan watch.Event
+	if w.eventWatcher != nil {
+		defer w.eventWatcher.Stop()
+		eventsCh = w.eventWatcher.ResultChan()
+	}
+
 	var out chan struct{}
 	// Set delayCh now so that initial event is sent.
 	delayCh := time.After(sendDelay)
@@ -70,6 +92,21 @@ func (w *kubernetesWatcher) loop() error {
 			if delayCh == nil {
 				delayCh = time.After(sendDelay)
 			}
+		case evt, ok := <-eventsCh:
+			if !ok {
+				return errors.Errorf("k8s event watcher closed, restarting")
+			}
+			if evt.Type == watch.Error {
+				return errors.Errorf("kubernetes event watcher error: %v", k8serrors.FromObject(evt.Object))
+			}
+			k8sEvent, ok := evt.Object.(*core.Event)
+			if !ok || w.eventFilter != nil && !w.eventFilter(k8sEvent) {
+				continue
+			}
+			logger.Tracef("%v %v/%v: %v", k8sEvent.Reason, k8sEvent.InvolvedObject.Kind, k8sEvent.InvolvedObject.Name, k8sEvent.Message)
+			if delayCh == nil {
+				delayCh = time.After(sendDelay)
+			}
 		case <-delayCh:
 			out = w.out
 		case out <- struct{}{}:
@@ -0,0 +1,200 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/environs/tags"
+)
+
+// namespaceLabels returns the labels which mark the broker's
+// namespace as belonging to the model.
+func (k *kubernetesClient) namespaceLabels(owner string) map[string]string {
+	return map[string]string{
+		tags.JujuModel:      k.modelUUID,
+		labelNamespaceOwner: owner,
+	}
+}
+
+// ensureJujuNamespace creates the broker's namespace, or labels the
+// existing namespace as belonging to the model if it is not already
+// used by another model or adopted by this one.
+func (k *kubernetesClient) ensureJujuNamespace() error {
+	namespaces := k.CoreV1().Namespaces()
+	ns, err := namespaces.Get(k.namespace, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		_, err = namespaces.Create(&core.Namespace{ObjectMeta: v1.ObjectMeta{
+			Name:   k.namespace,
+			Labels: k.namespaceLabels(namespaceOwnerJuju),
+		}})
+		return errors.Trace(err)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Never take over a namespace belonging to another model, or one
+	// the model adopted, which must not be deleted with the model.
+	if modelUUID := ns.Labels[tags.JujuModel]; modelUUID != "" && modelUUID != k.modelUUID {
+		return errors.Errorf("namespace %q is already used by model %q", k.namespace, modelUUID)
+	}
+	if ns.Labels[labelNamespaceOwner] == namespaceOwnerExternal {
+		return errors.Errorf("namespace %q is not owned by Juju", k.namespace)
+	}
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	for key, value := range k.namespaceLabels(namespaceOwnerJuju) {
+		ns.Labels[key] = value
+	}
+	_, err = namespaces.Update(ns)
+	return errors.Trace(err)
+}
+
+// adoptExistingNamespace checks that the existing namespace the model
+// adopts is not used by another model, and that it is either empty or
+// has been labelled for the model by the cluster admin, before labelling
+// it as belonging to the model.
+func (k *kubernetesClient) adoptExistingNamespace() error {
+	namespaces := k.CoreV1().Namespaces()
+	ns, err := namespaces.Get(k.namespace, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("namespace %q to adopt", k.namespace)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch modelUUID := ns.Labels[tags.JujuModel]; modelUUID {
+	case k.modelUUID:
+		// The model has already adopted the namespace.
+		return nil
+	case "":
+	default:
+		return errors.Errorf("namespace %q is already used by model %q", k.namespace, modelUUID)
+	}
+
+	modelName := k.Config().Name()
+	if ns.Labels[labelModel] != modelName {
+		empty, err := k.namespaceIsEmpty()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !empty {
+			return errors.Errorf(
+				"namespace %q is not empty and is not labelled %s=%s", k.namespace, labelModel, modelName)
+		}
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	for key, value := range k.namespaceLabels(namespaceOwnerExternal) {
+		ns.Labels[key] = value
+	}
+	_, err = namespaces.Update(ns)
+	return errors.Trace(err)
+}
+
+// namespaceIsEmpty returns true if there are no workloads
+// or services running in the broker's namespace.
+func (k *kubernetesClient) namespaceIsEmpty() (bool, error) {
+	listOptions := v1.ListOptions{IncludeUninitialized: true}
+	pods, err := k.CoreV1().Pods(k.namespace).List(listOptions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	services, err := k.CoreV1().Services(k.namespace).List(listOptions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	deployments, err := k.AppsV1().Deployments(k.namespace).List(listOptions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	statefulSets, err := k.AppsV1().StatefulSets(k.namespace).List(listOptions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	daemonSets, err := k.AppsV1().DaemonSets(k.namespace).List(listOptions)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	count := len(pods.Items) + len(services.Items) + len(deployments.Items) +
+		len(statefulSets.Items) + len(daemonSets.Items)
+	return count == 0, nil
+}
+
+// releaseNamespace removes the resources labelled as belonging to the
+// model from the namespace it adopted, along with the model's labels on
+// the namespace. The namespace itself, and anything in it not created
+// by Juju, is left intact.
+func (k *kubernetesClient) releaseNamespace() error {
+	namespaces := k.CoreV1().Namespaces()
+	ns, err := namespaces.Get(k.namespace, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ns.Labels[tags.JujuModel] != k.modelUUID {
+		// The model never adopted the namespace.
+		return nil
+	}
+
+	deleteOptions := &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}
+	listOptions := v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v==%v", tags.JujuModel, k.modelUUID),
+	}
+
+	// Services cannot be deleted as a collection.
+	services := k.CoreV1().Services(k.namespace)
+	serviceList, err := services.List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, svc := range serviceList.Items {
+		err := services.Delete(svc.Name, deleteOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting service %q", svc.Name)
+		}
+	}
+	for _, deleteCollection := range []func(*v1.DeleteOptions, v1.ListOptions) error{
+		k.AppsV1().StatefulSets(k.namespace).DeleteCollection,
+		k.AppsV1().Deployments(k.namespace).DeleteCollection,
+		k.AppsV1().DaemonSets(k.namespace).DeleteCollection,
+		k.ExtensionsV1beta1().Ingresses(k.namespace).DeleteCollection,
+		k.NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection,
+		k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace).DeleteCollection,
+		k.CoreV1().ConfigMaps(k.namespace).DeleteCollection,
+		k.CoreV1().Secrets(k.namespace).DeleteCollection,
+		k.CoreV1().ServiceAccounts(k.namespace).DeleteCollection,
+		k.RbacV1().Roles(k.namespace).DeleteCollection,
+		k.RbacV1().RoleBindings(k.namespace).DeleteCollection,
+		k.CoreV1().PersistentVolumeClaims(k.namespace).DeleteCollection,
+	} {
+		err := deleteCollection(deleteOptions, listOptions)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+
+	// Cluster roles and bindings are not namespaced, so are deleted
+	// separately.
+	if err := k.deleteModelClusterRoles(); err != nil {
+		return errors.Trace(err)
+	}
+
+	delete(ns.Labels, tags.JujuModel)
+	delete(ns.Labels, labelNamespaceOwner)
+	_, err = namespaces.Update(ns)
+	return errors.Trace(err)
+}
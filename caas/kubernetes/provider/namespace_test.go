@@ -0,0 +1,315 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) setupAdoptingBroker(c *gc.C) *gomock.Controller {
+	s.modelConfigAttrs = testing.Attrs{"namespace": testNamespace}
+	return s.setupBroker(c)
+}
+
+func adoptedNamespaceArg(labels map[string]string) *core.Namespace {
+	return &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "test",
+		Labels: labels,
+	}}
+}
+
+func (s *K8sBrokerSuite) expectEmptyNamespace(empty bool) {
+	listOptions := v1.ListOptions{IncludeUninitialized: true}
+	pods := &core.PodList{}
+	if !empty {
+		pods.Items = []core.Pod{{ObjectMeta: v1.ObjectMeta{Name: "workload"}}}
+	}
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(listOptions).Times(1).Return(pods, nil),
+		s.mockServices.EXPECT().List(listOptions).Times(1).Return(&core.ServiceList{}, nil),
+		s.mockDeployments.EXPECT().List(listOptions).Times(1).Return(&appsv1.DeploymentList{}, nil),
+		s.mockStatefulSets.EXPECT().List(listOptions).Times(1).Return(&appsv1.StatefulSetList{}, nil),
+		s.mockDaemonSets.EXPECT().List(listOptions).Times(1).Return(&appsv1.DaemonSetList{}, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptsEmptyNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(map[string]string{"quota": "small"}), nil)
+	s.expectEmptyNamespace(true)
+	s.mockNamespaces.EXPECT().Update(adoptedNamespaceArg(map[string]string{
+		"quota":                "small",
+		"juju-model-uuid":      testing.ModelTag.Id(),
+		"juju-namespace-owner": "external",
+	})).Times(1)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptsLabelledNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(adoptedNamespaceArg(map[string]string{"juju-model": "test"}), nil),
+		s.mockNamespaces.EXPECT().Update(adoptedNamespaceArg(map[string]string{
+			"juju-model":           "test",
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "external",
+		})).Times(1),
+	)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptNonEmptyNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(nil), nil)
+	s.expectEmptyNamespace(false)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, gc.ErrorMatches, `namespace "test" is not empty and is not labelled juju-model=test`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptNamespaceUsedByAnotherModel(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(map[string]string{"juju-model-uuid": "another-uuid"}), nil)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, gc.ErrorMatches, `namespace "test" is already used by model "another-uuid"`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptMissingNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, gc.ErrorMatches, `namespace "test" to adopt not found`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceUsedByAnotherModel(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(map[string]string{
+			"juju-model-uuid":      "another-uuid",
+			"juju-namespace-owner": "juju",
+		}), nil)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, gc.ErrorMatches, `namespace "test" is already used by model "another-uuid"`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceAdoptedNamespace(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(map[string]string{
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "external",
+		}), nil)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, gc.ErrorMatches, `namespace "test" is not owned by Juju`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNamespaceLabelsExistingNamespace(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(adoptedNamespaceArg(map[string]string{"quota": "small"}), nil),
+		s.mockNamespaces.EXPECT().Update(adoptedNamespaceArg(map[string]string{
+			"quota":                "small",
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "juju",
+		})).Times(1),
+	)
+
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestDestroyReleasesAdoptedNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	deleteOptions := s.deleteOptions(v1.DeletePropagationForeground)
+	listOptions := v1.ListOptions{LabelSelector: "juju-model-uuid==" + testing.ModelTag.Id()}
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(adoptedNamespaceArg(map[string]string{
+			"quota":                "small",
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "external",
+		}), nil)
+	s.mockServices.EXPECT().List(listOptions).Times(1).
+		Return(&core.ServiceList{Items: []core.Service{{ObjectMeta: v1.ObjectMeta{Name: "app-name"}}}}, nil)
+	s.mockServices.EXPECT().Delete("app-name", deleteOptions).Times(1)
+	s.mockStatefulSets.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockDeployments.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockDaemonSets.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockIngressInterface.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockNetworkPolicies.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockHorizontalAutoscalers.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockConfigMaps.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockSecrets.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockServiceAccounts.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockRoles.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockRoleBindings.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockPersistentVolumeClaims.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockNamespaces.EXPECT().Update(adoptedNamespaceArg(map[string]string{"quota": "small"})).Times(1)
+	s.mockClusterRoleBindings.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockClusterRoles.EXPECT().DeleteCollection(deleteOptions, listOptions).Times(1)
+	s.mockStorageClass.EXPECT().DeleteCollection(
+		deleteOptions,
+		v1.ListOptions{LabelSelector: "juju-model==test"},
+	).Times(1)
+
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestReleaseSelectsEverythingCreated(c *gc.C) {
+	ctrl := s.setupAdoptingBroker(c)
+	defer ctrl.Finish()
+
+	// Record the labels of every object created for the model, so we
+	// can check they are all selected when the namespace is released.
+	created := make(map[string]map[string]string)
+	record := func(kind string, meta v1.ObjectMeta) {
+		created[kind+"/"+meta.Name] = meta.Labels
+	}
+	notFound := s.k8sNotFoundError()
+	s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).AnyTimes().
+		Return(adoptedNamespaceArg(map[string]string{
+			"juju-model-uuid":      testing.ModelTag.Id(),
+			"juju-namespace-owner": "external",
+		}), nil)
+	s.mockConfigMaps.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockConfigMaps.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(cm *core.ConfigMap) { record("ConfigMap", cm.ObjectMeta) })
+	s.mockSecrets.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockSecrets.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(secret *core.Secret) { record("Secret", secret.ObjectMeta) })
+	s.mockStorageClass.EXPECT().Get("test-juju-operator-storage", v1.GetOptions{IncludeUninitialized: false}).AnyTimes().
+		Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-juju-operator-storage"}}, nil)
+	s.mockStatefulSets.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockStatefulSets.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockStatefulSets.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(ss *appsv1.StatefulSet) { record("StatefulSet", ss.ObjectMeta) })
+	s.mockDeployments.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockDeployments.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(d *appsv1.Deployment) { record("Deployment", d.ObjectMeta) })
+	s.mockServices.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockServices.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockServices.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(svc *core.Service) { record("Service", svc.ObjectMeta) })
+	s.mockHorizontalAutoscalers.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(notFound)
+	s.mockDaemonSets.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(notFound)
+	s.mockSecrets.EXPECT().List(gomock.Any()).AnyTimes().Return(&core.SecretList{}, nil)
+	s.mockConfigMaps.EXPECT().List(gomock.Any()).AnyTimes().Return(&core.ConfigMapList{}, nil)
+	s.mockServiceAccounts.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockServiceAccounts.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockServiceAccounts.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(sa *core.ServiceAccount) { record("ServiceAccount", sa.ObjectMeta) })
+	s.mockClusterRoles.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockClusterRoles.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(role *rbacv1.ClusterRole) { record("ClusterRole", role.ObjectMeta) })
+	s.mockClusterRoleBindings.EXPECT().Update(gomock.Any()).AnyTimes().Return(nil, notFound)
+	s.mockClusterRoleBindings.EXPECT().Create(gomock.Any()).AnyTimes().
+		Do(func(binding *rbacv1.ClusterRoleBinding) { record("ClusterRoleBinding", binding.ObjectMeta) })
+	s.mockRoleBindings.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(notFound)
+	s.mockRoles.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(notFound)
+
+	modelTags := map[string]string{"juju-model-uuid": testing.ModelTag.Id()}
+	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		ResourceTags:      modelTags,
+		CharmStorage: caas.CharmStorageParams{
+			Size:         uint64(10),
+			Provider:     "kubernetes",
+			ResourceTags: modelTags,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	podSpec := &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:         "test",
+			ImageDetails: caas.ImageDetails{ImagePath: "juju/image", Username: "fred", Password: "secret"},
+			Ports:        []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+			Files: []caas.FileSet{{
+				Name:      "configuration",
+				MountPath: "/var/lib/foo",
+				Files:     map[string]string{"file1": "foo=bar"},
+			}},
+		}},
+		Secrets:    []caas.Secret{{Name: "creds", Data: map[string]string{"password": "s3cret"}}},
+		ConfigMaps: []caas.ConfigMap{{Name: "settings", Data: map[string]string{"foo": "bar"}}},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			Global: true,
+			Rules:  []caas.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+		},
+	}
+	err = s.broker.EnsureService("app-name", nil, &caas.ServiceParams{
+		PodSpec:      podSpec,
+		ResourceTags: modelTags,
+	}, 1, application.ConfigAttributes{"trust": true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, name := range []string{
+		"ConfigMap/juju-operator-test-config",
+		"ConfigMap/juju-app-name-configuration-config",
+		"ConfigMap/juju-app-name-settings-config",
+		"Secret/juju-app-name-creds-secret",
+		"Secret/juju-app-name-test-secret",
+		"StatefulSet/juju-operator-test",
+		"Deployment/juju-app-name",
+		"Service/juju-app-name",
+		"ServiceAccount/juju-app-name",
+		"ClusterRole/test-juju-app-name",
+		"ClusterRoleBinding/test-juju-app-name",
+	} {
+		c.Check(created[name], gc.NotNil, gc.Commentf("%s not created", name))
+	}
+	selector, err := labels.Parse("juju-model-uuid==" + testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	for name, objLabels := range created {
+		c.Check(selector.Matches(labels.Set(objLabels)), jc.IsTrue, gc.Commentf("%s survives release", name))
+	}
+}
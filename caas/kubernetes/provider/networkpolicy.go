@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	networking "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// EnsureNetworkPolicy creates or updates the network policy restricting
+// ingress to the pods of the specified application. Pods of the
+// application itself and of the allowed applications, along with their
+// operators, may connect; anything may connect if the policy allows all.
+func (k *kubernetesClient) EnsureNetworkPolicy(appName string, resourceTags map[string]string, policy caas.NetworkPolicy) error {
+	logger.Debugf("creating/updating network policy for %s", appName)
+
+	labels := map[string]string{labelApplication: appName}
+	for k, v := range resourceTags {
+		labels[k] = v
+	}
+	rule := networking.NetworkPolicyIngressRule{}
+	if !policy.AllowAll {
+		// An ingress rule without peers allows all sources,
+		// so only list them when restricting ingress.
+		for _, name := range append([]string{appName}, policy.AllowedApplications...) {
+			rule.From = append(rule.From,
+				networking.NetworkPolicyPeer{
+					PodSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{labelApplication: name},
+					},
+				},
+				networking.NetworkPolicyPeer{
+					PodSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{labelOperator: name},
+					},
+				},
+			)
+		}
+	}
+	spec := &networking.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: labels,
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Ingress:     []networking.NetworkPolicyIngressRule{rule},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		},
+	}
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := policies.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = policies.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+// DeleteNetworkPolicy deletes the network policy of the specified
+// application, leaving ingress to its pods unrestricted.
+func (k *kubernetesClient) DeleteNetworkPolicy(appName string) error {
+	logger.Debugf("deleting network policy for %s", appName)
+	policies := k.NetworkingV1().NetworkPolicies(k.namespace)
+	err := policies.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+func appPeers(names ...string) []networking.NetworkPolicyPeer {
+	var peers []networking.NetworkPolicyPeer
+	for _, name := range names {
+		peers = append(peers,
+			networking.NetworkPolicyPeer{
+				PodSelector: &v1.LabelSelector{
+					MatchLabels: map[string]string{"juju-application": name},
+				},
+			},
+			networking.NetworkPolicyPeer{
+				PodSelector: &v1.LabelSelector{
+					MatchLabels: map[string]string{"juju-operator": name},
+				},
+			},
+		)
+	}
+	return peers
+}
+
+func networkPolicyArg(rule networking.NetworkPolicyIngressRule) *networking.NetworkPolicy {
+	return &networking.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-gitlab",
+			Labels: map[string]string{"juju-application": "gitlab", "juju-controller-uuid": "deadbeef"},
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "gitlab"},
+			},
+			Ingress:     []networking.NetworkPolicyIngressRule{rule},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyRelated(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	policyArg := networkPolicyArg(networking.NetworkPolicyIngressRule{
+		From: appPeers("gitlab", "mariadb", "redis"),
+	})
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Update(policyArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policyArg).Times(1).
+			Return(policyArg, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", map[string]string{"juju-controller-uuid": "deadbeef"}, caas.NetworkPolicy{
+		AllowedApplications: []string{"mariadb", "redis"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyAllowAll(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	policyArg := networkPolicyArg(networking.NetworkPolicyIngressRule{})
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Update(policyArg).Times(1).
+			Return(policyArg, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", map[string]string{"juju-controller-uuid": "deadbeef"}, caas.NetworkPolicy{
+		AllowedApplications: []string{"mariadb"},
+		AllowAll:            true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestDeleteNetworkPolicy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Delete("juju-gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.DeleteNetworkPolicy("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -101,6 +101,13 @@ func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Co
 	if err := config.Validate(cfg, old); err != nil {
 		return nil, err
 	}
+	namespace := cfg.UnknownAttrs()[caas.NamespaceConfigKey]
+	if _, ok := namespace.(string); namespace != nil && !ok {
+		return nil, errors.NotValidf("%s value %v", caas.NamespaceConfigKey, namespace)
+	}
+	if old != nil && old.UnknownAttrs()[caas.NamespaceConfigKey] != namespace {
+		return nil, errors.Errorf("cannot change %s of a model", caas.NamespaceConfigKey)
+	}
 	return cfg, nil
 }
 
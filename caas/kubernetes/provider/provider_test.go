@@ -125,3 +125,11 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestValidateCannotChangeNamespace(c *gc.C) {
+	old := fakeConfig(c, coretesting.Attrs{"namespace": "existing"})
+	config, err := old.Apply(coretesting.Attrs{"namespace": "other"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(config, old)
+	c.Assert(err, gc.ErrorMatches, "cannot change namespace of a model")
+}
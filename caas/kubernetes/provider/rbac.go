@@ -0,0 +1,292 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs/tags"
+)
+
+// configureServiceAccount creates or updates the service account the
+// application's pods run as, along with the role and role binding which
+// grant it the access asked for in the spec. A global service account
+// is granted access with a cluster role and cluster role binding instead,
+// and any role left over from when it was not global is removed, and
+// vice versa.
+func (k *kubernetesClient) configureServiceAccount(
+	appName string, labels map[string]string, spec *caas.ServiceAccountSpec,
+) (cleanups []func(), err error) {
+	logger.Debugf("creating/updating service account for %s", appName)
+
+	name := serviceAccountName(appName)
+	if err := k.ensureServiceAccount(&core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+	}); err != nil {
+		return nil, errors.Annotatef(err, "creating or updating service account %q", name)
+	}
+	cleanups = append(cleanups, func() { k.deleteServiceAccount(name) })
+
+	rules := make([]rbacv1.PolicyRule, len(spec.Rules))
+	for i, r := range spec.Rules {
+		rules[i] = rbacv1.PolicyRule{
+			APIGroups:       r.APIGroups,
+			Resources:       r.Resources,
+			ResourceNames:   r.ResourceNames,
+			NonResourceURLs: r.NonResourceURLs,
+			Verbs:           r.Verbs,
+		}
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      name,
+		Namespace: k.namespace,
+	}}
+
+	if !spec.Global {
+		if err := k.ensureRole(&rbacv1.Role{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: k.namespace,
+				Labels:    labels,
+			},
+			Rules: rules,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating role %q", name)
+		}
+		cleanups = append(cleanups, func() { k.deleteRole(name) })
+		if err := k.ensureRoleBinding(&rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: k.namespace,
+				Labels:    labels,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			},
+			Subjects: subjects,
+		}); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating role binding %q", name)
+		}
+		cleanups = append(cleanups, func() { k.deleteRoleBinding(name) })
+		return cleanups, errors.Trace(k.deleteClusterRoleAndBinding(appName))
+	}
+
+	clusterName := clusterRoleName(k.namespace, appName)
+	if err := k.ensureClusterRole(&rbacv1.ClusterRole{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   clusterName,
+			Labels: labels,
+		},
+		Rules: rules,
+	}); err != nil {
+		return cleanups, errors.Annotatef(err, "creating or updating cluster role %q", clusterName)
+	}
+	cleanups = append(cleanups, func() { k.deleteClusterRole(clusterName) })
+	if err := k.ensureClusterRoleBinding(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   clusterName,
+			Labels: labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterName,
+		},
+		Subjects: subjects,
+	}); err != nil {
+		return cleanups, errors.Annotatef(err, "creating or updating cluster role binding %q", clusterName)
+	}
+	cleanups = append(cleanups, func() { k.deleteClusterRoleBinding(clusterName) })
+	return cleanups, errors.Trace(k.deleteRoleAndBinding(appName))
+}
+
+// deleteServiceAccountAndRoles deletes the service account for the
+// application, and any roles and role bindings granting it access.
+func (k *kubernetesClient) deleteServiceAccountAndRoles(appName string) error {
+	if err := k.deleteRoleAndBinding(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteClusterRoleAndBinding(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteServiceAccount(serviceAccountName(appName)))
+}
+
+// removeServiceAccount removes the service account of an application
+// whose pod spec no longer asks for one, along with any roles and role
+// bindings granting it access.
+func (k *kubernetesClient) removeServiceAccount(appName string) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	_, err := serviceAccounts.Get(serviceAccountName(appName), v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteServiceAccountAndRoles(appName))
+}
+
+func (k *kubernetesClient) deleteRoleAndBinding(appName string) error {
+	name := serviceAccountName(appName)
+	if err := k.deleteRoleBinding(name); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteRole(name))
+}
+
+func (k *kubernetesClient) deleteClusterRoleAndBinding(appName string) error {
+	name := clusterRoleName(k.namespace, appName)
+	if err := k.deleteClusterRoleBinding(name); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteClusterRole(name))
+}
+
+func (k *kubernetesClient) ensureServiceAccount(spec *core.ServiceAccount) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	_, err := serviceAccounts.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteServiceAccount(name string) error {
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	err := serviceAccounts.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRole(spec *rbacv1.Role) error {
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteRole(name string) error {
+	roles := k.RbacV1().Roles(k.namespace)
+	err := roles.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRoleBinding(spec *rbacv1.RoleBinding) error {
+	roleBindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err := roleBindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roleBindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteRoleBinding(name string) error {
+	roleBindings := k.RbacV1().RoleBindings(k.namespace)
+	err := roleBindings.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRole(spec *rbacv1.ClusterRole) error {
+	clusterRoles := k.RbacV1().ClusterRoles()
+	_, err := clusterRoles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = clusterRoles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteClusterRole(name string) error {
+	clusterRoles := k.RbacV1().ClusterRoles()
+	err := clusterRoles.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRoleBinding(spec *rbacv1.ClusterRoleBinding) error {
+	clusterRoleBindings := k.RbacV1().ClusterRoleBindings()
+	_, err := clusterRoleBindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = clusterRoleBindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteClusterRoleBinding(name string) error {
+	clusterRoleBindings := k.RbacV1().ClusterRoleBindings()
+	err := clusterRoleBindings.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// deleteModelClusterRoles deletes the cluster roles and cluster role
+// bindings created for the model's applications. They live outside the
+// namespace so are not deleted along with it.
+func (k *kubernetesClient) deleteModelClusterRoles() error {
+	deleteOptions := &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}
+	listOptions := v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v==%v", tags.JujuModel, k.modelUUID),
+	}
+	err := k.RbacV1().ClusterRoleBindings().DeleteCollection(deleteOptions, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting model cluster role bindings")
+	}
+	err = k.RbacV1().ClusterRoles().DeleteCollection(deleteOptions, listOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting model cluster roles")
+	}
+	return nil
+}
+
+func serviceAccountName(appName string) string {
+	return deploymentName(appName)
+}
+
+// clusterRoleName returns the name of the cluster role for the
+// application. Cluster roles are not namespaced, so the name
+// includes the model's namespace to keep it unique.
+func clusterRoleName(namespace, appName string) string {
+	return namespace + "-" + deploymentName(appName)
+}
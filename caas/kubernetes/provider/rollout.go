@@ -0,0 +1,299 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+const (
+	// deploymentRevisionAnnotation is set by Kubernetes on a deployment
+	// and its replica sets to record the revision of the pod template.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// podTemplateHashAnnotation records the hash of the pod template
+	// last applied to a deployment by Juju.
+	podTemplateHashAnnotation = "juju-pod-template-hash"
+
+	// abortedPodTemplateHashAnnotation records the hash of a pod
+	// template whose rollout was aborted, so that it is not applied
+	// again until the charm sets a different pod spec.
+	abortedPodTemplateHashAnnotation = "juju-aborted-pod-template-hash"
+)
+
+// podTemplateHash returns a hash identifying the pod template.
+func podTemplateHash(template core.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// applyDeploymentStrategy sets the update strategy of a deployment
+// from the pod spec. A nil strategy keeps the Kubernetes defaults.
+func applyDeploymentStrategy(spec *apps.DeploymentSpec, us *caas.UpdateStrategy) error {
+	if us == nil {
+		return nil
+	}
+	if err := us.Validate(caas.DeploymentStateless); err != nil {
+		return errors.Trace(err)
+	}
+	spec.ProgressDeadlineSeconds = us.ProgressDeadlineSeconds
+	if us.Type == caas.Recreate {
+		spec.Strategy.Type = apps.RecreateDeploymentStrategyType
+		return nil
+	}
+	spec.Strategy.Type = apps.RollingUpdateDeploymentStrategyType
+	if us.MaxUnavailable == "" && us.MaxSurge == "" {
+		return nil
+	}
+	spec.Strategy.RollingUpdate = &apps.RollingUpdateDeployment{
+		MaxUnavailable: intOrPercent(us.MaxUnavailable),
+		MaxSurge:       intOrPercent(us.MaxSurge),
+	}
+	return nil
+}
+
+// applyStatefulSetStrategy sets the update strategy of a stateful
+// set from the pod spec. A nil strategy keeps the Kubernetes defaults.
+func applyStatefulSetStrategy(spec *apps.StatefulSetSpec, us *caas.UpdateStrategy) error {
+	if us == nil {
+		return nil
+	}
+	if err := us.Validate(caas.DeploymentStateful); err != nil {
+		return errors.Trace(err)
+	}
+	if us.Type == caas.OnDelete {
+		spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+		return nil
+	}
+	spec.UpdateStrategy.Type = apps.RollingUpdateStatefulSetStrategyType
+	if us.Partition != nil {
+		spec.UpdateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
+			Partition: us.Partition,
+		}
+	}
+	return nil
+}
+
+// applyDaemonSetStrategy sets the update strategy of a daemon set
+// from the pod spec. A nil strategy keeps the Kubernetes defaults.
+func applyDaemonSetStrategy(spec *apps.DaemonSetSpec, us *caas.UpdateStrategy) error {
+	if us == nil {
+		return nil
+	}
+	if err := us.Validate(caas.DeploymentDaemon); err != nil {
+		return errors.Trace(err)
+	}
+	if us.Type == caas.OnDelete {
+		spec.UpdateStrategy.Type = apps.OnDeleteDaemonSetStrategyType
+		return nil
+	}
+	spec.UpdateStrategy.Type = apps.RollingUpdateDaemonSetStrategyType
+	if us.MaxUnavailable != "" {
+		spec.UpdateStrategy.RollingUpdate = &apps.RollingUpdateDaemonSet{
+			MaxUnavailable: intOrPercent(us.MaxUnavailable),
+		}
+	}
+	return nil
+}
+
+func intOrPercent(value string) *intstr.IntOrString {
+	if value == "" {
+		return nil
+	}
+	result := intstr.Parse(value)
+	return &result
+}
+
+// RolloutStatus returns the progress of replacing the pods of
+// the specified application after its pod spec changed.
+// The checks made follow those of "kubectl rollout status".
+func (k *kubernetesClient) RolloutStatus(appName string) (*caas.RolloutStatus, error) {
+	name := deploymentName(appName)
+	deployment, err := k.AppsV1().Deployments(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if err == nil {
+		return deploymentRolloutStatus(deployment), nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	statefulSet, err := k.AppsV1().StatefulSets(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if err == nil {
+		return statefulSetRolloutStatus(statefulSet), nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	daemonSet, err := k.AppsV1().DaemonSets(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if err == nil {
+		return daemonSetRolloutStatus(daemonSet), nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return nil, errors.NotFoundf("pods for application %q", appName)
+}
+
+func deploymentRolloutStatus(deployment *apps.Deployment) *caas.RolloutStatus {
+	result := &caas.RolloutStatus{
+		Generation: deployment.Generation,
+		Desired:    int(deployment.Status.Replicas),
+		Updated:    int(deployment.Status.UpdatedReplicas),
+		Ready:      int(deployment.Status.AvailableReplicas),
+	}
+	if deployment.Spec.Replicas != nil {
+		result.Desired = int(*deployment.Spec.Replicas)
+	}
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		result.Message = "waiting for the new pod spec to be observed"
+		return result
+	}
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == apps.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			result.Failed = true
+			result.Message = cond.Message
+			return result
+		}
+	}
+	switch {
+	case deployment.Status.UpdatedReplicas < int32(result.Desired):
+		result.Message = fmt.Sprintf("%d of %d pods updated", result.Updated, result.Desired)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		result.Message = fmt.Sprintf("%d old pods pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		result.Message = fmt.Sprintf("%d of %d updated pods available", result.Ready, result.Updated)
+	default:
+		result.Complete = true
+	}
+	return result
+}
+
+func statefulSetRolloutStatus(statefulSet *apps.StatefulSet) *caas.RolloutStatus {
+	result := &caas.RolloutStatus{
+		Generation: statefulSet.Generation,
+		Desired:    int(statefulSet.Status.Replicas),
+		Updated:    int(statefulSet.Status.UpdatedReplicas),
+		Ready:      int(statefulSet.Status.ReadyReplicas),
+	}
+	if statefulSet.Spec.Replicas != nil {
+		result.Desired = int(*statefulSet.Spec.Replicas)
+	}
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		result.Message = "waiting for the new pod spec to be observed"
+		return result
+	}
+	if result.Ready < result.Desired {
+		result.Message = fmt.Sprintf("%d of %d pods ready", result.Ready, result.Desired)
+		return result
+	}
+	strategy := statefulSet.Spec.UpdateStrategy
+	switch {
+	case strategy.Type == apps.OnDeleteStatefulSetStrategyType:
+		// Pods are only replaced when deleted, so there
+		// is no rollout to wait for.
+		result.Complete = true
+	case strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil:
+		expected := result.Desired - int(*strategy.RollingUpdate.Partition)
+		if result.Updated < expected {
+			result.Message = fmt.Sprintf("%d of %d pods updated", result.Updated, expected)
+		} else {
+			result.Complete = true
+		}
+	case statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision:
+		result.Message = fmt.Sprintf("%d of %d pods updated", result.Updated, result.Desired)
+	default:
+		result.Complete = true
+	}
+	return result
+}
+
+func daemonSetRolloutStatus(daemonSet *apps.DaemonSet) *caas.RolloutStatus {
+	result := &caas.RolloutStatus{
+		Generation: daemonSet.Generation,
+		Desired:    int(daemonSet.Status.DesiredNumberScheduled),
+		Updated:    int(daemonSet.Status.UpdatedNumberScheduled),
+		Ready:      int(daemonSet.Status.NumberAvailable),
+	}
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		result.Message = "waiting for the new pod spec to be observed"
+		return result
+	}
+	switch {
+	case daemonSet.Spec.UpdateStrategy.Type == apps.OnDeleteDaemonSetStrategyType:
+		result.Complete = true
+	case result.Updated < result.Desired:
+		result.Message = fmt.Sprintf("%d of %d pods updated", result.Updated, result.Desired)
+	case result.Ready < result.Desired:
+		result.Message = fmt.Sprintf("%d of %d updated pods available", result.Ready, result.Desired)
+	default:
+		result.Complete = true
+	}
+	return result
+}
+
+// AbortRollout stops the current rollout of the specified application,
+// returning its pods to the previous pod spec. Only stateless
+// applications keep the previous pod spec to return to. The aborted
+// pod spec is not applied again until the charm sets a different one.
+func (k *kubernetesClient) AbortRollout(appName string) error {
+	name := deploymentName(appName)
+	deployments := k.AppsV1().Deployments(k.namespace)
+	deployment, err := deployments.Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotSupportedf("aborting the rollout of application %q which is not stateless", appName)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	revision, err := strconv.ParseInt(deployment.Annotations[deploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return errors.NotFoundf("revision of application %q", appName)
+	}
+
+	replicaSets, err := k.AppsV1().ReplicaSets(k.namespace).List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var (
+		previous         *apps.ReplicaSet
+		previousRevision int64
+	)
+	for i, rs := range replicaSets.Items {
+		if !v1.IsControlledBy(&replicaSets.Items[i], deployment) {
+			continue
+		}
+		rsRevision, err := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		if err != nil || rsRevision >= revision || rsRevision <= previousRevision {
+			continue
+		}
+		previous = &replicaSets.Items[i]
+		previousRevision = rsRevision
+	}
+	if previous == nil {
+		return errors.NotFoundf("previous revision of application %q", appName)
+	}
+
+	logger.Infof("rolling back %s from revision %d to %d", appName, revision, previousRevision)
+	template := previous.Spec.Template
+	delete(template.Labels, apps.DefaultDeploymentUniqueLabelKey)
+	deployment.Spec.Template = template
+	if hash := deployment.Annotations[podTemplateHashAnnotation]; hash != "" {
+		deployment.Annotations[abortedPodTemplateHashAnnotation] = hash
+	}
+	_, err = deployments.Update(deployment)
+	return errors.Trace(err)
+}
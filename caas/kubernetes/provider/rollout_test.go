@@ -0,0 +1,431 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+// withPodTemplateHash annotates the deployment with the
+// hash of its pod template, as Juju does when applying it.
+func withPodTemplateHash(c *gc.C, deployment *appsv1.Deployment) {
+	hash, err := provider.PodTemplateHash(deployment.Spec.Template)
+	c.Assert(err, jc.ErrorIsNil)
+	if deployment.Annotations == nil {
+		deployment.Annotations = make(map[string]string)
+	}
+	deployment.Annotations["juju-pod-template-hash"] = hash
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithUpdateStrategy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.UpdateStrategy = &caas.UpdateStrategy{
+		MaxUnavailable:          "0",
+		MaxSurge:                "25%",
+		ProgressDeadlineSeconds: int32Ptr(120),
+	}
+	unitSpec, err := provider.MakeUnitSpec("app-name", &basicPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromString("25%")
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(2),
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
+			ProgressDeadlineSeconds: int32Ptr(120),
+		},
+	}
+
+	withPodTemplateHash(c, deploymentArg)
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deploymentArg, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceKeepsAbortedPodSpec(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(2),
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-app-name-",
+					Labels:       labels,
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	withPodTemplateHash(c, deploymentArg)
+	hash := deploymentArg.Annotations["juju-pod-template-hash"]
+
+	// The rollout of the pod spec was aborted, so the
+	// previous pod template is kept.
+	previousTemplate := core.PodTemplateSpec{
+		Spec: core.PodSpec{Containers: []core.Container{{Name: "test", Image: "juju/image:1"}}},
+	}
+	existing := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-app-name",
+			Annotations: map[string]string{
+				"juju-pod-template-hash":         hash,
+				"juju-aborted-pod-template-hash": hash,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Template: previousTemplate},
+	}
+	kept := *deploymentArg
+	kept.Annotations = map[string]string{
+		"juju-pod-template-hash":         hash,
+		"juju-aborted-pod-template-hash": hash,
+	}
+	kept.Spec.Template = previousTemplate
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(existing, nil),
+		s.mockDeployments.EXPECT().Update(&kept).Times(1).
+			Return(nil, nil),
+		s.mockDaemonSets.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockHorizontalAutoscalers.EXPECT().Delete("juju-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithInvalidUpdateStrategy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := *basicPodspec
+	basicPodSpec.UpdateStrategy = &caas.UpdateStrategy{
+		Partition: int32Ptr(1),
+	}
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockConfigMaps.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ConfigMapList{}, nil),
+		s.mockServiceAccounts.EXPECT().Get("juju-app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Delete("juju-app-name-test-secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &basicPodSpec,
+	}
+	statusCallback := func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}
+	err := s.broker.EnsureService("app-name", statusCallback, params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, `creating or updating DeploymentController: partition for stateless application not valid`)
+}
+
+func (s *K8sBrokerSuite) TestRolloutStatusDeployment(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-app-name", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           4,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  3,
+		},
+	}
+	failed := *deployment
+	failed.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  core.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "juju-app-name-1234" has timed out progressing.`,
+	}}
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deployment, nil),
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&failed, nil),
+	)
+
+	rollout, err := s.broker.RolloutStatus("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, &caas.RolloutStatus{
+		Generation: 2,
+		Desired:    3,
+		Updated:    2,
+		Ready:      3,
+		Message:    "2 of 3 pods updated",
+	})
+
+	rollout, err = s.broker.RolloutStatus("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, &caas.RolloutStatus{
+		Generation: 2,
+		Desired:    3,
+		Updated:    2,
+		Ready:      3,
+		Failed:     true,
+		Message:    `ReplicaSet "juju-app-name-1234" has timed out progressing.`,
+	})
+}
+
+func (s *K8sBrokerSuite) TestRolloutStatusStatefulSetPartition(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-app-name"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: int32Ptr(2),
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        3,
+			ReadyReplicas:   3,
+			UpdatedReplicas: 1,
+			CurrentRevision: "juju-app-name-1",
+			UpdateRevision:  "juju-app-name-2",
+		},
+	}
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(statefulSet, nil),
+	)
+
+	rollout, err := s.broker.RolloutStatus("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, &caas.RolloutStatus{
+		Desired:  3,
+		Updated:  1,
+		Ready:    3,
+		Complete: true,
+	})
+}
+
+func (s *K8sBrokerSuite) TestRolloutStatusNotFound(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	_, err := s.broker.RolloutStatus("app-name")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestAbortRollout(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "juju-app-name",
+			UID:  "deployment-uid",
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": "3",
+				"juju-pod-template-hash":            "hash-3",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{Containers: []core.Container{{Name: "test", Image: "juju/image:3"}}},
+			},
+		},
+	}
+	isController := true
+	replicaSet := func(revision, image string) appsv1.ReplicaSet {
+		return appsv1.ReplicaSet{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        "juju-app-name-" + revision,
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": revision},
+				OwnerReferences: []v1.OwnerReference{{
+					Kind:       "Deployment",
+					Name:       "juju-app-name",
+					UID:        "deployment-uid",
+					Controller: &isController,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: core.PodTemplateSpec{
+					ObjectMeta: v1.ObjectMeta{
+						Labels: map[string]string{
+							"juju-application":  "app-name",
+							"pod-template-hash": "hash-" + revision,
+						},
+					},
+					Spec: core.PodSpec{Containers: []core.Container{{Name: "test", Image: image}}},
+				},
+			},
+		}
+	}
+	rolledBack := *deployment
+	rolledBack.Annotations = map[string]string{
+		"deployment.kubernetes.io/revision": "3",
+		"juju-pod-template-hash":            "hash-3",
+		"juju-aborted-pod-template-hash":    "hash-3",
+	}
+	rolledBack.Spec.Template = core.PodTemplateSpec{
+		ObjectMeta: v1.ObjectMeta{
+			Labels: map[string]string{"juju-application": "app-name"},
+		},
+		Spec: core.PodSpec{Containers: []core.Container{{Name: "test", Image: "juju/image:2"}}},
+	}
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(deployment, nil),
+		s.mockReplicaSets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&appsv1.ReplicaSetList{Items: []appsv1.ReplicaSet{
+				replicaSet("1", "juju/image:1"),
+				replicaSet("3", "juju/image:3"),
+				replicaSet("2", "juju/image:2"),
+			}}, nil),
+		s.mockDeployments.EXPECT().Update(&rolledBack).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.AbortRollout("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestAbortRolloutNotStateless(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("juju-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	err := s.broker.AbortRollout("app-name")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// ensurePodSpecObjects creates or updates the secrets and config maps
+// declared in the pod spec. They are labelled as belonging to the
+// application so they are removed when the application is deleted,
+// and any left over from a previous pod spec are removed here.
+// Errors name the object but never include its data.
+func (k *kubernetesClient) ensurePodSpecObjects(
+	appName string, labels map[string]string, spec *caas.PodSpec,
+) (cleanups []func(), err error) {
+	for _, s := range spec.Secrets {
+		name := appSecretName(appName, s.Name)
+		secretType := core.SecretTypeOpaque
+		if s.Type != "" {
+			secretType = core.SecretType(s.Type)
+		}
+		secret := &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: k.namespace,
+				Labels:    labels,
+			},
+			Type: secretType,
+			Data: make(map[string][]byte),
+		}
+		for key, value := range s.Data {
+			secret.Data[key] = []byte(value)
+		}
+		if err := k.updateOrCreateSecret(secret); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating secret %q", s.Name)
+		}
+		cleanups = append(cleanups, func() { k.deleteSecret(name) })
+	}
+
+	for _, cm := range spec.ConfigMaps {
+		name := applicationConfigMapName(appName, cm.Name)
+		configMap := &core.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: k.namespace,
+				Labels:    labels,
+			},
+			Data: make(map[string]string),
+		}
+		for key, value := range cm.Data {
+			configMap.Data[key] = value
+		}
+		if err := k.ensureConfigMap(configMap); err != nil {
+			return cleanups, errors.Annotatef(err, "creating or updating config map %q", cm.Name)
+		}
+		cleanups = append(cleanups, func() { k.deleteConfigMap(name) })
+	}
+	if err := k.deleteStalePodSpecObjects(appName, spec); err != nil {
+		return cleanups, errors.Trace(err)
+	}
+	return cleanups, nil
+}
+
+// deleteStalePodSpecObjects deletes the secrets and config maps
+// labelled as belonging to the application which the pod spec no
+// longer declares. The image pull secrets of the containers and the
+// config maps holding their files are kept.
+func (k *kubernetesClient) deleteStalePodSpecObjects(appName string, spec *caas.PodSpec) error {
+	secretNames := set.NewStrings()
+	for _, s := range spec.Secrets {
+		secretNames.Add(appSecretName(appName, s.Name))
+	}
+	configMapNames := set.NewStrings()
+	for _, cm := range spec.ConfigMaps {
+		configMapNames.Add(applicationConfigMapName(appName, cm.Name))
+	}
+	for _, c := range append(spec.Containers, spec.InitContainers...) {
+		if c.ImageDetails.Password != "" {
+			secretNames.Add(appSecretName(appName, c.Name))
+		}
+		for _, fileSet := range c.Files {
+			configMapNames.Add(applicationConfigMapName(appName, fileSet.Name))
+		}
+	}
+
+	listOptions := v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	}
+	secretList, err := k.CoreV1().Secrets(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range secretList.Items {
+		if secretNames.Contains(s.Name) {
+			continue
+		}
+		if err := k.deleteSecret(s.Name); err != nil {
+			return errors.Annotatef(err, "deleting secret %q", s.Name)
+		}
+	}
+	configMapList, err := k.CoreV1().ConfigMaps(k.namespace).List(listOptions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, cm := range configMapList.Items {
+		if configMapNames.Contains(cm.Name) {
+			continue
+		}
+		if err := k.deleteConfigMap(cm.Name); err != nil {
+			return errors.Annotatef(err, "deleting config map %q", cm.Name)
+		}
+	}
+	return nil
+}
+
+// deletePodSpecConfigMaps deletes the config maps created for the
+// application from its pod spec. Its secrets are deleted along with
+// the image pull secrets, which carry the same labels.
+func (k *kubernetesClient) deletePodSpecConfigMaps(appName string) error {
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
+	configMapList, err := configMaps.List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, cm := range configMapList.Items {
+		if err := k.deleteConfigMap(cm.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesClient) deleteConfigMap(name string) error {
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
+	err := configMaps.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// mountPodSpecObjects adds the secrets and config maps which the
+// containers refer to as environment sources and volume mounts.
+func mountPodSpecObjects(appName string, pod *core.PodSpec, spec *caas.PodSpec) {
+	volumes := set.NewStrings()
+	mount := func(podContainers []core.Container, containers []caas.ContainerSpec) {
+		for i, c := range containers {
+			for _, src := range c.EnvFrom {
+				var envFrom core.EnvFromSource
+				if src.Secret != "" {
+					envFrom.SecretRef = &core.SecretEnvSource{
+						LocalObjectReference: core.LocalObjectReference{
+							Name: appSecretName(appName, src.Secret),
+						},
+					}
+				} else {
+					envFrom.ConfigMapRef = &core.ConfigMapEnvSource{
+						LocalObjectReference: core.LocalObjectReference{
+							Name: applicationConfigMapName(appName, src.ConfigMap),
+						},
+					}
+				}
+				podContainers[i].EnvFrom = append(podContainers[i].EnvFrom, envFrom)
+			}
+			for _, m := range c.Mounts {
+				var vol core.Volume
+				if m.Secret != "" {
+					vol.Name = appSecretName(appName, m.Secret)
+					vol.Secret = &core.SecretVolumeSource{SecretName: vol.Name}
+				} else {
+					vol.Name = applicationConfigMapName(appName, m.ConfigMap)
+					vol.ConfigMap = &core.ConfigMapVolumeSource{
+						LocalObjectReference: core.LocalObjectReference{Name: vol.Name},
+					}
+				}
+				if !volumes.Contains(vol.Name) {
+					volumes.Add(vol.Name)
+					pod.Volumes = append(pod.Volumes, vol)
+				}
+				podContainers[i].VolumeMounts = append(podContainers[i].VolumeMounts, core.VolumeMount{
+					Name:      vol.Name,
+					MountPath: m.MountPath,
+					ReadOnly:  true,
+				})
+			}
+		}
+	}
+	mount(pod.Containers, spec.Containers)
+	mount(pod.InitContainers, spec.InitContainers)
+}
@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const bindErrorPrefix = "bindings must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
+
+var usageBindSummary = `
+Changes the spaces an application's endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Rebinds the endpoints of a deployed application to different spaces,
+using the same form as the --bind option of deploy. A lone space name
+changes the default space, which applies to all endpoints that are not
+explicitly bound. Endpoints not mentioned keep their current bindings.
+
+Units in relations on the rebound endpoints, and the units they are
+related to, are notified of the new addresses through relation-changed
+hooks, and the application's units run config-changed so that they can
+reconfigure the addresses they listen on.
+
+The machines hosting the application's units must be connected to the
+new spaces. Use --force to change the bindings regardless.
+
+Examples:
+    juju bind mysql internal
+    juju bind mysql db=internal monitoring=admin
+    juju bind --force mysql public db=internal
+
+See also:
+    deploy
+    show-application
+    spaces`[1:]
+
+// NewBindCommand returns a command to change the endpoint bindings of
+// an application.
+func NewBindCommand() modelcmd.ModelCommand {
+	c := &bindCommand{}
+	c.newAPIFunc = func() (SetBindingsAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+
+	ApplicationName string
+	Bindings        map[string]string
+	Force           bool
+
+	newAPIFunc func() (SetBindingsAPI, error)
+}
+
+// SetBindingsAPI defines the API methods that the bind command uses.
+type SetBindingsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetBindings(application string, bindings map[string]string, force bool) error
+}
+
+// Info implements Command.Info.
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application> [<default-space>] [<endpoint-name>=<space> ...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *bindCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Force, "force", false, "Allow endpoints to be bound to spaces the units' machines are not connected to")
+}
+
+// Init implements Command.Init.
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	bindings, err := parseBindExpr(strings.Join(args[1:], " "), bindErrorPrefix)
+	if err != nil {
+		return err
+	}
+	c.Bindings = bindings
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *bindCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 9 {
+		return errors.New("bind is not supported by this controller")
+	}
+	err = client.SetBindings(c.ApplicationName, c.Bindings, c.Force)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type BindSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockSetBindingsAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetBindingsAPI{Stub: &testing.Stub{}, version: 9}
+}
+
+func (s *BindSuite) runBind(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewBindCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql/0", "internal"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  "bindings must be in the form .* Found = without endpoint name. .*",
+	}, {
+		args: []string{"mysql", "db=internal=public"},
+		err:  "bindings must be in the form .* Found multiple = in binding. .*",
+	}, {
+		args: []string{"mysql", "db=$bad"},
+		err:  "bindings must be in the form .* Space name invalid.",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	err := s.runBind(c, "mysql", "internal", "db=public")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetBindings", "mysql", map[string]string{
+		"":   "internal",
+		"db": "public",
+	}, false)
+}
+
+func (s *BindSuite) TestBindForce(c *gc.C) {
+	err := s.runBind(c, "--force", "mysql", "db=public")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetBindings", "mysql", map[string]string{"db": "public"}, true)
+}
+
+func (s *BindSuite) TestBindError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runBind(c, "mysql", "db=public")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *BindSuite) TestBindNotSupported(c *gc.C) {
+	s.mockAPI.version = 8
+	err := s.runBind(c, "mysql", "db=public")
+	c.Assert(err, gc.ErrorMatches, "bind is not supported by this controller")
+}
+
+type mockSetBindingsAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (m *mockSetBindingsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSetBindingsAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockSetBindingsAPI) SetBindings(application string, bindings map[string]string, force bool) error {
+	m.MethodCall(m, "SetBindings", application, bindings, force)
+	return m.NextErr()
+}
@@ -58,11 +58,15 @@ type deploymentLogger interface {
 }
 
 // composeBundle adds the overlays and bundle includes into the passed bundle
-// data struct.
-func composeBundle(data *charm.BundleData, ctx *cmd.Context, bundleDir string, overlayFileNames []string) error {
-	if err := processBundleOverlay(data, overlayFileNames...); err != nil {
+// data struct. The bundle data must already have been read with the same
+// variables, so that any variable set but never declared is reported.
+func composeBundle(data *charm.BundleData, ctx *cmd.Context, bundleDir string, overlayFileNames []string, vars *bundleVariables) error {
+	if err := processBundleOverlay(data, vars, overlayFileNames...); err != nil {
 		return errors.Annotate(err, "unable to process overlays")
 	}
+	if err := vars.checkDeclared(); err != nil {
+		return errors.Trace(err)
+	}
 	if bundleDir == "" {
 		bundleDir = ctx.Dir
 	}
@@ -110,6 +114,7 @@ func deployBundle(
 	data *charm.BundleData,
 	bundleURL *charm.URL,
 	bundleOverlayFile []string,
+	vars *bundleVariables,
 	channel csparams.Channel,
 	apiRoot DeployAPI,
 	ctx *cmd.Context,
@@ -118,17 +123,22 @@ func deployBundle(
 	dryRun bool,
 	useExistingMachines bool,
 	bundleMachines map[string]string,
+	prune *bundlePrune,
+	out *cmd.Output,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 
-	if err := composeBundle(data, ctx, bundleDir, bundleOverlayFile); err != nil {
+	if err := composeBundle(data, ctx, bundleDir, bundleOverlayFile, vars); err != nil {
 		return nil, errors.Trace(err)
 	}
 	if err := verifyBundle(data, bundleDir); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if prune != nil {
+		markBundleOwnership(data, prune.owner)
+	}
 
 	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices)
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices, prune, out)
 	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -138,6 +148,7 @@ func deployBundle(
 	if err := h.getChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	h.getPruneChanges()
 	if err := h.handleChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -154,6 +165,19 @@ type bundleHandler struct {
 	// changes holds the changes to be applied in order to deploy the bundle.
 	changes []bundlechanges.Change
 
+	// prune holds the settings for removing entities owned by the bundle
+	// that the bundle no longer describes. It is nil when not pruning.
+	prune *bundlePrune
+
+	// pruneChanges holds the removals to apply after the bundle changes.
+	pruneChanges []pruneChange
+
+	// offers holds the application offers in the model, keyed by offer name.
+	offers map[string]params.ApplicationOfferStatus
+
+	// out is used to write the plan of changes when doing a dry run.
+	out *cmd.Output
+
 	// applications are all the applications defined in the bundle.
 	// Used primarily for iterating over sorted values.
 	applications set.Strings
@@ -234,6 +258,8 @@ func makeBundleHandler(
 	bundleURL *charm.URL,
 	bundleStorage map[string]map[string]storage.Constraints,
 	bundleDevices map[string]map[string]devices.Constraints,
+	prune *bundlePrune,
+	out *cmd.Output,
 ) *bundleHandler {
 	applications := set.NewStrings()
 	for name := range data.Applications {
@@ -242,6 +268,8 @@ func makeBundleHandler(
 	return &bundleHandler{
 		dryRun:        dryRun,
 		bundleDir:     bundleDir,
+		prune:         prune,
+		out:           out,
 		applications:  applications,
 		results:       make(map[string]string),
 		channel:       channel,
@@ -271,6 +299,7 @@ func (h *bundleHandler) makeModel(
 		return errors.Trace(err)
 	}
 	logger.Debugf("model: %s", pretty.Sprint(h.model))
+	h.offers = status.Offers
 
 	for _, appData := range status.Applications {
 		for unit, unitData := range appData.Units {
@@ -367,8 +396,16 @@ func (h *bundleHandler) getChanges() error {
 	return nil
 }
 
-func (h *bundleHandler) handleChanges() error {
-	var err error
+// getPruneChanges computes the removals needed for the model to converge on
+// the bundle, when pruning has been requested.
+func (h *bundleHandler) getPruneChanges() {
+	if h.prune == nil {
+		return
+	}
+	h.pruneChanges = computePruneChanges(h.data, h.model, h.offers, h.prune.owner)
+}
+
+func (h *bundleHandler) handleChanges() (err error) {
 	// Instantiate a watcher used to follow the deployment progress.
 	h.watcher, err = h.api.WatchAll()
 	if err != nil {
@@ -376,20 +413,29 @@ func (h *bundleHandler) handleChanges() error {
 	}
 	defer h.watcher.Stop()
 
-	if len(h.changes) == 0 {
+	if len(h.changes) == 0 && len(h.pruneChanges) == 0 && h.out.Name() == "human" {
 		h.ctx.Infof("No changes to apply.")
 		return nil
 	}
 
 	if h.dryRun {
-		fmt.Fprintf(h.ctx.Stdout, "Changes to deploy bundle:\n")
+		// The handlers below do nothing but report details of the
+		// changes in dry-run mode; the plan itself is written once
+		// they have all been visited.
+		defer func() {
+			if err == nil {
+				err = h.out.Write(h.ctx, h.makePlan())
+			}
+		}()
 	} else {
 		fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
 	}
 
 	// Deploy the bundle.
 	for i, change := range h.changes {
-		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		if !h.dryRun {
+			fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		}
 		logger.Tracef("%d: change %s", i, pretty.Sprint(change))
 		switch change := change.(type) {
 		case *bundlechanges.AddCharmChange:
@@ -422,6 +468,16 @@ func (h *bundleHandler) handleChanges() error {
 		}
 	}
 
+	// Remove what the bundle owns but no longer describes.
+	for _, change := range h.pruneChanges {
+		if !h.dryRun {
+			fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		}
+		if err = h.pruneEntity(change); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if !h.dryRun {
 		h.ctx.Infof("Deploy of bundle completed.")
 	}
@@ -1232,7 +1288,7 @@ type bundleOverlayValueExists struct {
 	Applications map[string]map[string]interface{} `yaml:"applications"`
 }
 
-func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string) error {
+func processBundleOverlay(data *charm.BundleData, vars *bundleVariables, bundleOverlayFiles ...string) error {
 	for _, filename := range bundleOverlayFiles {
 		bundleOverlayFile, err := utils.NormalizePath(filename)
 		if err != nil {
@@ -1247,15 +1303,15 @@ func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string)
 			}
 			bundleOverlayFile = filepath.Clean(filepath.Join(cwd, bundleOverlayFile))
 		}
-		if err := processSingleBundleOverlay(data, bundleOverlayFile); err != nil {
+		if err := processSingleBundleOverlay(data, vars, bundleOverlayFile); err != nil {
 			return errors.Trace(err)
 		}
 	}
 	return nil
 }
 
-func processSingleBundleOverlay(data *charm.BundleData, bundleOverlayFile string) error {
-	config, err := charmrepo.ReadBundleFile(bundleOverlayFile)
+func processSingleBundleOverlay(data *charm.BundleData, vars *bundleVariables, bundleOverlayFile string) error {
+	config, err := readBundleFile(bundleOverlayFile, vars)
 	if err != nil {
 		return errors.Annotatef(err, "unable to read bundle overlay file %q", bundleOverlayFile)
 	}
@@ -1274,6 +1330,10 @@ func processSingleBundleOverlay(data *charm.BundleData, bundleOverlayFile string
 	if err != nil {
 		return errors.Annotate(err, "unable to open bundle overlay file")
 	}
+	content, err = expandBundleVariables(content, vars)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	baseDir := filepath.Dir(bundleOverlayFile)
 
 	// If this works, then this deserialisation should certainly succeed.
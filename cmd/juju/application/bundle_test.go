@@ -5,6 +5,7 @@ package application
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http/httptest"
@@ -420,6 +421,44 @@ func (s *BundleDeployCharmStoreSuite) TestDryRunExistingModel(c *gc.C) {
 	c.Check(stdOut, gc.Equals, expected)
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDryRunStructuredOutput(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	stdOut, _, err := runDeployWithOutput(c, "bundle/wordpress-simple", "--dry-run", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var plan bundlePlan
+	err = json.Unmarshal([]byte(stdOut), &plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan.Changes, gc.HasLen, 9)
+
+	addCharm := plan.Changes[0]
+	c.Check(addCharm.Id, gc.Equals, "addCharm-0")
+	c.Check(addCharm.Method, gc.Equals, "addCharm")
+	c.Check(addCharm.Charm, gc.Equals, "cs:xenial/mysql-42")
+	c.Assert(addCharm.Revision, gc.NotNil)
+	c.Check(*addCharm.Revision, gc.Equals, 42)
+	c.Check(addCharm.Series, gc.Equals, "xenial")
+
+	deploy := plan.Changes[1]
+	c.Check(deploy.Method, gc.Equals, "deploy")
+	c.Check(deploy.Application, gc.Equals, "mysql")
+	c.Check(deploy.Charm, gc.Equals, "cs:xenial/mysql-42")
+	c.Check(deploy.Requires, jc.DeepEquals, []string{"addCharm-0"})
+
+	s.assertCharmsUploaded(c /* none */)
+	s.assertApplicationsDeployed(c, map[string]applicationInfo{})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestStructuredOutputRequiresDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	err := runDeploy(c, "bundle/wordpress-simple", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, "flags provided but only supported with --dry-run: --format")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleGatedCharm(c *gc.C) {
 	_, mysqlch := testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
 	url, _ := testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
@@ -2067,18 +2106,18 @@ func (s *ProcessBundleOverlaySuite) writeFile(c *gc.C, content string) string {
 }
 
 func (s *ProcessBundleOverlaySuite) TestNoFile(c *gc.C) {
-	err := processBundleOverlay(s.bundleData)
+	err := processBundleOverlay(s.bundleData, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *ProcessBundleOverlaySuite) TestBadFile(c *gc.C) {
-	err := processBundleOverlay(s.bundleData, "bad")
+	err := processBundleOverlay(s.bundleData, nil, "bad")
 	c.Assert(err, gc.ErrorMatches, `unable to read bundle overlay file ".*": bundle not found: .*bad`)
 }
 
 func (s *ProcessBundleOverlaySuite) TestGoodYAML(c *gc.C) {
 	filename := s.writeFile(c, "bad:\n\tindent")
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, gc.ErrorMatches, `unable to read bundle overlay file ".*": cannot unmarshal bundle data: yaml: line 2: found character that cannot start any token`)
 }
 
@@ -2090,7 +2129,7 @@ func (s *ProcessBundleOverlaySuite) TestReplaceZeroValues(c *gc.C) {
                 num_units: 0
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 
@@ -2105,7 +2144,7 @@ func (s *ProcessBundleOverlaySuite) TestMachineReplacement(c *gc.C) {
             2:
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 
 	var machines []string
@@ -2137,7 +2176,7 @@ func (s *ProcessBundleOverlaySuite) TestNewApplication(c *gc.C) {
               - "django:pgsql"
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertApplications(c, "django", "memcached", "postgresql")
 	c.Assert(s.bundleData.Relations, jc.DeepEquals, [][]string{
@@ -2152,7 +2191,7 @@ func (s *ProcessBundleOverlaySuite) TestRemoveApplication(c *gc.C) {
             memcached:
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertApplications(c, "django")
 	c.Assert(s.bundleData.Relations, gc.HasLen, 0)
@@ -2164,7 +2203,7 @@ func (s *ProcessBundleOverlaySuite) TestRemoveUnknownApplication(c *gc.C) {
             unknown:
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertApplications(c, "django", "memcached")
 	c.Assert(s.bundleData.Relations, jc.DeepEquals, [][]string{
@@ -2195,7 +2234,7 @@ func (s *ProcessBundleOverlaySuite) TestIncludes(c *gc.C) {
 			[]byte("value3"), 0644),
 		jc.ErrorIsNil)
 
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 	c.Check(django.Annotations, jc.DeepEquals, map[string]string{
@@ -2228,7 +2267,7 @@ func (s *ProcessBundleOverlaySuite) TestRemainingFields(c *gc.C) {
                     where: dmz
     `
 	filename := s.writeFile(c, config)
-	err := processBundleOverlay(s.bundleData, filename)
+	err := processBundleOverlay(s.bundleData, nil, filename)
 	c.Assert(err, jc.ErrorIsNil)
 	django := s.bundleData.Applications["django"]
 
@@ -2273,7 +2312,7 @@ relations:
       - "memcached"
 `)
 
-	err := processBundleOverlay(s.bundleData, removeDjango, addWiki)
+	err := processBundleOverlay(s.bundleData, nil, removeDjango, addWiki)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.assertApplications(c, "memcached", "wiki")
@@ -30,6 +30,9 @@ same way as the deploy command) before comparing with the model.
 The map-machines option works similarly as for the deploy command, but
 existing is always assumed, so it doesn't need to be specified.
 
+Bundle variables are set with the var and var-file options, as for the
+deploy command.
+
 Examples:
     juju diff-bundle localbundle.yaml
     juju diff-bundle canonical-kubernetes
@@ -37,6 +40,7 @@ Examples:
     juju diff-bundle mongodb-cluster --channel beta
     juju diff-bundle canonical-kubernetes --overlay local-config.yaml --overlay extra.yaml
     juju diff-bundle localbundle.yaml --map-machines 3=4
+    juju diff-bundle localbundle.yaml --var production=true
 
 See also:
     deploy
@@ -59,6 +63,10 @@ type bundleDiffCommand struct {
 	bundleMachines map[string]string
 	machineMap     string
 
+	bundleVars      map[string]string
+	bundleVarFiles  []string
+	bundleVariables *bundleVariables
+
 	// These are set in tests to enable mocking out the API and the
 	// charm store.
 	_apiRoot    base.APICallCloser
@@ -88,6 +96,8 @@ func (c *bundleDiffCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.bundleOverlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
 	f.BoolVar(&c.annotations, "annotations", false, "Include differences in annotations")
+	f.Var(stringMap{&c.bundleVars}, "var", "Set a bundle variable as <name>=<value>")
+	f.Var(cmd.NewAppendStringsValue(&c.bundleVarFiles), "var-file", "YAML file of bundle variable values")
 }
 
 // Init is part of cmd.Command.
@@ -103,6 +113,11 @@ func (c *bundleDiffCommand) Init(args []string) error {
 	}
 	c.bundleMachines = mapping
 
+	c.bundleVariables, err = parseBundleVariables(c.bundleVars, c.bundleVarFiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -119,7 +134,7 @@ func (c *bundleDiffCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := composeBundle(bundle, ctx, bundleDir, c.bundleOverlays); err != nil {
+	if err := composeBundle(bundle, ctx, bundleDir, c.bundleOverlays, c.bundleVariables); err != nil {
 		return errors.Trace(err)
 	}
 	if err := verifyBundle(bundle, bundleDir); err != nil {
@@ -160,7 +175,7 @@ func (c *bundleDiffCommand) newAPIRoot() (base.APICallCloser, error) {
 }
 
 func (c *bundleDiffCommand) readBundle(ctx *cmd.Context, apiRoot base.APICallCloser) (*charm.BundleData, string, error) {
-	bundleData, bundleDir, err := readLocalBundle(ctx, c.bundle)
+	bundleData, bundleDir, err := readLocalBundle(ctx, c.bundle, c.bundleVariables)
 	// NotValid means we should try interpreting it as a charm store
 	// bundle URL.
 	if err != nil && !errors.IsNotValid(err) {
@@ -190,8 +205,11 @@ func (c *bundleDiffCommand) readBundle(ctx *cmd.Context, apiRoot base.APICallClo
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
-
-	return bundle.Data(), "", nil
+	bundleData, err = expandBundle(bundle, c.bundleVariables)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return bundleData, "", nil
 }
 
 func (c *bundleDiffCommand) charmStore() (BundleResolver, error) {
@@ -0,0 +1,116 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// bundlePlan is the structured form of the changes computed for a bundle
+// deployment, as output by deploy --dry-run.
+type bundlePlan struct {
+	Changes []bundlePlanChange `yaml:"changes" json:"changes"`
+}
+
+// bundlePlanChange describes a single change in a bundle plan. Change ids
+// are those computed by bundlechanges, and are stable for a given bundle
+// and model.
+type bundlePlanChange struct {
+	Id          string        `yaml:"id" json:"id"`
+	Method      string        `yaml:"method" json:"method"`
+	Description string        `yaml:"description" json:"description"`
+	Requires    []string      `yaml:"requires,omitempty" json:"requires,omitempty"`
+	Application string        `yaml:"application,omitempty" json:"application,omitempty"`
+	Charm       string        `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Revision    *int          `yaml:"revision,omitempty" json:"revision,omitempty"`
+	Series      string        `yaml:"series,omitempty" json:"series,omitempty"`
+	Args        []interface{} `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// makePlan returns the structured plan for the bundle and prune changes
+// held by the handler.
+func (h *bundleHandler) makePlan() bundlePlan {
+	plan := bundlePlan{
+		Changes: make([]bundlePlanChange, 0, len(h.changes)+len(h.pruneChanges)),
+	}
+	// Charms added by the bundle, keyed by change id, so that placeholders
+	// in later changes can be reported as the resolved charm.
+	charms := make(map[string]string)
+	for _, change := range h.changes {
+		planChange := bundlePlanChange{
+			Id:          change.Id(),
+			Method:      change.Method(),
+			Description: change.Description(),
+			Requires:    change.Requires(),
+			Args:        change.GUIArgs(),
+		}
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			charms[change.Id()] = change.Params.Charm
+			planChange.Charm = change.Params.Charm
+			planChange.Series = change.Params.Series
+		case *bundlechanges.AddApplicationChange:
+			planChange.Application = change.Params.Application
+			planChange.Charm = resolve(change.Params.Charm, charms)
+			planChange.Series = change.Params.Series
+		case *bundlechanges.UpgradeCharmChange:
+			planChange.Application = change.Params.Application
+			planChange.Charm = resolve(change.Params.Charm, charms)
+		}
+		planChange.Revision, planChange.Series = charmRevisionAndSeries(planChange.Charm, planChange.Series)
+		plan.Changes = append(plan.Changes, planChange)
+	}
+	for i, change := range h.pruneChanges {
+		planChange := bundlePlanChange{
+			Id:          fmt.Sprintf("%s-%d", change.Method(), i),
+			Method:      change.Method(),
+			Description: change.Description(),
+		}
+		if change.kind == pruneApplicationKind {
+			planChange.Application = change.id
+		}
+		plan.Changes = append(plan.Changes, planChange)
+	}
+	return plan
+}
+
+// charmRevisionAndSeries returns the revision of the given charm store
+// URL, if it has one, along with the series from the URL when no series
+// was otherwise specified. Local charm paths are left alone.
+func charmRevisionAndSeries(charmURL, series string) (*int, string) {
+	if charmURL == "" {
+		return nil, series
+	}
+	url, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return nil, series
+	}
+	if series == "" {
+		series = url.Series
+	}
+	if url.Revision < 0 {
+		return nil, series
+	}
+	revision := url.Revision
+	return &revision, series
+}
+
+// formatBundlePlanHuman writes the bundle plan as a list of change
+// descriptions.
+func formatBundlePlanHuman(writer io.Writer, value interface{}) error {
+	plan, ok := value.(bundlePlan)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", plan, value)
+	}
+	fmt.Fprintf(writer, "Changes to deploy bundle:\n")
+	for _, change := range plan.Changes {
+		fmt.Fprintf(writer, "- %s\n", change.Description)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type bundlePlanSuite struct{}
+
+var _ = gc.Suite(&bundlePlanSuite{})
+
+func (*bundlePlanSuite) TestCharmRevisionAndSeries(c *gc.C) {
+	revision, series := charmRevisionAndSeries("cs:xenial/mysql-42", "")
+	c.Assert(revision, gc.NotNil)
+	c.Assert(*revision, gc.Equals, 42)
+	c.Assert(series, gc.Equals, "xenial")
+
+	revision, series = charmRevisionAndSeries("cs:xenial/mysql-42", "bionic")
+	c.Assert(*revision, gc.Equals, 42)
+	c.Assert(series, gc.Equals, "bionic")
+
+	revision, series = charmRevisionAndSeries("cs:mysql", "")
+	c.Assert(revision, gc.IsNil)
+	c.Assert(series, gc.Equals, "")
+
+	revision, series = charmRevisionAndSeries("./charms/mysql", "xenial")
+	c.Assert(revision, gc.IsNil)
+	c.Assert(series, gc.Equals, "xenial")
+}
+
+func (*bundlePlanSuite) TestMakePlanPruneChanges(c *gc.C) {
+	h := &bundleHandler{
+		pruneChanges: []pruneChange{
+			{kind: pruneOfferKind, id: "db"},
+			{kind: pruneApplicationKind, id: "mysql"},
+		},
+	}
+	plan := h.makePlan()
+	c.Assert(plan.Changes, jc.DeepEquals, []bundlePlanChange{{
+		Id:          "removeOffer-0",
+		Method:      "removeOffer",
+		Description: "remove offer db",
+	}, {
+		Id:          "removeApplication-1",
+		Method:      "removeApplication",
+		Description: "remove application mysql",
+		Application: "mysql",
+	}})
+}
+
+func (*bundlePlanSuite) TestFormatBundlePlanHuman(c *gc.C) {
+	plan := bundlePlan{Changes: []bundlePlanChange{
+		{Description: "upload charm cs:xenial/mysql-42 for series xenial"},
+		{Description: "remove application wordpress"},
+	}}
+	var buf bytes.Buffer
+	err := formatBundlePlanHuman(&buf, plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Changes to deploy bundle:\n"+
+		"- upload charm cs:xenial/mysql-42 for series xenial\n"+
+		"- remove application wordpress\n")
+}
@@ -0,0 +1,282 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// bundleOwnerAnnotation is the application annotation used to record which
+// bundle owns an application. Only applications carrying this annotation
+// with a value matching the bundle being deployed are considered for
+// removal when pruning.
+const bundleOwnerAnnotation = "bundle-owner"
+
+// bundlePrune holds what is needed to remove the parts of a model that
+// are owned by a bundle but are no longer described by it.
+type bundlePrune struct {
+	// owner identifies the bundle being deployed.
+	owner string
+
+	// modelName is the fully qualified name (owner/model) of the model
+	// being deployed into. It is used to build offer URLs.
+	modelName string
+}
+
+// pruneChange is a single removal computed when pruning a model.
+type pruneChange struct {
+	// kind is one of the prune*Kind constants below.
+	kind string
+
+	// id identifies the entity to remove: an offer or application name,
+	// a unit name, or a relation key in the form "app1:ep1 app2:ep2".
+	id string
+
+	// endpoints holds the relation endpoints for relation removals.
+	endpoints []string
+}
+
+const (
+	pruneOfferKind       = "offer"
+	pruneRelationKind    = "relation"
+	pruneUnitKind        = "unit"
+	pruneApplicationKind = "application"
+)
+
+// Method returns the name of the change, in the same style as the
+// bundlechanges change methods.
+func (c pruneChange) Method() string {
+	return "remove" + strings.Title(c.kind)
+}
+
+// Description returns a human readable description of the change.
+func (c pruneChange) Description() string {
+	return fmt.Sprintf("remove %s %s", c.kind, c.id)
+}
+
+// markBundleOwnership adds the bundle owner annotation to every application
+// in the bundle, so that the resulting bundle changes claim ownership of
+// the applications.
+func markBundleOwnership(data *charm.BundleData, owner string) {
+	for _, spec := range data.Applications {
+		if spec.Annotations == nil {
+			spec.Annotations = make(map[string]string)
+		}
+		spec.Annotations[bundleOwnerAnnotation] = owner
+	}
+}
+
+// computePruneChanges returns the changes needed to remove the offers,
+// relations, units and applications owned by the bundle identified by owner
+// that are present in the model but not in the bundle data. The offers of
+// an owned application are owned with it. Changes are returned in the order
+// they need to be applied.
+func computePruneChanges(
+	data *charm.BundleData,
+	model *bundlechanges.Model,
+	offers map[string]params.ApplicationOfferStatus,
+	owner string,
+) []pruneChange {
+	owned := func(app *bundlechanges.Application) bool {
+		return app != nil && app.Annotations[bundleOwnerAnnotation] == owner
+	}
+
+	var appNames []string
+	for name := range model.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+
+	removedApps := make(map[string]bool)
+	var applications, units []pruneChange
+	for _, name := range appNames {
+		app := model.Applications[name]
+		if !owned(app) {
+			continue
+		}
+		spec, ok := data.Applications[name]
+		if !ok {
+			removedApps[name] = true
+			applications = append(applications, pruneChange{
+				kind: pruneApplicationKind,
+				id:   name,
+			})
+			continue
+		}
+		// Subordinate units follow their principals, and CAAS
+		// applications are scaled rather than having units removed.
+		if len(app.SubordinateTo) > 0 || app.Scale > 0 {
+			continue
+		}
+		for _, unit := range excessUnits(app.Units, spec.NumUnits) {
+			units = append(units, pruneChange{
+				kind: pruneUnitKind,
+				id:   unit,
+			})
+		}
+	}
+
+	var relations []pruneChange
+	for _, rel := range model.Relations {
+		if removedApps[rel.App1] || removedApps[rel.App2] {
+			// Removing the application removes the relation.
+			continue
+		}
+		if !owned(model.Applications[rel.App1]) || !owned(model.Applications[rel.App2]) {
+			continue
+		}
+		if bundleHasRelation(data.Relations, rel) {
+			continue
+		}
+		endpoints := []string{
+			rel.App1 + ":" + rel.Endpoint1,
+			rel.App2 + ":" + rel.Endpoint2,
+		}
+		relations = append(relations, pruneChange{
+			kind:      pruneRelationKind,
+			id:        strings.Join(endpoints, " "),
+			endpoints: endpoints,
+		})
+	}
+
+	// Offers go with their application, and the offers of a kept
+	// application are pruned when the bundle no longer lists them.
+	var offerChanges []pruneChange
+	for name, offer := range offers {
+		appName := offer.ApplicationName
+		if !removedApps[appName] {
+			spec := data.Applications[appName]
+			if spec == nil || !owned(model.Applications[appName]) {
+				continue
+			}
+			if _, ok := spec.Offers[name]; ok {
+				continue
+			}
+		}
+		offerChanges = append(offerChanges, pruneChange{
+			kind: pruneOfferKind,
+			id:   name,
+		})
+	}
+	sort.Slice(offerChanges, func(i, j int) bool {
+		return offerChanges[i].id < offerChanges[j].id
+	})
+
+	var changes []pruneChange
+	changes = append(changes, offerChanges...)
+	changes = append(changes, relations...)
+	changes = append(changes, units...)
+	changes = append(changes, applications...)
+	return changes
+}
+
+// excessUnits returns the names of the units above the wanted number,
+// picking the most recently added units first.
+func excessUnits(units []bundlechanges.Unit, wanted int) []string {
+	if len(units) <= wanted {
+		return nil
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.Name
+	}
+	sort.Slice(unitNames, func(i, j int) bool {
+		return names.NewUnitTag(unitNames[i]).Number() < names.NewUnitTag(unitNames[j]).Number()
+	})
+	return unitNames[wanted:]
+}
+
+// bundleHasRelation reports whether the given model relation is described
+// by one of the bundle relations. Bundle relations may omit the endpoint
+// names, in which case any endpoint of the application matches.
+func bundleHasRelation(relations [][]string, rel bundlechanges.Relation) bool {
+	matches := func(bundleEndpoint, app, endpoint string) bool {
+		parts := strings.SplitN(bundleEndpoint, ":", 2)
+		if parts[0] != app {
+			return false
+		}
+		return len(parts) == 1 || parts[1] == endpoint
+	}
+	for _, relation := range relations {
+		if len(relation) != 2 {
+			continue
+		}
+		left, right := relation[0], relation[1]
+		if matches(left, rel.App1, rel.Endpoint1) && matches(right, rel.App2, rel.Endpoint2) {
+			return true
+		}
+		if matches(left, rel.App2, rel.Endpoint2) && matches(right, rel.App1, rel.Endpoint1) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneEntity removes the entity described by the given prune change.
+func (h *bundleHandler) pruneEntity(change pruneChange) error {
+	if h.dryRun {
+		return nil
+	}
+	switch change.kind {
+	case pruneOfferKind:
+		offerURL := crossmodel.MakeURL(h.prune.modelOwner(), h.prune.modelShortName(), change.id, "")
+		if err := h.api.DestroyOffers(false, offerURL); err != nil {
+			return errors.Annotatef(err, "cannot remove offer %q", change.id)
+		}
+	case pruneRelationKind:
+		if err := h.api.DestroyRelation(change.endpoints...); err != nil {
+			return errors.Annotatef(err, "cannot remove relation %q", change.id)
+		}
+	case pruneUnitKind:
+		results, err := h.api.DestroyUnits(application.DestroyUnitsParams{
+			Units: []string{change.id},
+		})
+		if err == nil && len(results) > 0 && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot remove unit %q", change.id)
+		}
+	case pruneApplicationKind:
+		results, err := h.api.DestroyApplications(application.DestroyApplicationsParams{
+			Applications: []string{change.id},
+		})
+		if err == nil && len(results) > 0 && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot remove application %q", change.id)
+		}
+	default:
+		return errors.Errorf("unknown prune change kind %q", change.kind)
+	}
+	return nil
+}
+
+// modelOwner returns the owner part of the qualified model name.
+func (p *bundlePrune) modelOwner() string {
+	if i := strings.Index(p.modelName, "/"); i >= 0 {
+		return p.modelName[:i]
+	}
+	return ""
+}
+
+// modelShortName returns the model name without the owner qualifier.
+func (p *bundlePrune) modelShortName() string {
+	if i := strings.Index(p.modelName, "/"); i >= 0 {
+		return p.modelName[i+1:]
+	}
+	return p.modelName
+}
@@ -0,0 +1,314 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type bundlePruneSuite struct{}
+
+var _ = gc.Suite(&bundlePruneSuite{})
+
+func (*bundlePruneSuite) owned(name string, units ...string) *bundlechanges.Application {
+	app := &bundlechanges.Application{
+		Name:        name,
+		Annotations: map[string]string{bundleOwnerAnnotation: "cs:bundle/wiki"},
+	}
+	for _, unit := range units {
+		app.Units = append(app.Units, bundlechanges.Unit{Name: unit})
+	}
+	return app
+}
+
+func (s *bundlePruneSuite) TestMarkBundleOwnership(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {},
+			"wiki":  {Annotations: map[string]string{"gui-x": "10"}},
+		},
+	}
+	markBundleOwnership(data, "cs:bundle/wiki")
+	c.Assert(data.Applications["mysql"].Annotations, jc.DeepEquals, map[string]string{
+		bundleOwnerAnnotation: "cs:bundle/wiki",
+	})
+	c.Assert(data.Applications["wiki"].Annotations, jc.DeepEquals, map[string]string{
+		"gui-x":               "10",
+		bundleOwnerAnnotation: "cs:bundle/wiki",
+	})
+}
+
+func (s *bundlePruneSuite) TestComputePruneChanges(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				NumUnits: 1,
+				Offers: map[string]*charm.OfferSpec{
+					"database": {Endpoints: []string{"server"}},
+				},
+			},
+			"wiki": {NumUnits: 1},
+		},
+		Relations: [][]string{{"wiki", "mysql"}},
+	}
+	model := &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql":   s.owned("mysql", "mysql/0"),
+			"wiki":    s.owned("wiki", "wiki/0", "wiki/10", "wiki/2"),
+			"haproxy": s.owned("haproxy", "haproxy/0"),
+			"varnish": s.owned("varnish"),
+			"logging": {Name: "logging"},
+		},
+		Relations: []bundlechanges.Relation{
+			{App1: "wiki", Endpoint1: "db", App2: "mysql", Endpoint2: "server"},
+			{App1: "wiki", Endpoint1: "cache", App2: "varnish", Endpoint2: "website"},
+			{App1: "wiki", Endpoint1: "juju-info", App2: "logging", Endpoint2: "info"},
+			{App1: "haproxy", Endpoint1: "reverseproxy", App2: "wiki", Endpoint2: "website"},
+		},
+	}
+	model.Applications["varnish"].Annotations[bundleOwnerAnnotation] = "cs:bundle/other"
+	offers := map[string]params.ApplicationOfferStatus{
+		"proxy":    {OfferName: "proxy", ApplicationName: "haproxy"},
+		"database": {OfferName: "database", ApplicationName: "mysql"},
+	}
+
+	changes := computePruneChanges(data, model, offers, "cs:bundle/wiki")
+	var descriptions []string
+	for _, change := range changes {
+		descriptions = append(descriptions, change.Description())
+	}
+	c.Assert(descriptions, jc.DeepEquals, []string{
+		"remove offer proxy",
+		"remove unit wiki/2",
+		"remove unit wiki/10",
+		"remove application haproxy",
+	})
+}
+
+func (s *bundlePruneSuite) TestComputePruneChangesOffers(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				NumUnits: 1,
+				Offers: map[string]*charm.OfferSpec{
+					"database": {Endpoints: []string{"server"}},
+				},
+			},
+		},
+	}
+	model := &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql":   s.owned("mysql", "mysql/0"),
+			"logging": {Name: "logging"},
+		},
+	}
+	offers := map[string]params.ApplicationOfferStatus{
+		"database": {OfferName: "database", ApplicationName: "mysql"},
+		"admin":    {OfferName: "admin", ApplicationName: "mysql"},
+		"logs":     {OfferName: "logs", ApplicationName: "logging"},
+	}
+
+	changes := computePruneChanges(data, model, offers, "cs:bundle/wiki")
+	c.Assert(changes, jc.DeepEquals, []pruneChange{
+		{kind: pruneOfferKind, id: "admin"},
+	})
+}
+
+func (s *bundlePruneSuite) TestComputePruneChangesRelation(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {},
+			"wiki":  {},
+		},
+		Relations: [][]string{{"mysql:server", "wiki:db"}},
+	}
+	model := &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql": s.owned("mysql"),
+			"wiki":  s.owned("wiki"),
+		},
+		Relations: []bundlechanges.Relation{
+			{App1: "wiki", Endpoint1: "db", App2: "mysql", Endpoint2: "server"},
+			{App1: "wiki", Endpoint1: "slave", App2: "mysql", Endpoint2: "server"},
+		},
+	}
+	changes := computePruneChanges(data, model, nil, "cs:bundle/wiki")
+	c.Assert(changes, jc.DeepEquals, []pruneChange{{
+		kind:      pruneRelationKind,
+		id:        "wiki:slave mysql:server",
+		endpoints: []string{"wiki:slave", "mysql:server"},
+	}})
+}
+
+func (s *bundlePruneSuite) TestComputePruneChangesNothingOwned(c *gc.C) {
+	data := &charm.BundleData{}
+	model := &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql": {Name: "mysql", Units: []bundlechanges.Unit{{Name: "mysql/0"}}},
+		},
+	}
+	changes := computePruneChanges(data, model, nil, "cs:bundle/wiki")
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *bundlePruneSuite) TestBundleOwner(c *gc.C) {
+	owner, err := bundleOwner("", "./bundles/wiki.yaml", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "wiki")
+	owner, err = bundleOwner("", "/path/to/wiki-bundle/", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "wiki-bundle")
+	url := charm.MustParseURL("cs:bundle/wiki-simple-4")
+	owner, err = bundleOwner("", "wiki-simple", url)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "cs:bundle/wiki-simple")
+}
+
+func (s *bundlePruneSuite) TestBundleOwnerExplicit(c *gc.C) {
+	owner, err := bundleOwner("wiki", "./wiki/bundle.yaml", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "wiki")
+	url := charm.MustParseURL("cs:bundle/wiki-simple-4")
+	owner, err = bundleOwner("wiki", "wiki-simple", url)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "wiki")
+}
+
+func (s *bundlePruneSuite) TestBundleOwnerAmbiguous(c *gc.C) {
+	for _, path := range []string{"./wiki/bundle.yaml", "/path/to/bundle/", ".", "/"} {
+		c.Logf("path %q", path)
+		_, err := bundleOwner("", path, nil)
+		c.Assert(err, gc.ErrorMatches, `cannot prune: bundle name ".*" is ambiguous, specify --bundle-owner`)
+	}
+}
+
+func (s *bundlePruneSuite) TestBundlePruneModelName(c *gc.C) {
+	p := &bundlePrune{modelName: "admin/default"}
+	c.Assert(p.modelOwner(), gc.Equals, "admin")
+	c.Assert(p.modelShortName(), gc.Equals, "default")
+}
+
+func (s *bundlePruneSuite) fakeAPI() *fakeDeployAPI {
+	return &fakeDeployAPI{CallMocker: jujutesting.NewCallMocker(loggo.GetLogger("bundleprune_test"))}
+}
+
+func (s *bundlePruneSuite) handler(c *gc.C, api DeployAPI, dryRun bool, data *charm.BundleData) *bundleHandler {
+	var out cmd.Output
+	out.AddFlags(gnuflag.NewFlagSet("deploy", gnuflag.ContinueOnError), "human", map[string]cmd.Formatter{
+		"human": formatBundlePlanHuman,
+	})
+	prune := &bundlePrune{owner: "cs:bundle/wiki", modelName: "admin/default"}
+	return makeBundleHandler(dryRun, "", "", api, cmdtesting.Context(c), data, nil, nil, nil, prune, &out)
+}
+
+func (s *bundlePruneSuite) TestPruneEntity(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("DestroyOffers", false, []string{"admin/default.db"}).Returns(error(nil))
+	fakeAPI.Call("DestroyRelation", []string{"wiki:db", "mysql:server"}).Returns(error(nil))
+	fakeAPI.Call("DestroyUnits", application.DestroyUnitsParams{
+		Units: []string{"wiki/1"},
+	}).Returns([]params.DestroyUnitResult{{}}, error(nil))
+	fakeAPI.Call("DestroyApplications", application.DestroyApplicationsParams{
+		Applications: []string{"mysql"},
+	}).Returns([]params.DestroyApplicationResult{{}}, error(nil))
+
+	h := s.handler(c, fakeAPI, false, &charm.BundleData{})
+	for _, change := range []pruneChange{
+		{kind: pruneOfferKind, id: "db"},
+		{kind: pruneRelationKind, id: "wiki:db mysql:server", endpoints: []string{"wiki:db", "mysql:server"}},
+		{kind: pruneUnitKind, id: "wiki/1"},
+		{kind: pruneApplicationKind, id: "mysql"},
+	} {
+		err := h.pruneEntity(change)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	fakeAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"DestroyOffers", []interface{}{false, []string{"admin/default.db"}}},
+		{"DestroyRelation", []interface{}{[]string{"wiki:db", "mysql:server"}}},
+		{"DestroyUnits", []interface{}{application.DestroyUnitsParams{Units: []string{"wiki/1"}}}},
+		{"DestroyApplications", []interface{}{application.DestroyApplicationsParams{Applications: []string{"mysql"}}}},
+	})
+}
+
+func (s *bundlePruneSuite) TestPruneEntityError(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	fakeAPI.Call("DestroyApplications", application.DestroyApplicationsParams{
+		Applications: []string{"mysql"},
+	}).Returns([]params.DestroyApplicationResult{{Error: &params.Error{Message: "boom"}}}, error(nil))
+
+	h := s.handler(c, fakeAPI, false, &charm.BundleData{})
+	err := h.pruneEntity(pruneChange{kind: pruneApplicationKind, id: "mysql"})
+	c.Assert(err, gc.ErrorMatches, `cannot remove application "mysql": boom`)
+}
+
+func (s *bundlePruneSuite) TestPruneEntityDryRun(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	h := s.handler(c, fakeAPI, true, &charm.BundleData{})
+	err := h.pruneEntity(pruneChange{kind: pruneApplicationKind, id: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	fakeAPI.CheckNoCalls(c)
+}
+
+func (s *bundlePruneSuite) TestHandleChangesPrunes(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	withAllWatcher(fakeAPI)
+	fakeAPI.Call("DestroyOffers", false, []string{"admin/default.db"}).Returns(error(nil))
+	fakeAPI.Call("DestroyUnits", application.DestroyUnitsParams{
+		Units: []string{"wiki/1"},
+	}).Returns([]params.DestroyUnitResult{{}}, error(nil))
+	fakeAPI.Call("DestroyApplications", application.DestroyApplicationsParams{
+		Applications: []string{"mysql"},
+	}).Returns([]params.DestroyApplicationResult{{}}, error(nil))
+
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"wiki": {NumUnits: 1},
+		},
+	}
+	h := s.handler(c, fakeAPI, false, data)
+	h.model = &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql":   s.owned("mysql", "mysql/0"),
+			"wiki":    s.owned("wiki", "wiki/0", "wiki/1"),
+			"varnish": {Name: "varnish"},
+		},
+		Relations: []bundlechanges.Relation{{
+			App1: "wiki", Endpoint1: "db",
+			App2: "mysql", Endpoint2: "server",
+		}},
+	}
+	h.offers = map[string]params.ApplicationOfferStatus{
+		"db": {ApplicationName: "mysql"},
+	}
+	h.getPruneChanges()
+	err := h.handleChanges()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(h.ctx), gc.Equals, ""+
+		"Executing changes:\n"+
+		"- remove offer db\n"+
+		"- remove unit wiki/1\n"+
+		"- remove application mysql\n",
+	)
+	var destroyed []string
+	for _, call := range fakeAPI.Calls() {
+		if strings.HasPrefix(call.FuncName, "Destroy") {
+			destroyed = append(destroyed, call.FuncName)
+		}
+	}
+	c.Assert(destroyed, jc.DeepEquals, []string{"DestroyOffers", "DestroyUnits", "DestroyApplications"})
+}
@@ -0,0 +1,477 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	bundleVariablesKey = "variables"
+	bundleConditionKey = "if"
+)
+
+// bundleVariableRefRegexp matches references to bundle variables, in the
+// form ${name}.
+var bundleVariableRefRegexp = regexp.MustCompile(`\$\{([a-zA-Z][a-zA-Z0-9_-]*)\}`)
+
+// bundleVariableError is returned when the variables or conditions in a
+// bundle cannot be resolved. It is distinct from the errors returned when
+// the content is not a bundle at all.
+type bundleVariableError struct {
+	error
+}
+
+func isBundleVariableError(err error) bool {
+	_, ok := errors.Cause(err).(*bundleVariableError)
+	return ok
+}
+
+func newBundleVariableError(format string, args ...interface{}) error {
+	return &bundleVariableError{errors.Errorf(format, args...)}
+}
+
+// bundleVariables holds the values of the bundle variables supplied on
+// the command line. It records the variables declared by the bundle and
+// overlays expanded with it, so that a value supplied with --var for a
+// variable none of them declare can be reported, and so that overlays
+// can refer to the variables declared by the bundle.
+type bundleVariables struct {
+	// values holds the variable values from --var-file and --var.
+	values map[string]interface{}
+
+	// flagNames holds the names of the variables set with --var.
+	flagNames set.Strings
+
+	// declared holds the names of the variables declared by
+	// the bundle content expanded so far.
+	declared set.Strings
+
+	// scope holds the resolved values of the variables declared by
+	// the bundle content expanded so far.
+	scope map[string]interface{}
+}
+
+// checkDeclared returns an error if a variable set with --var is not
+// declared by the bundle or any of its overlays. Values from variable
+// files are not checked, as a file may be shared between bundles.
+func (v *bundleVariables) checkDeclared() error {
+	if v == nil {
+		return nil
+	}
+	if undeclared := v.flagNames.Difference(v.declared); !undeclared.IsEmpty() {
+		return errors.Errorf("bundle does not declare variables %s set with --var",
+			strings.Join(undeclared.SortedValues(), ", "))
+	}
+	return nil
+}
+
+// parseBundleVariables combines the variables supplied with --var-file and
+// --var into a single map. Values from files keep their YAML types, values
+// from --var are strings, and --var values override those from files.
+func parseBundleVariables(vars map[string]string, varFiles []string) (*bundleVariables, error) {
+	result := &bundleVariables{
+		values:    make(map[string]interface{}),
+		flagNames: set.NewStrings(),
+		declared:  set.NewStrings(),
+		scope:     make(map[string]interface{}),
+	}
+	for _, filename := range varFiles {
+		path, err := utils.NormalizePath(filename)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read bundle variables file")
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return nil, errors.Annotatef(err, "cannot parse bundle variables file %q", filename)
+		}
+		for name, value := range values {
+			result.values[name] = value
+		}
+	}
+	for name, value := range vars {
+		result.values[name] = value
+		result.flagNames.Add(name)
+	}
+	return result, nil
+}
+
+// readBundleFile reads the bundle YAML file at the given path, resolving
+// any bundle variables and conditions before parsing the bundle data.
+func readBundleFile(path string, vars *bundleVariables) (*charm.BundleData, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, charmrepo.BundleNotFound(path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	content, err = expandBundleVariables(content, vars)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadBundleData(bytes.NewReader(content))
+}
+
+// readBundleArchive reads the bundle.yaml in the bundle archive at the
+// given path, resolving any bundle variables and conditions before
+// parsing the bundle data.
+func readBundleArchive(path string, vars *bundleVariables) (*charm.BundleData, error) {
+	content, err := readBundleArchiveFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	content, err = expandBundleVariables(content, vars)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadBundleData(bytes.NewReader(content))
+}
+
+// expandBundle returns the data of a bundle downloaded from the charm
+// store, with its variables and conditions resolved. The declarations
+// of the variables are lost once the bundle data is parsed, so the
+// bundle.yaml is read from the archive again.
+func expandBundle(bundle charm.Bundle, vars *bundleVariables) (*charm.BundleData, error) {
+	archive, ok := bundle.(*charm.BundleArchive)
+	if !ok || archive.Path == "" {
+		return bundle.Data(), nil
+	}
+	return readBundleArchive(archive.Path, vars)
+}
+
+// readBundleArchiveFile returns the content of the bundle.yaml
+// in the bundle archive at the given path.
+func readBundleArchiveFile(path string) ([]byte, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != "bundle.yaml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	return nil, errors.NotFoundf("bundle.yaml in archive %q", path)
+}
+
+// expandBundleVariables resolves the variables declared in the bundle
+// content's "variables" section, substituting references to them, and
+// then drops the applications and relations whose "if" condition is
+// false. Content that is not a YAML map is returned unchanged so that
+// the bundle parsing can report the problem.
+//
+// Variables are declared with a type and an optional default:
+//
+//	variables:
+//	  units:
+//	    type: int
+//	    default: 1
+//	  production:
+//	    type: bool
+//	    default: false
+//	  domain: example.com
+//
+// A variable declared with a plain value is a string variable with that
+// default. References take the form ${name}; a value consisting only of a
+// reference takes the type of the variable, otherwise the variable's value
+// is substituted into the string.
+//
+// The bundle and its overlays share one scope: content may refer to the
+// variables declared by the content expanded before it with the same
+// vars, and its own declarations take precedence.
+func expandBundleVariables(content []byte, vars *bundleVariables) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil || doc == nil {
+		return content, nil
+	}
+	declared, hasVariables := doc[bundleVariablesKey]
+	hasConditions := hasBundleConditions(doc)
+	inScope := vars != nil && len(vars.scope) > 0
+	if !hasVariables && !hasConditions && !inScope {
+		return content, nil
+	}
+
+	values := make(map[string]interface{})
+	if vars != nil {
+		for name, value := range vars.scope {
+			values[name] = value
+		}
+	}
+	if hasVariables {
+		declaredValues, err := resolveBundleVariables(declared, vars)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for name, value := range declaredValues {
+			values[name] = value
+			if vars != nil {
+				vars.scope[name] = value
+			}
+		}
+		delete(doc, bundleVariablesKey)
+	}
+	for key, value := range doc {
+		doc[key] = substituteBundleVariables(value, values)
+	}
+	if err := applyBundleConditions(doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// resolveBundleVariables returns the value of each declared variable,
+// taken from vars or else from its default, converted to its type.
+func resolveBundleVariables(declared interface{}, vars *bundleVariables) (map[string]interface{}, error) {
+	decls, ok := declared.(map[interface{}]interface{})
+	if !ok {
+		return nil, newBundleVariableError("bundle variables must be a map, got %T", declared)
+	}
+	values := make(map[string]interface{})
+	for key, decl := range decls {
+		name := fmt.Sprint(key)
+		varType := "string"
+		defaultValue, hasDefault := decl, decl != nil
+		if attrs, ok := decl.(map[interface{}]interface{}); ok {
+			if t, ok := attrs["type"]; ok {
+				varType = fmt.Sprint(t)
+			}
+			defaultValue, hasDefault = attrs["default"]
+		}
+		var value interface{}
+		var supplied bool
+		if vars != nil {
+			vars.declared.Add(name)
+			value, supplied = vars.values[name]
+		}
+		if !supplied {
+			if !hasDefault {
+				return nil, newBundleVariableError("bundle variable %q has no value; use --var %s=<value>", name, name)
+			}
+			value = defaultValue
+		}
+		converted, err := convertBundleVariable(varType, value)
+		if err != nil {
+			return nil, newBundleVariableError("bundle variable %q: %v", name, err)
+		}
+		values[name] = converted
+	}
+	return values, nil
+}
+
+// convertBundleVariable converts the value to the given variable type.
+func convertBundleVariable(varType string, value interface{}) (interface{}, error) {
+	s := fmt.Sprint(value)
+	switch varType {
+	case "string":
+		return s, nil
+	case "int":
+		if v, ok := value.(int); ok {
+			return v, nil
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.Errorf("expected int, got %q", s)
+		}
+		return v, nil
+	case "float":
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Errorf("expected float, got %q", s)
+		}
+		return v, nil
+	case "bool":
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Errorf("expected bool, got %q", s)
+		}
+		return v, nil
+	}
+	return nil, errors.Errorf("unknown type %q", varType)
+}
+
+// substituteBundleVariables returns the given YAML value with references
+// to the given variables replaced by their values. References to names
+// that are not declared variables are left alone.
+func substituteBundleVariables(value interface{}, values map[string]interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if match := bundleVariableRefRegexp.FindStringSubmatch(value); match != nil && match[0] == value {
+			if v, ok := values[match[1]]; ok {
+				return v
+			}
+			return value
+		}
+		return bundleVariableRefRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+			name := bundleVariableRefRegexp.FindStringSubmatch(ref)[1]
+			if v, ok := values[name]; ok {
+				return fmt.Sprint(v)
+			}
+			return ref
+		})
+	case map[interface{}]interface{}:
+		for k, v := range value {
+			value[k] = substituteBundleVariables(v, values)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = substituteBundleVariables(v, values)
+		}
+	}
+	return value
+}
+
+// hasBundleConditions reports whether any application or relation in the
+// bundle document has a condition.
+func hasBundleConditions(doc map[string]interface{}) bool {
+	for _, key := range []string{"applications", "services"} {
+		apps, _ := doc[key].(map[interface{}]interface{})
+		for _, app := range apps {
+			if attrs, ok := app.(map[interface{}]interface{}); ok {
+				if _, ok := attrs[bundleConditionKey]; ok {
+					return true
+				}
+			}
+		}
+	}
+	relations, _ := doc["relations"].([]interface{})
+	for _, relation := range relations {
+		if _, ok := relation.(map[interface{}]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBundleConditions removes the applications and relations whose
+// condition evaluates to false, along with any relation involving a
+// removed application. Relations with a condition are written as a map
+// with "endpoints" and "if" keys, and are converted to the usual list
+// form.
+func applyBundleConditions(doc map[string]interface{}) error {
+	var removed []string
+	for _, key := range []string{"applications", "services"} {
+		apps, _ := doc[key].(map[interface{}]interface{})
+		for name, app := range apps {
+			attrs, ok := app.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			condition, ok := attrs[bundleConditionKey]
+			if !ok {
+				continue
+			}
+			include, err := evalBundleCondition(condition)
+			if err != nil {
+				return newBundleVariableError("condition for application %q: %v", name, err)
+			}
+			delete(attrs, bundleConditionKey)
+			if !include {
+				delete(apps, name)
+				removed = append(removed, fmt.Sprint(name))
+			}
+		}
+	}
+	sort.Strings(removed)
+
+	relations, ok := doc["relations"].([]interface{})
+	if !ok {
+		return nil
+	}
+	var result [][]string
+	for i, relation := range relations {
+		var endpoints []interface{}
+		switch relation := relation.(type) {
+		case []interface{}:
+			endpoints = relation
+		case map[interface{}]interface{}:
+			endpoints, _ = relation["endpoints"].([]interface{})
+			if condition, ok := relation[bundleConditionKey]; ok {
+				include, err := evalBundleCondition(condition)
+				if err != nil {
+					return newBundleVariableError("condition for relation %d: %v", i, err)
+				}
+				if !include {
+					continue
+				}
+			}
+		default:
+			return newBundleVariableError("relation %d: expected list or map, got %T", i, relation)
+		}
+		endpointNames := make([]string, len(endpoints))
+		for j, endpoint := range endpoints {
+			endpointNames[j] = fmt.Sprint(endpoint)
+		}
+		result = append(result, endpointNames)
+	}
+	for _, name := range removed {
+		result = removeRelations(result, name)
+	}
+	doc["relations"] = result
+	return nil
+}
+
+// evalBundleCondition evaluates an "if" condition, after variable
+// substitution. A condition is either a boolean, or a string comparing
+// two values with "==" or "!=".
+func evalBundleCondition(condition interface{}) (bool, error) {
+	switch condition := condition.(type) {
+	case bool:
+		return condition, nil
+	case string:
+		for _, op := range []string{"==", "!="} {
+			parts := strings.SplitN(condition, op, 2)
+			if len(parts) != 2 {
+				continue
+			}
+			equal := strings.TrimSpace(parts[0]) == strings.TrimSpace(parts[1])
+			return equal == (op == "=="), nil
+		}
+		value, err := strconv.ParseBool(strings.TrimSpace(condition))
+		if err != nil {
+			return false, errors.Errorf("cannot evaluate %q", condition)
+		}
+		return value, nil
+	}
+	return false, errors.Errorf("cannot evaluate %v", condition)
+}
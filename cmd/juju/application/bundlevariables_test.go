@@ -0,0 +1,248 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/collections/set"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+)
+
+type bundleVariablesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&bundleVariablesSuite{})
+
+const variablesBundle = `
+variables:
+  production:
+    type: bool
+    default: false
+  units:
+    type: int
+    default: 1
+  domain: example.com
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    num_units: ${units}
+    options:
+      hostname: blog.${domain}
+      not-a-variable: ${HOME}
+  mysql:
+    charm: cs:xenial/mysql-42
+    num_units: 1
+  memcached:
+    charm: cs:xenial/memcached-7
+    num_units: 1
+    if: ${production}
+relations:
+- - wordpress:db
+  - mysql:server
+- endpoints: [wordpress, memcached]
+  if: ${production}
+- endpoints: [wordpress, mysql]
+  if: ${domain} != example.com
+`
+
+// newBundleVariables returns variables holding the given values,
+// as if they were read from a --var-file.
+func newBundleVariables(values map[string]interface{}) *bundleVariables {
+	return &bundleVariables{
+		values:    values,
+		flagNames: set.NewStrings(),
+		declared:  set.NewStrings(),
+		scope:     make(map[string]interface{}),
+	}
+}
+
+func (s *bundleVariablesSuite) readBundle(c *gc.C, content string, vars map[string]interface{}) (*charm.BundleData, error) {
+	expanded, err := expandBundleVariables([]byte(content), newBundleVariables(vars))
+	if err != nil {
+		return nil, err
+	}
+	data, err := charm.ReadBundleData(bytes.NewReader(expanded))
+	c.Assert(err, jc.ErrorIsNil)
+	return data, nil
+}
+
+func (s *bundleVariablesSuite) TestDefaults(c *gc.C) {
+	data, err := s.readBundle(c, variablesBundle, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 2)
+	wordpress := data.Applications["wordpress"]
+	c.Assert(wordpress.NumUnits, gc.Equals, 1)
+	c.Assert(wordpress.Options, jc.DeepEquals, map[string]interface{}{
+		"hostname":       "blog.example.com",
+		"not-a-variable": "${HOME}",
+	})
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"wordpress:db", "mysql:server"},
+	})
+}
+
+func (s *bundleVariablesSuite) TestVariablesSupplied(c *gc.C) {
+	data, err := s.readBundle(c, variablesBundle, map[string]interface{}{
+		"production": "true",
+		"units":      "3",
+		"domain":     "example.org",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 3)
+	c.Assert(data.Applications["wordpress"].NumUnits, gc.Equals, 3)
+	c.Assert(data.Applications["wordpress"].Options["hostname"], gc.Equals, "blog.example.org")
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"wordpress:db", "mysql:server"},
+		{"wordpress", "memcached"},
+		{"wordpress", "mysql"},
+	})
+}
+
+func (s *bundleVariablesSuite) TestConditionRemovesRelations(c *gc.C) {
+	data, err := s.readBundle(c, `
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-47
+  mysql:
+    charm: cs:xenial/mysql-42
+    if: false
+relations:
+- [wordpress, mysql]
+`, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 1)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *bundleVariablesSuite) TestMissingValue(c *gc.C) {
+	_, err := s.readBundle(c, `
+variables:
+  units:
+    type: int
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    num_units: ${units}
+`, nil)
+	c.Assert(err, gc.ErrorMatches, `bundle variable "units" has no value; use --var units=<value>`)
+	c.Assert(isBundleVariableError(err), jc.IsTrue)
+}
+
+func (s *bundleVariablesSuite) TestBadType(c *gc.C) {
+	_, err := s.readBundle(c, variablesBundle, map[string]interface{}{
+		"units": "many",
+	})
+	c.Assert(err, gc.ErrorMatches, `bundle variable "units": expected int, got "many"`)
+}
+
+func (s *bundleVariablesSuite) TestBadCondition(c *gc.C) {
+	_, err := s.readBundle(c, `
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    if: sometimes
+`, nil)
+	c.Assert(err, gc.ErrorMatches, `condition for application "wordpress": cannot evaluate "sometimes"`)
+}
+
+func (s *bundleVariablesSuite) TestNoVariablesUnchanged(c *gc.C) {
+	content := []byte("applications:\n  wordpress:\n    options:\n      cmd: echo ${HOME}\n")
+	expanded, err := expandBundleVariables(content, newBundleVariables(map[string]interface{}{"HOME": "/root"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expanded, jc.DeepEquals, content)
+}
+
+func (s *bundleVariablesSuite) TestParseBundleVariables(c *gc.C) {
+	dir := c.MkDir()
+	filename := filepath.Join(dir, "vars.yaml")
+	err := ioutil.WriteFile(filename, []byte("units: 3\nproduction: true\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	vars, err := parseBundleVariables(map[string]string{"units": "5"}, []string{filename})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vars.values, jc.DeepEquals, map[string]interface{}{
+		"units":      "5",
+		"production": true,
+	})
+	c.Assert(vars.flagNames.SortedValues(), jc.DeepEquals, []string{"units"})
+}
+
+func (s *bundleVariablesSuite) TestUndeclaredVariable(c *gc.C) {
+	vars, err := parseBundleVariables(map[string]string{"units": "3", "flavour": "large"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = expandBundleVariables([]byte(variablesBundle), vars)
+	c.Assert(err, jc.ErrorIsNil)
+	err = vars.checkDeclared()
+	c.Assert(err, gc.ErrorMatches, `bundle does not declare variables flavour set with --var`)
+}
+
+func (s *bundleVariablesSuite) TestReadLocalBundleDir(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "bundle.yaml"), []byte(variablesBundle), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	vars, err := parseBundleVariables(map[string]string{"units": "3", "production": "true"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	data, bundleDir, err := readLocalBundle(cmdtesting.Context(c), dir, vars)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundleDir, gc.Equals, dir)
+	c.Assert(data.Applications, gc.HasLen, 3)
+	c.Assert(data.Applications["wordpress"].NumUnits, gc.Equals, 3)
+	c.Assert(vars.checkDeclared(), jc.ErrorIsNil)
+}
+
+func (s *bundleVariablesSuite) TestOverlayVariables(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {Charm: "cs:xenial/wordpress-47", NumUnits: 1},
+		},
+	}
+	filename := filepath.Join(c.MkDir(), "overlay.yaml")
+	err := ioutil.WriteFile(filename, []byte(`
+variables:
+  units:
+    type: int
+applications:
+  wordpress:
+    num_units: ${units}
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = processBundleOverlay(data, newBundleVariables(map[string]interface{}{"units": 4}), filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["wordpress"].NumUnits, gc.Equals, 4)
+}
+
+func (s *bundleVariablesSuite) TestOverlayUsesBundleVariables(c *gc.C) {
+	vars := newBundleVariables(map[string]interface{}{"units": 3})
+	expanded, err := expandBundleVariables([]byte(variablesBundle), vars)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := charm.ReadBundleData(bytes.NewReader(expanded))
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(c.MkDir(), "overlay.yaml")
+	err = ioutil.WriteFile(filename, []byte(`
+applications:
+  mysql:
+    num_units: ${units}
+    options:
+      hostname: db.${domain}
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = processBundleOverlay(data, vars, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].NumUnits, gc.Equals, 3)
+	c.Assert(data.Applications["mysql"].Options, jc.DeepEquals, map[string]interface{}{
+		"hostname": "db.example.com",
+	})
+}
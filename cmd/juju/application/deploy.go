@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/romulus"
@@ -28,6 +29,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/applicationoffers"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelconfig"
@@ -57,6 +59,9 @@ type ApplicationAPI interface {
 	AddMachines(machineParams []apiparams.AddMachineParams) ([]apiparams.AddMachinesResult, error)
 	AddRelation(endpoints, viaCIDRs []string) (*apiparams.AddRelationResults, error)
 	AddUnits(application.AddUnitsParams) ([]string, error)
+	DestroyApplications(application.DestroyApplicationsParams) ([]apiparams.DestroyApplicationResult, error)
+	DestroyRelation(endpoints ...string) error
+	DestroyUnits(application.DestroyUnitsParams) ([]apiparams.DestroyUnitResult, error)
 	Expose(application string) error
 	GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error)
 	GetConfig(appNames ...string) ([]map[string]interface{}, error)
@@ -75,6 +80,12 @@ type ModelAPI interface {
 	Sequences() (map[string]int, error)
 }
 
+// OfferAPI represents the methods of the API the deploy command
+// needs for application offers.
+type OfferAPI interface {
+	DestroyOffers(force bool, offerURLs ...string) error
+}
+
 // MeteredDeployAPI represents the methods of the API the deploy
 // command needs for metered charms.
 type MeteredDeployAPI interface {
@@ -99,6 +110,7 @@ type DeployAPI interface {
 	CharmDeployAPI
 	ApplicationAPI
 	ModelAPI
+	OfferAPI
 
 	// ApplicationClient
 	Deploy(application.DeployArgs) error
@@ -149,6 +161,10 @@ type annotationsClient struct {
 	*annotations.Client
 }
 
+type offersClient struct {
+	*applicationoffers.Client
+}
+
 type plansClient struct {
 	planURL string
 }
@@ -170,6 +186,7 @@ type deployAPIAdapter struct {
 	*charmRepoClient
 	*charmstoreClient
 	*annotationsClient
+	*offersClient
 	*plansClient
 }
 
@@ -251,6 +268,7 @@ func NewDeployCommandForTest(newAPIRoot func() (DeployAPI, error), steps []Deplo
 				modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
 				charmstoreClient:  &charmstoreClient{Client: cstoreClient},
 				annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
+				offersClient:      &offersClient{Client: applicationoffers.NewClient(apiRoot)},
 				charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
 				plansClient:       &plansClient{planURL: mURL},
 			}, nil
@@ -304,6 +322,7 @@ func NewDeployCommand() modelcmd.ModelCommand {
 			modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
 			charmstoreClient:  &charmstoreClient{Client: cstoreClient},
 			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
+			offersClient:      &offersClient{Client: applicationoffers.NewClient(apiRoot)},
 			charmRepoClient:   &charmRepoClient{CharmStore: charmrepo.NewCharmStoreFromClient(cstoreClient)},
 			plansClient:       &plansClient{planURL: mURL},
 		}, nil
@@ -324,6 +343,10 @@ type DeployCommand struct {
 	// configuration to be merged with the main bundle.
 	BundleOverlayFile []string
 
+	// bundleVariables holds the values of the variables used by the
+	// bundle and its overlays, from --var and --var-file.
+	bundleVariables *bundleVariables
+
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be deployed.
 	Channel params.Channel
@@ -339,6 +362,15 @@ type DeployCommand struct {
 	// deployed but just output the changes.
 	DryRun bool
 
+	// Prune is used to specify that applications, relations, units and
+	// offers owned by the bundle but no longer described by it should be
+	// removed from the model.
+	Prune bool
+
+	// BundleOwner overrides the owner recorded on, and used to select,
+	// the applications of the bundle when pruning.
+	BundleOwner string
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -386,6 +418,10 @@ type DeployCommand struct {
 
 	machineMap string
 	flagSet    *gnuflag.FlagSet
+	out        cmd.Output
+
+	bundleVars     map[string]string
+	bundleVarFiles []string
 
 	unknownModel bool
 }
@@ -546,6 +582,58 @@ bundle.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+Use the '--prune' option to make the model converge on the bundle. Every
+application in the bundle is annotated as being owned by the bundle, and any
+application carrying that annotation which is no longer in the bundle is
+removed, along with its offers. Relations between owned applications that
+the bundle does not describe, and units beyond the bundle's num_units, are
+also removed. Applications not owned by the bundle are never touched. The
+owner is the charm store URL of the bundle (without revision), or the file
+or directory name of a local bundle. Use '--bundle-owner' to name the owner
+explicitly; it is required for local bundles with a generic name such as
+'bundle.yaml'. Combine with '--dry-run' to review the removals first:
+
+  juju deploy ./mybundle.yaml --prune --dry-run
+
+The changes computed for a bundle with '--dry-run' can be output as YAML or
+JSON with the '--format' option, for review by other tools. Each change has
+an id, which other changes refer to in their 'requires' list, and changes
+adding or upgrading charms include the resolved charm URL, revision and
+series:
+
+  juju deploy ./mybundle.yaml --dry-run --format json
+
+Local bundle files and overlays can declare typed variables, which are
+referenced as ${name} and set with '--var' or '--var-file'. Applications and
+relations can be included conditionally with 'if':
+
+  variables:
+    production:
+      type: bool
+      default: false
+    units:
+      type: int
+      default: 1
+  applications:
+    wordpress:
+      charm: cs:wordpress
+      num_units: ${units}
+    memcached:
+      charm: cs:memcached
+      if: ${production}
+  relations:
+  - - wordpress
+    - mysql
+  - endpoints: [wordpress, memcached]
+    if: ${production}
+
+  juju deploy ./mybundle.yaml --var production=true --var units=3
+  juju deploy ./mybundle.yaml --var-file production.yaml
+
+Variable types are string (the default), int, float and bool. A condition
+is a boolean or a comparison such as '${env} == production'. Relations with
+an application excluded by a condition are also excluded.
+
 When charms that include LXD profiles are deployed the profiles are validated
 for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
@@ -667,7 +755,8 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "dry-run", "map-machines", "prune", "bundle-owner", "format", "o", "output",
+		"var", "var-file",
 	}
 )
 
@@ -697,15 +786,24 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Trust, "trust", false, "Allows charm to run hooks that require access credentials")
 
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.Var(stringMap{&c.bundleVars}, "var", "Set a bundle variable as <name>=<value>")
+	f.Var(cmd.NewAppendStringsValue(&c.bundleVarFiles), "var-file", "YAML file of bundle variable values")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.Prune, "prune", false, "Remove applications, relations, units and offers owned by the bundle that it no longer describes")
+	f.StringVar(&c.BundleOwner, "bundle-owner", "", "The name identifying the bundle's applications when pruning")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
 	f.StringVar(&c.machineMap, "map-machines", "", "Specify the existing machines to use for bundle deployments")
+	c.out.AddFlags(f, "human", map[string]cmd.Formatter{
+		"human": formatBundlePlanHuman,
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+	})
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -752,6 +850,15 @@ func (c *DeployCommand) Init(args []string) error {
 	c.UseExisting = useExisting
 	c.BundleMachines = mapping
 
+	if c.BundleOwner != "" && !c.Prune {
+		return errors.New("--bundle-owner can only be used with --prune")
+	}
+
+	c.bundleVariables, err = parseBundleVariables(c.bundleVars, c.bundleVarFiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.UnitCommandBase.Init(args); err != nil {
 		return err
 	}
@@ -899,6 +1006,22 @@ func (c *DeployCommand) deployBundle(
 		}
 	}
 
+	var prune *bundlePrune
+	if c.Prune {
+		modelName, err := c.ModelName()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		owner, err := bundleOwner(c.BundleOwner, c.CharmOrBundle, bundleURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		prune = &bundlePrune{
+			owner:     owner,
+			modelName: modelName,
+		}
+	}
+
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	// Deploying bundles does not allow the use force, it's expected that the
 	// bundle is correct and therefore the charms are also.
@@ -907,6 +1030,7 @@ func (c *DeployCommand) deployBundle(
 		data,
 		bundleURL,
 		c.BundleOverlayFile,
+		c.bundleVariables,
 		channel,
 		apiRoot,
 		ctx,
@@ -915,12 +1039,39 @@ func (c *DeployCommand) deployBundle(
 		c.DryRun,
 		c.UseExisting,
 		c.BundleMachines,
+		prune,
+		&c.out,
 	); err != nil {
 		return errors.Annotate(err, "cannot deploy bundle")
 	}
 	return nil
 }
 
+// ambiguousBundleOwners holds the local bundle names shared by too many
+// unrelated bundles to safely identify the applications one of them owns.
+var ambiguousBundleOwners = set.NewStrings("", ".", "..", string(filepath.Separator), "bundle")
+
+// bundleOwner returns the value used to mark applications as owned by the
+// bundle being deployed: the owner given with --bundle-owner, the
+// unrevisioned URL for charm store bundles, or the base name of the bundle
+// file or directory for local bundles. An error is returned if a local
+// bundle's name does not identify it, as pruning could then remove the
+// applications of another bundle.
+func bundleOwner(owner, charmOrBundle string, bundleURL *charm.URL) (string, error) {
+	if owner != "" {
+		return owner, nil
+	}
+	if bundleURL != nil {
+		return bundleURL.WithRevision(-1).String(), nil
+	}
+	name := filepath.Base(filepath.Clean(charmOrBundle))
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if ambiguousBundleOwners.Contains(name) {
+		return "", errors.Errorf("cannot prune: bundle name %q is ambiguous, specify --bundle-owner", name)
+	}
+	return name, nil
+}
+
 func (c *DeployCommand) deployCharm(
 	id charmstore.CharmID,
 	csMac *macaroon.Macaroon,
@@ -1108,12 +1259,24 @@ const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<en
 // * The above in a space separated list to specify multiple bindings,
 //   e.g. "rel1=space1 ext1=space2 space3"
 func (c *DeployCommand) parseBind() error {
-	bindings := make(map[string]string)
 	if c.BindToSpaces == "" {
 		return nil
 	}
+	bindings, err := parseBindExpr(c.BindToSpaces, parseBindErrorPrefix)
+	if err != nil {
+		return err
+	}
+	c.Bindings = bindings
+	return nil
+}
 
-	for _, s := range strings.Split(c.BindToSpaces, " ") {
+// parseBindExpr parses a space separated list of endpoint bindings, as
+// accepted by --bind and the bind command. A lone space name sets the
+// default space, which is keyed by the empty endpoint name. Errors are
+// prefixed with errorPrefix, which describes the expected form.
+func parseBindExpr(expr, errorPrefix string) (map[string]string, error) {
+	bindings := make(map[string]string)
+	for _, s := range strings.Split(expr, " ") {
 		s = strings.TrimSpace(s)
 		if s == "" {
 			continue
@@ -1127,21 +1290,20 @@ func (c *DeployCommand) parseBind() error {
 			space = v[0]
 		case 2:
 			if v[0] == "" {
-				return errors.New(parseBindErrorPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
+				return nil, errors.New(errorPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
 			}
 			endpoint = v[0]
 			space = v[1]
 		default:
-			return errors.New(parseBindErrorPrefix + "Found multiple = in binding. Did you forget to space-separate the binding list?")
+			return nil, errors.New(errorPrefix + "Found multiple = in binding. Did you forget to space-separate the binding list?")
 		}
 
 		if !names.IsValidSpace(space) {
-			return errors.New(parseBindErrorPrefix + "Space name invalid.")
+			return nil, errors.New(errorPrefix + "Space name invalid.")
 		}
 		bindings[endpoint] = space
 	}
-	c.Bindings = bindings
-	return nil
+	return bindings, nil
 }
 
 func (c *DeployCommand) Run(ctx *cmd.Context) error {
@@ -1199,6 +1361,9 @@ func (c *DeployCommand) validateBundleFlags() error {
 	if flags := getFlags(c.flagSet, charmOnlyFlags()); len(flags) > 0 {
 		return errors.Errorf("flags provided but not supported when deploying a bundle: %s", strings.Join(flags, ", "))
 	}
+	if flags := getFlags(c.flagSet, []string{"format", "o", "output"}); len(flags) > 0 && !c.DryRun {
+		return errors.Errorf("flags provided but only supported with --dry-run: %s", strings.Join(flags, ", "))
+	}
 	return nil
 }
 
@@ -1283,13 +1448,34 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 // readLocalBundle returns the bundle data and bundle dir (for
 // resolving includes) for the bundleFile passed in. If the bundle
 // file doesn't exist we return nil.
-func readLocalBundle(ctx *cmd.Context, bundleFile string) (*charm.BundleData, string, error) {
-	bundleData, err := charmrepo.ReadBundleFile(bundleFile)
+func readLocalBundle(ctx *cmd.Context, bundleFile string, vars *bundleVariables) (*charm.BundleData, string, error) {
+	bundleData, err := readBundleFile(bundleFile, vars)
 	if err == nil {
 		// If the bundle is defined with just a yaml file, the bundle
 		// path is the directory that holds the file.
 		return bundleData, filepath.Dir(ctx.AbsPath(bundleFile)), nil
 	}
+	if isBundleVariableError(err) {
+		return nil, "", errors.Trace(err)
+	}
+
+	// The bundle.yaml of a bundle directory or archive is read directly,
+	// as its variables must be resolved before the bundle data is parsed.
+	if info, statErr := os.Stat(bundleFile); statErr == nil {
+		var bundleDir string
+		if info.IsDir() {
+			bundleData, err = readBundleFile(filepath.Join(bundleFile, "bundle.yaml"), vars)
+			bundleDir = ctx.AbsPath(bundleFile)
+		} else {
+			bundleData, err = readBundleArchive(bundleFile, vars)
+		}
+		if err == nil {
+			return bundleData, bundleDir, nil
+		}
+		if isBundleVariableError(err) {
+			return nil, "", errors.Trace(err)
+		}
+	}
 
 	// We may have been given a local bundle archive or exploded directory.
 	bundle, _, pathErr := charmrepo.NewBundleAtPath(bundleFile)
@@ -1326,7 +1512,7 @@ func readLocalBundle(ctx *cmd.Context, bundleFile string) (*charm.BundleData, st
 
 func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error) {
 	bundleFile := c.CharmOrBundle
-	bundleData, bundleDir, err := readLocalBundle(ctx, bundleFile)
+	bundleData, bundleDir, err := readLocalBundle(ctx, bundleFile, c.bundleVariables)
 	if charmrepo.IsInvalidPathError(err) {
 		return nil, errors.Errorf(""+
 			"The charm or bundle %q is ambiguous.\n"+
@@ -1514,7 +1700,10 @@ func (c *DeployCommand) maybeReadCharmstoreBundleFn(apiRoot DeployAPI) func() (d
 				return errors.Trace(err)
 			}
 			ctx.Infof("Located bundle %q", bundleURL)
-			data := bundle.Data()
+			data, err := expandBundle(bundle, c.bundleVariables)
+			if err != nil {
+				return errors.Trace(err)
+			}
 
 			return errors.Trace(c.deployBundle(
 				ctx,
@@ -2088,6 +2088,26 @@ func (f *fakeDeployAPI) AddMachines(machineParams []params.AddMachineParams) ([]
 	return results[0].([]params.AddMachinesResult), jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) DestroyOffers(force bool, offerURLs ...string) error {
+	results := f.MethodCall(f, "DestroyOffers", force, offerURLs)
+	return jujutesting.TypeAssertError(results[0])
+}
+
+func (f *fakeDeployAPI) DestroyRelation(endpoints ...string) error {
+	results := f.MethodCall(f, "DestroyRelation", endpoints)
+	return jujutesting.TypeAssertError(results[0])
+}
+
+func (f *fakeDeployAPI) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	results := f.MethodCall(f, "DestroyUnits", args)
+	return results[0].([]params.DestroyUnitResult), jujutesting.TypeAssertError(results[1])
+}
+
+func (f *fakeDeployAPI) DestroyApplications(args application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	results := f.MethodCall(f, "DestroyApplications", args)
+	return results[0].([]params.DestroyApplicationResult), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) PlanURL() string {
 	return f.planURL
 }
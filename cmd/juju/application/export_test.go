@@ -4,6 +4,7 @@
 package application
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/api"
@@ -36,6 +37,7 @@ func NewUpgradeCharmCommandForTest(
 		NewModelConfigGetter:  newModelConfigGetter,
 		NewResourceLister:     newResourceLister,
 		CharmStoreURLGetter:   charmStoreURLGetter,
+		Clock:                 clock.WallClock,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpen(apiOpen)
@@ -145,3 +147,32 @@ func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolv
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewShowApplicationCommandForTest returns a show-application command
+// using the given API.
+func NewShowApplicationCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showApplicationCommand{newAPIFunc: func() (ApplicationsInfoAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowUnitCommandForTest returns a show-unit command using the
+// given API.
+func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showUnitCommand{newAPIFunc: func() (UnitsInfoAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewBindCommandForTest returns a bind command using the given API.
+func NewBindCommandForTest(api SetBindingsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &bindCommand{newAPIFunc: func() (SetBindingsAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
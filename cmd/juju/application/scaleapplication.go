@@ -43,9 +43,19 @@ Scale a Kubernetes application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+An application may instead be autoscaled by setting the maximum number of
+units in the kubernetes-autoscaling-max-replicas application config. The
+autoscaler then keeps the number of units between its minimum and maximum,
+and the scale of the application follows the number of units it chooses.
+An autoscaled application cannot be scaled with this command; unset
+kubernetes-autoscaling-max-replicas to scale it manually again.
+
 Examples:
 
     juju scale-application mariadb 2
+
+    juju config mariadb kubernetes-autoscaling-max-replicas=5 \
+        kubernetes-autoscaling-target-cpu=70
 `
 
 // Info implements cmd.Command.
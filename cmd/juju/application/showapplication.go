@@ -0,0 +1,256 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showApplicationDoc = `
+The command takes deployed application names as arguments.
+
+The output includes the application's charm, endpoints and the spaces
+they are bound to, the applications it is related to on each endpoint,
+its leader unit, the resources in use and its recent status history.
+
+Examples:
+    juju show-application mysql
+    juju show-application mysql wordpress
+    juju show-application --format json mysql
+
+See also:
+    show-unit
+    status
+`
+
+// NewShowApplicationCommand returns a command that displays detailed
+// information about applications.
+func NewShowApplicationCommand() cmd.Command {
+	s := &showApplicationCommand{}
+	s.newAPIFunc = func() (ApplicationsInfoAPI, error) {
+		root, err := s.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showApplicationCommand displays detailed information about applications.
+type showApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	apps       []string
+	newAPIFunc func() (ApplicationsInfoAPI, error)
+}
+
+// ApplicationsInfoAPI defines the API methods that the show-application
+// command uses.
+type ApplicationsInfoAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ApplicationsInfo([]names.ApplicationTag) ([]params.ApplicationInfoResult, error)
+}
+
+// Info implements Command.Info.
+func (c *showApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-application",
+		Args:    "<application name>",
+		Purpose: "Displays information about an application.",
+		Doc:     showApplicationDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showApplicationCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("an application name must be supplied")
+	}
+	c.apps = args
+	var invalid []string
+	for _, app := range c.apps {
+		if !names.IsValidApplication(app) {
+			invalid = append(invalid, app)
+		}
+	}
+	if len(invalid) > 0 {
+		plural := "s"
+		if len(invalid) == 1 {
+			plural = ""
+		}
+		return errors.NotValidf(`application name%v %v`, plural, strings.Join(invalid, `, `))
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 9 {
+		return errors.New("show-application is not supported by this controller")
+	}
+
+	tags := make([]names.ApplicationTag, len(c.apps))
+	for i, app := range c.apps {
+		tags[i] = names.NewApplicationTag(app)
+	}
+	results, err := client.ApplicationsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	var infos []params.ApplicationResult
+	for _, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{Error: result.Error})
+			continue
+		}
+		infos = append(infos, *result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+
+	output, err := formatApplicationInfos(infos)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// ApplicationInfo defines the serialization behaviour of the
+// application information.
+type ApplicationInfo struct {
+	Charm         string                  `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series        string                  `yaml:"series,omitempty" json:"series,omitempty"`
+	Channel       string                  `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Constraints   string                  `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Principal     bool                    `yaml:"principal" json:"principal"`
+	Exposed       bool                    `yaml:"exposed" json:"exposed"`
+	Remote        bool                    `yaml:"remote" json:"remote"`
+	Life          string                  `yaml:"life,omitempty" json:"life,omitempty"`
+	Leader        string                  `yaml:"leader,omitempty" json:"leader,omitempty"`
+	Endpoints     map[string]EndpointInfo `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	Relations     map[string][]string     `yaml:"relations,omitempty" json:"relations,omitempty"`
+	Resources     map[string]ResourceInfo `yaml:"resources,omitempty" json:"resources,omitempty"`
+	StatusHistory []StatusHistoryEntry    `yaml:"status-history,omitempty" json:"status-history,omitempty"`
+}
+
+// EndpointInfo holds the details of a single application endpoint.
+type EndpointInfo struct {
+	Role      string `yaml:"role" json:"role"`
+	Interface string `yaml:"interface" json:"interface"`
+	Scope     string `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Space     string `yaml:"space,omitempty" json:"space,omitempty"`
+}
+
+// ResourceInfo holds the revision of a resource in use.
+type ResourceInfo struct {
+	Type     string `yaml:"type" json:"type"`
+	Origin   string `yaml:"origin" json:"origin"`
+	Revision int    `yaml:"revision" json:"revision"`
+}
+
+// StatusHistoryEntry holds a single status history entry.
+type StatusHistoryEntry struct {
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+func formatApplicationInfos(all []params.ApplicationResult) (map[string]ApplicationInfo, error) {
+	if len(all) == 0 {
+		return nil, nil
+	}
+	output := make(map[string]ApplicationInfo)
+	for _, one := range all {
+		tag, err := names.ParseApplicationTag(one.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := ApplicationInfo{
+			Charm:       one.Charm,
+			Series:      one.Series,
+			Channel:     one.Channel,
+			Constraints: one.Constraints.String(),
+			Principal:   one.Principal,
+			Exposed:     one.Exposed,
+			Remote:      one.Remote,
+			Life:        one.Life,
+			Leader:      one.Leader,
+			Relations:   one.Relations,
+			Resources:   formatResources(one.Resources),
+		}
+		for _, ep := range one.Endpoints {
+			if info.Endpoints == nil {
+				info.Endpoints = make(map[string]EndpointInfo)
+			}
+			info.Endpoints[ep.Name] = EndpointInfo{
+				Role:      ep.Role,
+				Interface: ep.Interface,
+				Scope:     ep.Scope,
+				Space:     one.EndpointBindings[ep.Name],
+			}
+		}
+		info.StatusHistory = formatStatusHistory(one.StatusHistory)
+		output[tag.Id()] = info
+	}
+	return output, nil
+}
+
+func formatResources(resources []params.ResourceSummary) map[string]ResourceInfo {
+	if len(resources) == 0 {
+		return nil
+	}
+	result := make(map[string]ResourceInfo)
+	for _, r := range resources {
+		result[r.Name] = ResourceInfo{
+			Type:     r.Type,
+			Origin:   r.Origin,
+			Revision: r.Revision,
+		}
+	}
+	return result
+}
+
+func formatStatusHistory(history []params.DetailedStatus) []StatusHistoryEntry {
+	var result []StatusHistoryEntry
+	for _, h := range history {
+		entry := StatusHistoryEntry{
+			Status:  h.Status,
+			Message: h.Info,
+		}
+		if h.Since != nil {
+			entry.Since = common.FormatTime(h.Since, true)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowApplicationSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockApplicationsInfoAPI
+}
+
+var _ = gc.Suite(&ShowApplicationSuite{})
+
+func (s *ShowApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockApplicationsInfoAPI{Stub: &testing.Stub{}, version: 9}
+}
+
+func (s *ShowApplicationSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowApplicationCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowApplicationSuite) TestShowNoArguments(c *gc.C) {
+	_, err := s.runShow(c)
+	c.Assert(err, gc.ErrorMatches, "an application name must be supplied")
+}
+
+func (s *ShowApplicationSuite) TestShowInvalidName(c *gc.C) {
+	_, err := s.runShow(c, "mysql", "~wordpress", "Foo")
+	c.Assert(err, gc.ErrorMatches, "application names ~wordpress, Foo not valid")
+}
+
+func (s *ShowApplicationSuite) TestShowNotSupported(c *gc.C) {
+	s.mockAPI.version = 8
+	_, err := s.runShow(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "show-application is not supported by this controller")
+}
+
+func (s *ShowApplicationSuite) TestShowError(c *gc.C) {
+	s.mockAPI.results = []params.ApplicationInfoResult{
+		{Error: &params.Error{Message: `application "mysql" not found`}},
+	}
+	_, err := s.runShow(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+func (s *ShowApplicationSuite) TestShow(c *gc.C) {
+	since := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.mockAPI.results = []params.ApplicationInfoResult{{
+		Result: &params.ApplicationResult{
+			Tag:       "application-wordpress",
+			Charm:     "cs:wordpress-5",
+			Series:    "bionic",
+			Principal: true,
+			Life:      "alive",
+			Leader:    "wordpress/1",
+			Endpoints: []params.CharmRelation{
+				{Name: "db", Role: "requirer", Interface: "mysql", Scope: "global"},
+			},
+			EndpointBindings: map[string]string{"db": "internal"},
+			Relations:        map[string][]string{"db": {"mysql"}},
+			Resources: []params.ResourceSummary{
+				{Name: "theme", Type: "file", Origin: "store", Revision: 2},
+			},
+			StatusHistory: []params.DetailedStatus{
+				{Status: "active", Info: "ready", Since: &since},
+			},
+		},
+	}}
+	ctx, err := s.runShow(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress:
+  charm: cs:wordpress-5
+  series: bionic
+  principal: true
+  exposed: false
+  remote: false
+  life: alive
+  leader: wordpress/1
+  endpoints:
+    db:
+      role: requirer
+      interface: mysql
+      scope: global
+      space: internal
+  relations:
+    db:
+    - mysql
+  resources:
+    theme:
+      type: file
+      origin: store
+      revision: 2
+  status-history:
+  - status: active
+    message: ready
+    since: 2019-04-01 10:00:00Z
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "ApplicationsInfo", []names.ApplicationTag{names.NewApplicationTag("wordpress")})
+}
+
+type mockApplicationsInfoAPI struct {
+	*testing.Stub
+	version int
+	results []params.ApplicationInfoResult
+}
+
+func (m *mockApplicationsInfoAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockApplicationsInfoAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockApplicationsInfoAPI) ApplicationsInfo(tags []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	m.MethodCall(m, "ApplicationsInfo", tags)
+	return m.results, m.NextErr()
+}
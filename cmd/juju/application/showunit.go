@@ -0,0 +1,267 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showUnitDoc = `
+The command takes deployed unit names as arguments.
+
+The output includes the unit's machine, charm, addresses and opened
+ports, whether it is the leader, its storage and resources, any events
+its hooks have deferred with defer-hook, and its recent workload and
+agent status history.
+
+For each relation the unit is in, the relation settings of the unit
+itself and of the units on the other side of the relation are shown,
+including those of units in other models for cross model relations.
+Use --endpoint to only show the relations of the given endpoint.
+
+Examples:
+    juju show-unit mysql/0
+    juju show-unit mysql/0 wordpress/1
+    juju show-unit --endpoint db wordpress/0
+    juju show-unit --format json mysql/0
+
+See also:
+    show-application
+    status
+`
+
+// NewShowUnitCommand returns a command that displays detailed
+// information about units.
+func NewShowUnitCommand() cmd.Command {
+	s := &showUnitCommand{}
+	s.newAPIFunc = func() (UnitsInfoAPI, error) {
+		root, err := s.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showUnitCommand displays detailed information about units.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	units      []string
+	endpoint   string
+	newAPIFunc func() (UnitsInfoAPI, error)
+}
+
+// UnitsInfoAPI defines the API methods that the show-unit command uses.
+type UnitsInfoAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+// Info implements Command.Info.
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.endpoint, "endpoint", "", "Only show relation data for the specified endpoint")
+}
+
+// Init implements Command.Init.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.units = args
+	var invalid []string
+	for _, unit := range c.units {
+		if !names.IsValidUnit(unit) {
+			invalid = append(invalid, unit)
+		}
+	}
+	if len(invalid) > 0 {
+		plural := "s"
+		if len(invalid) == 1 {
+			plural = ""
+		}
+		return errors.NotValidf(`unit name%v %v`, plural, strings.Join(invalid, `, `))
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 9 {
+		return errors.New("show-unit is not supported by this controller")
+	}
+
+	tags := make([]names.UnitTag, len(c.units))
+	for i, unit := range c.units {
+		tags[i] = names.NewUnitTag(unit)
+	}
+	results, err := client.UnitsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	var infos []params.UnitResult
+	for _, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{Error: result.Error})
+			continue
+		}
+		infos = append(infos, *result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+
+	output, err := c.formatUnitInfos(infos)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// UnitInfo defines the serialization behaviour of the unit information.
+type UnitInfo struct {
+	Machine               string                  `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Charm                 string                  `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Leader                bool                    `yaml:"leader" json:"leader"`
+	Life                  string                  `yaml:"life,omitempty" json:"life,omitempty"`
+	WorkloadVersion       string                  `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	PublicAddress         string                  `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	OpenedPorts           []string                `yaml:"opened-ports,omitempty" json:"opened-ports,omitempty"`
+	RelationInfo          []RelationInfo          `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	Storage               map[string]StorageInfo  `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Resources             map[string]ResourceInfo `yaml:"resources,omitempty" json:"resources,omitempty"`
+	DeferredEvents        map[string]string       `yaml:"deferred-events,omitempty" json:"deferred-events,omitempty"`
+	WorkloadStatusHistory []StatusHistoryEntry    `yaml:"workload-status-history,omitempty" json:"workload-status-history,omitempty"`
+	AgentStatusHistory    []StatusHistoryEntry    `yaml:"agent-status-history,omitempty" json:"agent-status-history,omitempty"`
+}
+
+// RelationInfo holds the relation settings of the units in a relation,
+// as seen from one of the unit's endpoints.
+type RelationInfo struct {
+	RelationId      int                         `yaml:"relation-id" json:"relation-id"`
+	Endpoint        string                      `yaml:"endpoint" json:"endpoint"`
+	CrossModel      bool                        `yaml:"cross-model,omitempty" json:"cross-model,omitempty"`
+	RelatedEndpoint string                      `yaml:"related-endpoint" json:"related-endpoint"`
+	LocalUnit       RelationUnitInfo            `yaml:"local-unit" json:"local-unit"`
+	RelatedUnits    map[string]RelationUnitInfo `yaml:"related-units,omitempty" json:"related-units,omitempty"`
+}
+
+// RelationUnitInfo holds the relation settings of a single unit.
+type RelationUnitInfo struct {
+	InScope bool                   `yaml:"in-scope" json:"in-scope"`
+	Data    map[string]interface{} `yaml:"data" json:"data"`
+}
+
+// StorageInfo holds the details of storage attached to a unit.
+type StorageInfo struct {
+	Location string `yaml:"location,omitempty" json:"location,omitempty"`
+	Life     string `yaml:"life,omitempty" json:"life,omitempty"`
+}
+
+func (c *showUnitCommand) formatUnitInfos(all []params.UnitResult) (map[string]UnitInfo, error) {
+	if len(all) == 0 {
+		return nil, nil
+	}
+	output := make(map[string]UnitInfo)
+	for _, one := range all {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := UnitInfo{
+			Machine:               one.Machine,
+			Charm:                 one.Charm,
+			Leader:                one.Leader,
+			Life:                  one.Life,
+			WorkloadVersion:       one.WorkloadVersion,
+			PublicAddress:         one.PublicAddress,
+			OpenedPorts:           one.OpenedPorts,
+			Resources:             formatResources(one.Resources),
+			WorkloadStatusHistory: formatStatusHistory(one.WorkloadStatusHistory),
+			AgentStatusHistory:    formatStatusHistory(one.AgentStatusHistory),
+		}
+		for _, rd := range one.RelationData {
+			if c.endpoint != "" && rd.Endpoint != c.endpoint {
+				continue
+			}
+			relInfo := RelationInfo{
+				RelationId:      rd.RelationId,
+				Endpoint:        rd.Endpoint,
+				CrossModel:      rd.CrossModel,
+				RelatedEndpoint: rd.RelatedEndpoint,
+			}
+			for unitName, data := range rd.UnitRelationData {
+				unitInfo := RelationUnitInfo{
+					InScope: data.InScope,
+					Data:    data.UnitData,
+				}
+				if unitName == tag.Id() {
+					relInfo.LocalUnit = unitInfo
+					continue
+				}
+				if relInfo.RelatedUnits == nil {
+					relInfo.RelatedUnits = make(map[string]RelationUnitInfo)
+				}
+				relInfo.RelatedUnits[unitName] = unitInfo
+			}
+			info.RelationInfo = append(info.RelationInfo, relInfo)
+		}
+		for _, storage := range one.Storage {
+			storageTag, err := names.ParseStorageTag(storage.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if info.Storage == nil {
+				info.Storage = make(map[string]StorageInfo)
+			}
+			info.Storage[storageTag.Id()] = StorageInfo{
+				Location: storage.Location,
+				Life:     string(storage.Life),
+			}
+		}
+		for _, event := range one.DeferredEvents {
+			if info.DeferredEvents == nil {
+				info.DeferredEvents = make(map[string]string)
+			}
+			at := event.Time
+			info.DeferredEvents[event.Name] = common.FormatTime(&at, true)
+		}
+		output[tag.Id()] = info
+	}
+	return output, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowUnitSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockUnitsInfoAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockUnitsInfoAPI{Stub: &testing.Stub{}, version: 9}
+	s.mockAPI.results = []params.UnitInfoResult{{
+		Result: &params.UnitResult{
+			Tag:         "unit-wordpress-0",
+			Charm:       "cs:wordpress-5",
+			Machine:     "0",
+			Leader:      true,
+			Life:        "alive",
+			OpenedPorts: []string{"80/tcp"},
+			RelationData: []params.EndpointRelationData{{
+				RelationId:      2,
+				Endpoint:        "db",
+				RelatedEndpoint: "server",
+				UnitRelationData: map[string]params.RelationData{
+					"wordpress/0": {InScope: true, UnitData: map[string]interface{}{"url": "http://wp"}},
+					"mysql/0":     {InScope: true, UnitData: map[string]interface{}{"host": "10.0.0.1"}},
+				},
+			}, {
+				RelationId:      3,
+				Endpoint:        "cache",
+				RelatedEndpoint: "cache",
+				UnitRelationData: map[string]params.RelationData{
+					"wordpress/0": {InScope: true, UnitData: map[string]interface{}{}},
+				},
+			}},
+			Storage: []params.StorageAttachmentDetails{{
+				StorageTag: "storage-uploads-0",
+				Location:   "/srv/uploads",
+				Life:       "alive",
+			}},
+			DeferredEvents: []params.DeferredEvent{{
+				Name: "check-again",
+				Time: time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC),
+			}},
+		},
+	}}
+}
+
+func (s *ShowUnitSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowUnitSuite) TestShowNoArguments(c *gc.C) {
+	_, err := s.runShow(c)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+}
+
+func (s *ShowUnitSuite) TestShowInvalidName(c *gc.C) {
+	_, err := s.runShow(c, "wordpress")
+	c.Assert(err, gc.ErrorMatches, "unit name wordpress not valid")
+}
+
+func (s *ShowUnitSuite) TestShowNotSupported(c *gc.C) {
+	s.mockAPI.version = 8
+	_, err := s.runShow(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "show-unit is not supported by this controller")
+}
+
+func (s *ShowUnitSuite) TestShow(c *gc.C) {
+	ctx, err := s.runShow(c, "wordpress/0", "--endpoint", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress/0:
+  machine: "0"
+  charm: cs:wordpress-5
+  leader: true
+  life: alive
+  opened-ports:
+  - 80/tcp
+  relation-info:
+  - relation-id: 2
+    endpoint: db
+    related-endpoint: server
+    local-unit:
+      in-scope: true
+      data:
+        url: http://wp
+    related-units:
+      mysql/0:
+        in-scope: true
+        data:
+          host: 10.0.0.1
+  storage:
+    uploads/0:
+      location: /srv/uploads
+      life: alive
+  deferred-events:
+    check-again: 2019-06-01 10:00:00Z
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "UnitsInfo", []names.UnitTag{names.NewUnitTag("wordpress/0")})
+}
+
+type mockUnitsInfoAPI struct {
+	*testing.Stub
+	version int
+	results []params.UnitInfoResult
+}
+
+func (m *mockUnitsInfoAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockUnitsInfoAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockUnitsInfoAPI) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	m.MethodCall(m, "UnitsInfo", tags)
+	return m.results, m.NextErr()
+}
@@ -6,7 +6,9 @@ package application
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -67,6 +69,7 @@ func NewUpgradeCharmCommand() cmd.Command {
 			return resclient, nil
 		},
 		CharmStoreURLGetter: getCharmStoreAPIURL,
+		Clock:               clock.WallClock,
 	}
 	return modelcmd.Wrap(cmd)
 }
@@ -76,6 +79,7 @@ func NewUpgradeCharmCommand() cmd.Command {
 type CharmAPIClient interface {
 	CharmUpgradeClient
 	LXDProfileUpgradeAPI
+	RolloutClient
 }
 
 // CharmUpgradeClient defines a subset of the application facade, as required
@@ -87,6 +91,18 @@ type CharmUpgradeClient interface {
 	SetCharmProfile(string, charmstore.CharmID) error
 }
 
+// RolloutClient defines a subset of the application facade, as required
+// by the upgrade-charm command to follow the rollout of Kubernetes
+// applications.
+type RolloutClient interface {
+	RolloutStatus(string) (*params.RolloutStatus, error)
+	AbortRollout(string) error
+}
+
+// rolloutPollInterval is how often the progress of a rollout is
+// checked while waiting for it to complete.
+var rolloutPollInterval = 5 * time.Second
+
 // CharmClient defines a subset of the charms facade, as required
 // by the upgrade-charm command.
 type CharmClient interface {
@@ -120,6 +136,7 @@ type upgradeCharmCommand struct {
 	NewModelConfigGetter  func(base.APICallCloser) ModelConfigGetter
 	NewResourceLister     func(base.APICallCloser) (ResourceLister, error)
 	CharmStoreURLGetter   func(base.APICallCloser) (string, error)
+	Clock                 clock.Clock
 
 	ApplicationName string
 	// Force should be ubiquitous and we should eventually deprecate both
@@ -146,6 +163,16 @@ type upgradeCharmCommand struct {
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
 
+	// WaitRollout is how long to wait for the pods of a Kubernetes
+	// application to be replaced after the upgrade. Zero means the
+	// command returns without waiting.
+	WaitRollout time.Duration
+
+	// AbortRollout returns the pods of a Kubernetes application to
+	// their previous pod spec if the rollout fails or times out. On
+	// its own, it aborts the current rollout without upgrading.
+	AbortRollout bool
+
 	catacomb catacomb.Catacomb
 	plan     catacomb.Plan
 }
@@ -225,6 +252,21 @@ behavior.
 --force flag for LXD Profiles is not generally recommended when upgrading an 
 application; overriding profiles on the container may cause unexpected 
 behavior. 
+
+For Kubernetes applications, the --wait-rollout flag waits up to the given
+duration for the application's pods to be replaced once the upgraded charm
+sets its new pod spec, and reports an error if the rollout fails or does not
+complete in time. Adding --abort-rollout returns the pods to their previous
+pod spec when that happens; the aborted pod spec is not rolled out again
+until the charm sets a different one. Only stateless applications keep their
+previous pod spec to return to.
+
+  juju upgrade-charm mariadb-k8s --wait-rollout 10m --abort-rollout
+
+Used on its own, --abort-rollout aborts the application's current rollout
+without upgrading its charm.
+
+  juju upgrade-charm mariadb-k8s --abort-rollout
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -248,6 +290,8 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.DurationVar(&c.WaitRollout, "wait-rollout", 0, "Wait for the pods of a Kubernetes application to be replaced")
+	f.BoolVar(&c.AbortRollout, "abort-rollout", false, "Return the pods of a Kubernetes application to their previous pod spec")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -271,9 +315,21 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.WaitRollout < 0 {
+		return errors.Errorf("--wait-rollout must not be negative")
+	}
+	if c.abortOnly() && (c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1) {
+		return errors.Errorf("--abort-rollout without --wait-rollout does not upgrade the charm")
+	}
 	return nil
 }
 
+// abortOnly returns whether the command aborts the current rollout
+// of the application rather than upgrading its charm.
+func (c *upgradeCharmCommand) abortOnly() bool {
+	return c.AbortRollout && c.WaitRollout == 0
+}
+
 // Run connects to the specified environment and starts the charm
 // upgrade process.
 func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
@@ -300,6 +356,14 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
+	if c.abortOnly() {
+		if err := charmUpgradeClient.AbortRollout(c.ApplicationName); err != nil {
+			return block.ProcessBlockedError(errors.Annotatef(err, "aborting rollout of %q", c.ApplicationName), block.BlockChange)
+		}
+		ctx.Infof("Aborted the rollout of %q.", c.ApplicationName)
+		return nil
+	}
+
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -394,7 +458,72 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
+	// Record the generation of the application's pods before the
+	// upgrade, so that only the rollout of the new pod spec counts.
+	var generation int64
+	if c.WaitRollout > 0 {
+		rollout, err := charmUpgradeClient.RolloutStatus(c.ApplicationName)
+		if err == nil {
+			generation = rollout.Generation
+		} else if !params.IsCodeNotFound(err) {
+			return errors.Annotatef(err, "getting rollout status of %q", c.ApplicationName)
+		}
+	}
+	if err := charmUpgradeClient.SetCharm(cfg); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.WaitRollout == 0 {
+		return nil
+	}
+	return errors.Trace(c.waitForRollout(ctx, charmUpgradeClient, generation))
+}
+
+// waitForRollout waits for the pods of the application to be replaced
+// with a generation newer than the given one, once its charm was
+// upgraded and it set a new pod spec, reporting progress as it goes.
+// If the rollout fails or times out and --abort-rollout was given,
+// the pods are returned to their previous pod spec.
+func (c *upgradeCharmCommand) waitForRollout(ctx *cmd.Context, client RolloutClient, generation int64) error {
+	timeout := c.Clock.After(c.WaitRollout)
+	var lastMessage string
+	for {
+		rollout, err := client.RolloutStatus(c.ApplicationName)
+		if params.IsCodeNotFound(err) {
+			rollout = &params.RolloutStatus{}
+		} else if err != nil {
+			return errors.Annotatef(err, "getting rollout status of %q", c.ApplicationName)
+		}
+		if rollout.Generation <= generation {
+			// The charm has not set its new pod spec yet.
+			rollout = &params.RolloutStatus{Message: "waiting for the new pod spec"}
+		} else if rollout.Complete {
+			ctx.Infof("Rollout of %q complete.", c.ApplicationName)
+			return nil
+		}
+		if rollout.Failed {
+			return c.abortRollout(ctx, client, errors.Errorf("rollout of %q failed: %s", c.ApplicationName, rollout.Message))
+		}
+		if rollout.Message != lastMessage {
+			ctx.Infof("Rolling out %q: %s", c.ApplicationName, rollout.Message)
+			lastMessage = rollout.Message
+		}
+		select {
+		case <-timeout:
+			return c.abortRollout(ctx, client, errors.Errorf("timed out waiting for rollout of %q", c.ApplicationName))
+		case <-c.Clock.After(rolloutPollInterval):
+		}
+	}
+}
+
+func (c *upgradeCharmCommand) abortRollout(ctx *cmd.Context, client RolloutClient, rolloutErr error) error {
+	if !c.AbortRollout {
+		return rolloutErr
+	}
+	if err := client.AbortRollout(c.ApplicationName); err != nil {
+		return errors.Annotatef(err, "%v; aborting rollout", rolloutErr)
+	}
+	ctx.Infof("Aborted the rollout of %q.", c.ApplicationName)
+	return rolloutErr
 }
 
 func (c *upgradeCharmCommand) handleNotifications(ctx *cmd.Context, lxdProfileUpgradeClient LXDProfileUpgradeAPI) error {
@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -236,6 +237,78 @@ func (s *UpgradeCharmSuite) TestConfigSettings(c *gc.C) {
 	})
 }
 
+func (s *UpgradeCharmSuite) TestWaitRollout(c *gc.C) {
+	s.charmAPIClient.rollouts = []*params.RolloutStatus{
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 2, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo", "--wait-rollout", "1m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmProfile", "WatchLXDProfileUpgradeNotifications", "GetLXDProfileUpgradeMessages", "RolloutStatus", "SetCharm", "RolloutStatus")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Rollout of "foo" complete.`)
+}
+
+func (s *UpgradeCharmSuite) TestWaitRolloutOfNewPodSpec(c *gc.C) {
+	s.PatchValue(&rolloutPollInterval, time.Millisecond)
+	// The rollout from before the upgrade is complete,
+	// but it is the rollout of the new pod spec which counts.
+	s.charmAPIClient.rollouts = []*params.RolloutStatus{
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 2, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo", "--wait-rollout", "1m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmProfile", "WatchLXDProfileUpgradeNotifications", "GetLXDProfileUpgradeMessages", "RolloutStatus", "SetCharm", "RolloutStatus", "RolloutStatus")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Rolling out "foo": waiting for the new pod spec`)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Rollout of "foo" complete.`)
+}
+
+func (s *UpgradeCharmSuite) TestWaitRolloutFailed(c *gc.C) {
+	s.charmAPIClient.rollouts = []*params.RolloutStatus{
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 2, Desired: 2, Updated: 1, Ready: 1, Failed: true, Message: "progress deadline exceeded"},
+	}
+	_, err := s.runUpgradeCharm(c, "foo", "--wait-rollout", "1m")
+	c.Assert(err, gc.ErrorMatches, `rollout of "foo" failed: progress deadline exceeded`)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmProfile", "WatchLXDProfileUpgradeNotifications", "GetLXDProfileUpgradeMessages", "RolloutStatus", "SetCharm", "RolloutStatus")
+}
+
+func (s *UpgradeCharmSuite) TestWaitRolloutFailedAbort(c *gc.C) {
+	s.charmAPIClient.rollouts = []*params.RolloutStatus{
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 2, Desired: 2, Updated: 1, Ready: 1, Failed: true, Message: "progress deadline exceeded"},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo", "--wait-rollout", "1m", "--abort-rollout")
+	c.Assert(err, gc.ErrorMatches, `rollout of "foo" failed: progress deadline exceeded`)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmProfile", "WatchLXDProfileUpgradeNotifications", "GetLXDProfileUpgradeMessages", "RolloutStatus", "SetCharm", "RolloutStatus", "AbortRollout")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Aborted the rollout of "foo".`)
+}
+
+func (s *UpgradeCharmSuite) TestWaitRolloutTimeoutAbort(c *gc.C) {
+	s.charmAPIClient.rollouts = []*params.RolloutStatus{
+		{Generation: 1, Desired: 2, Updated: 2, Ready: 2, Complete: true},
+		{Generation: 2, Desired: 2, Updated: 1, Ready: 2, Message: "1 of 2 pods updated"},
+	}
+	ctx, err := s.runUpgradeCharm(c, "foo", "--wait-rollout", "1ms", "--abort-rollout")
+	c.Assert(err, gc.ErrorMatches, `timed out waiting for rollout of "foo"`)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharmProfile", "WatchLXDProfileUpgradeNotifications", "GetLXDProfileUpgradeMessages", "RolloutStatus", "SetCharm", "RolloutStatus", "AbortRollout")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Rolling out "foo": 1 of 2 pods updated`)
+}
+
+func (s *UpgradeCharmSuite) TestAbortRolloutOnly(c *gc.C) {
+	ctx, err := s.runUpgradeCharm(c, "foo", "--abort-rollout")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "AbortRollout")
+	s.charmAPIClient.CheckCall(c, 0, "AbortRollout", "foo")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Aborted the rollout of "foo".`)
+}
+
+func (s *UpgradeCharmSuite) TestAbortRolloutOnlyWithRevision(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--abort-rollout", "--revision", "2")
+	c.Assert(err, gc.ErrorMatches, "--abort-rollout without --wait-rollout does not upgrade the charm")
+}
+
 func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 	tempdir := c.MkDir()
 	configFile := filepath.Join(tempdir, "config.yaml")
@@ -853,6 +926,7 @@ type mockCharmAPIClient struct {
 	CharmAPIClient
 	testing.Stub
 	charmURL *charm.URL
+	rollouts []*params.RolloutStatus
 }
 
 func (m *mockCharmAPIClient) GetCharmURL(applicationName string) (*charm.URL, error) {
@@ -889,6 +963,23 @@ func (m *mockCharmAPIClient) GetLXDProfileUpgradeMessages(appName string, watchI
 	return make([]application.LXDProfileUpgradeMessage, 0), m.NextErr()
 }
 
+func (m *mockCharmAPIClient) RolloutStatus(appName string) (*params.RolloutStatus, error) {
+	m.MethodCall(m, "RolloutStatus", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	rollout := m.rollouts[0]
+	if len(m.rollouts) > 1 {
+		m.rollouts = m.rollouts[1:]
+	}
+	return rollout, nil
+}
+
+func (m *mockCharmAPIClient) AbortRollout(appName string) error {
+	m.MethodCall(m, "AbortRollout", appName)
+	return m.NextErr()
+}
+
 type mockNotifyWatcher struct {
 	watcher.NotifyWatcher
 	testing.Stub
@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network/ssh"
+)
+
+func newDebugCodeCommand(hostChecker ssh.ReachableChecker) cmd.Command {
+	c := new(debugCodeCommand)
+	c.getActionAPI = c.newActionsAPI
+	c.setHostChecker(hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+// debugCodeCommand is like debug-hooks, but runs matching hooks and
+// actions automatically, signalling the charm to start its debugger.
+type debugCodeCommand struct {
+	debugHooksCommand
+	at string
+}
+
+const debugCodeDoc = `
+Run hooks or actions on an application unit in a tmux session, with the
+JUJU_DEBUG_AT environment variable set so that charms can break into
+their language debugger.
+
+Unlike debug-hooks, hooks and actions are run automatically in a new
+tmux window as they are triggered; the window closes when the hook or
+action completes. By default every hook and action is debugged; --at
+takes a comma separated list of the hooks and actions to stop at, and
+its value is passed to the charm in JUJU_DEBUG_AT.
+
+Examples:
+
+    juju debug-code mysql/0
+    juju debug-code --at install,config-changed mysql/0
+
+See "juju help ssh" for information about SSH related options
+accepted by the debug-code command.
+
+See also:
+    debug-hooks
+`
+
+// Info implements Command.Info.
+func (c *debugCodeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "debug-code",
+		Args:    "<unit name>",
+		Purpose: "Launch a tmux session to debug hooks and/or actions with the charm's debugger.",
+		Doc:     debugCodeDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.debugHooksCommand.SetFlags(f)
+	f.StringVar(&c.at, "at", "all", "Comma separated hooks and/or actions to stop at, or \"all\"")
+}
+
+// Init implements Command.Init.
+func (c *debugCodeCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("no unit name specified")
+	}
+	c.Target = args[0]
+	if !names.IsValidUnit(c.Target) {
+		return errors.Errorf("%q is not a valid unit name", c.Target)
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+
+	c.hooks = nil
+	var at []string
+	for _, name := range strings.Split(c.at, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "all" || name == "*" {
+			c.hooks = nil
+			at = []string{"all"}
+			break
+		}
+		c.hooks = append(c.hooks, name)
+		at = append(at, name)
+	}
+	if len(at) == 0 {
+		return errors.New("--at requires at least one hook or action name, or \"all\"")
+	}
+	c.debugAt = strings.Join(at, ",")
+	return nil
+}
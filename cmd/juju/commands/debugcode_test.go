@@ -0,0 +1,80 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"regexp"
+	"runtime"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&DebugCodeSuite{})
+
+type DebugCodeSuite struct {
+	SSHCommonSuite
+}
+
+func (s *DebugCodeSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args    []string
+		hooks   []string
+		debugAt string
+		err     string
+	}{{
+		args:    []string{"mysql/0"},
+		debugAt: "all",
+	}, {
+		args:    []string{"--at", "install, config-changed", "mysql/0"},
+		hooks:   []string{"install", "config-changed"},
+		debugAt: "install,config-changed",
+	}, {
+		args:    []string{"--at", "install,*", "mysql/0"},
+		debugAt: "all",
+	}, {
+		args: []string{"--at", ",", "mysql/0"},
+		err:  `--at requires at least one hook or action name, or "all"`,
+	}, {
+		args: []string{"mysql/0", "install"},
+		err:  `unrecognized args: \["install"\]`,
+	}, {
+		args: []string{"mysql"},
+		err:  `"mysql" is not a valid unit name`,
+	}, {
+		err: "no unit name specified",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		debugCode := &debugCodeCommand{}
+		err := cmdtesting.InitCommand(debugCode, t.args)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(debugCode.hooks, jc.DeepEquals, t.hooks)
+		c.Check(debugCode.debugAt, gc.Equals, t.debugAt)
+	}
+}
+
+func (s *DebugCodeSuite) TestDebugCodeCommand(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+	s.setupModel(c)
+	s.setHostChecker(validAddresses("0.private", "0.public", "0.1.2.3"))
+
+	ctx, err := cmdtesting.RunCommand(c, newDebugCodeCommand(s.hostChecker), "--at", "install", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	expected := &argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		argsMatch:       `ubuntu@0\.(private|public|1\.2\.3) sudo /bin/bash .+`,
+	}
+	expected.check(c, cmdtesting.Stdout(ctx))
+
+	_, err = cmdtesting.RunCommand(c, newDebugCodeCommand(s.hostChecker), "--at", "invalid-hook", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta(`unit "mysql/0" contains neither hook nor action "invalid-hook"`)+".*")
+}
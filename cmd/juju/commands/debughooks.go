@@ -33,6 +33,10 @@ type debugHooksCommand struct {
 	sshCommand
 	hooks []string
 
+	// debugAt is passed to hooks in JUJU_DEBUG_AT by debug-code,
+	// which runs hooks automatically rather than interactively.
+	debugAt string
+
 	getActionAPI func() (ActionsAPI, error)
 }
 
@@ -189,7 +193,7 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks, c.debugAt)))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
@@ -0,0 +1,214 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+const debugReplayDoc = `
+Replay a recorded hook against a local copy of its charm.
+
+Units record the hooks they run when their application's "hook-recording"
+setting is "failed" or "all". Each recording holds the hook's environment
+and every hook tool call it made, and is stored in the unit agent's
+hook-recordings directory, for example:
+
+    /var/lib/juju/agents/unit-mysql-0/hook-recordings
+
+Recordings can be copied from the unit with juju scp.
+
+The hook is run from the given charm directory with its recorded
+environment, and each hook tool call it makes is answered with the
+recorded response, so a charm can be changed and the hook replayed
+without a model. A hook tool call that does not match the recording
+fails. Hook tools are provided by jujud, which is found next to juju, on
+the PATH, or given with --jujud.
+
+Examples:
+
+    juju scp mysql/0:/var/lib/juju/agents/unit-mysql-0/hook-recordings/<recording> .
+    juju debug-replay --charm-dir ./mysql <recording>
+
+See also:
+    debug-hooks
+    config
+`
+
+func newDebugReplayCommand() cmd.Command {
+	return &debugReplayCommand{}
+}
+
+// debugReplayCommand runs a recorded hook against a local charm.
+type debugReplayCommand struct {
+	cmd.CommandBase
+	recording string
+	charmDir  string
+	jujud     string
+}
+
+// Info implements Command.Info.
+func (c *debugReplayCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "debug-replay",
+		Args:    "<recording>",
+		Purpose: "Replay a recorded hook against a local charm.",
+		Doc:     debugReplayDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *debugReplayCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.charmDir, "charm-dir", ".", "Directory containing the charm to run")
+	f.StringVar(&c.jujud, "jujud", "", "Path to the jujud binary providing hook tools")
+}
+
+// Init implements Command.Init.
+func (c *debugReplayCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no recording specified")
+	}
+	c.recording = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *debugReplayCommand) Run(ctx *cmd.Context) error {
+	if runtime.GOOS == "windows" {
+		return errors.NotSupportedf("debug-replay on windows")
+	}
+	rec, err := replay.ReadFile(ctx.AbsPath(c.recording))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmDir, err := filepath.Abs(ctx.AbsPath(c.charmDir))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hookPath, err := findReplayHook(charmDir, rec.Hook)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	jujud, err := c.findJujud(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "juju-debug-replay")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(tempDir)
+	toolsDir := filepath.Join(tempDir, "tools")
+	if err := os.Mkdir(toolsDir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range jujuc.CommandNames() {
+		if err := os.Symlink(jujud, filepath.Join(toolsDir, name)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	socketPath := filepath.Join(tempDir, "jujuc.socket")
+	player := replay.NewPlayer(rec)
+	srv, err := jujuc.NewServerFunc(player.Main, socketPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	go srv.Run()
+	defer srv.Close()
+
+	hook := exec.Command(hookPath)
+	hook.Dir = charmDir
+	hook.Env = replayEnv(rec.Env, charmDir, toolsDir, socketPath)
+	hook.Stdout = ctx.Stdout
+	hook.Stderr = ctx.Stderr
+	hookErr := hook.Run()
+
+	if remaining := player.Remaining(); len(remaining) > 0 {
+		ctx.Warningf("%d recorded hook tool calls were not made, starting with %q", len(remaining), remaining[0])
+	}
+	if rec.Error != "" {
+		ctx.Infof("recorded hook failed with: %s", rec.Error)
+	}
+	if hookErr != nil {
+		return errors.Annotatef(hookErr, "replaying %q", rec.Hook)
+	}
+	return nil
+}
+
+// findJujud returns the path of the jujud binary to use for hook tools.
+func (c *debugReplayCommand) findJujud(ctx *cmd.Context) (string, error) {
+	if c.jujud != "" {
+		path := ctx.AbsPath(c.jujud)
+		if _, err := os.Stat(path); err != nil {
+			return "", errors.Annotate(err, "cannot use jujud")
+		}
+		return path, nil
+	}
+	if juju, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(juju), "jujud")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("jujud")
+	if err != nil {
+		return "", errors.New("cannot find jujud to provide hook tools; specify it with --jujud")
+	}
+	return filepath.Abs(path)
+}
+
+// findReplayHook returns the path of the executable that runs hook in
+// charmDir, preferring the charm's dispatch script.
+func findReplayHook(charmDir, hook string) (string, error) {
+	dispatch := filepath.Join(charmDir, "dispatch")
+	if _, err := os.Stat(dispatch); err == nil {
+		return dispatch, nil
+	}
+	path := filepath.Join(charmDir, filepath.FromSlash(hook))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", errors.NotFoundf("hook %q in %q", hook, charmDir)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return path, nil
+}
+
+// replayEnv returns the recorded hook environment, pointing the charm
+// directory, hook tools and agent socket at the local replay.
+func replayEnv(recorded []string, charmDir, toolsDir, socketPath string) []string {
+	overrides := map[string]string{
+		"CHARM_DIR":         charmDir,
+		"JUJU_CHARM_DIR":    charmDir,
+		"JUJU_AGENT_SOCKET": socketPath,
+		"PATH":              toolsDir + string(os.PathListSeparator) + os.Getenv("PATH"),
+	}
+	var env []string
+	for _, kv := range recorded {
+		name := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := overrides[name]; ok {
+			continue
+		}
+		env = append(env, kv)
+	}
+	for name, value := range overrides {
+		env = append(env, fmt.Sprintf("%s=%s", name, value))
+	}
+	return env
+}
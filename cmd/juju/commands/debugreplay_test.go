@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type DebugReplaySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&DebugReplaySuite{})
+
+func (s *DebugReplaySuite) TestInitErrors(c *gc.C) {
+	err := cmdtesting.InitCommand(newDebugReplayCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "no recording specified")
+	err = cmdtesting.InitCommand(newDebugReplayCommand(), []string{"a", "b"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *DebugReplaySuite) TestMissingRecording(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debug-replay is not supported on windows")
+	}
+	_, err := cmdtesting.RunCommand(c, newDebugReplayCommand(), filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
+
+func (s *DebugReplaySuite) TestReplay(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debug-replay is not supported on windows")
+	}
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "dispatch"), []byte("#!/bin/sh\necho replayed $JUJU_HOOK_NAME\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	jujud := filepath.Join(c.MkDir(), "jujud")
+	err = ioutil.WriteFile(jujud, nil, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec := &replay.Recording{
+		Unit:  "mysql/0",
+		Hook:  "hooks/install",
+		Env:   []string{"JUJU_HOOK_NAME=install", "CHARM_DIR=/var/lib/juju/agents/unit-mysql-0/charm"},
+		Calls: []replay.Call{{Command: "status-set", Args: []string{"active"}}},
+	}
+	path, err := rec.WriteFile(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, newDebugReplayCommand(), "--charm-dir", charmDir, "--jujud", jujud, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "replayed install\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `1 recorded hook tool calls were not made, starting with "status-set active"`)
+}
+
+func (s *DebugReplaySuite) TestReplayMissingHook(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debug-replay is not supported on windows")
+	}
+	rec := &replay.Recording{Unit: "mysql/0", Hook: "hooks/install"}
+	path, err := rec.WriteFile(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, newDebugReplayCommand(), "--charm-dir", c.MkDir(), path)
+	c.Assert(err, gc.ErrorMatches, `hook "hooks/install" in ".*" not found`)
+}
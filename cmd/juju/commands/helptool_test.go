@@ -39,6 +39,7 @@ Currently available charm hook tools are:
     close-port               ensure a port or range is always closed
     config-get               print application configuration
     credential-get           access cloud credentials
+    defer-hook               deliver a custom event to a later hook
     goal-state               print the status of the charm's peers and related units
     is-leader                print application leadership status
     juju-log                 write a message to the juju log
@@ -53,6 +54,9 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               save a secret owned by the unit's application
+    secret-get               print the value of a secret
+    secret-grant             grant access to a secret on a relation
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -79,6 +83,7 @@ var expectedCommands = []string{
 	"close-port",
 	"config-get",
 	"credential-get",
+	"defer-hook",
 	"goal-state",
 	"is-leader",
 	"juju-log",
@@ -97,6 +102,9 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
 	"status-get",
 	"status-set",
 	"storage-add",
@@ -409,6 +409,7 @@ var commandNames = []string{
 	"attach-storage",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budget",
 	"cached-images",
@@ -426,8 +427,10 @@ var commandNames = []string{
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
+	"debug-code",
 	"debug-hooks",
 	"debug-log",
+	"debug-replay",
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
@@ -474,6 +477,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -525,6 +529,7 @@ var commandNames = []string{
 	"run-action",
 	"scale-application",
 	"scp",
+	"secrets",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -537,6 +542,7 @@ var commandNames = []string{
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
+	"show-application",
 	"show-backup",
 	"show-cloud",
 	"show-controller",
@@ -548,6 +554,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -0,0 +1,151 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var listSecretsHelpSummary = `
+Lists the secrets in a model.`[1:]
+
+var listSecretsHelpDetails = `
+Lists the secrets saved by the charms in a model, with the relations
+each secret has been granted on. Secret values are never shown.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml`
+
+// NewListSecretsCommand returns a command to list the secrets in a model.
+func NewListSecretsCommand() cmd.Command {
+	c := &listSecretsCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	isoTime bool
+
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+// ListSecretsAPI defines the API methods that the secrets command uses.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.SecretDetails, error)
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: listSecretsHelpSummary,
+		Doc:     listSecretsHelpDetails,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	result, err := client.ListSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	details := make([]secretDetails, len(result))
+	for i, r := range result {
+		details[i] = secretDetails{
+			ID:          r.ID,
+			Owner:       r.Owner,
+			Description: r.Description,
+			Revision:    r.Revision,
+			Grants:      r.Grants,
+			Created:     common.FormatTime(&r.CreateTime, c.isoTime),
+			Updated:     common.FormatTime(&r.UpdateTime, c.isoTime),
+		}
+		if r.RotateInterval > 0 {
+			details[i].RotateInterval = r.RotateInterval.String()
+		}
+		if r.NextRotateTime != nil {
+			details[i].NextRotation = common.FormatTime(r.NextRotateTime, c.isoTime)
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+type secretDetails struct {
+	ID             string   `yaml:"id" json:"id"`
+	Owner          string   `yaml:"owner" json:"owner"`
+	Description    string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision       int      `yaml:"revision" json:"revision"`
+	RotateInterval string   `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotation   string   `yaml:"next-rotation,omitempty" json:"next-rotation,omitempty"`
+	Grants         []string `yaml:"grants,omitempty" json:"grants,omitempty"`
+	Created        string   `yaml:"created" json:"created"`
+	Updated        string   `yaml:"updated" json:"updated"`
+}
+
+func formatTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.([]secretDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "Owner", "Revision", "Rotate", "Next rotation", "Grants", "Updated")
+	for _, d := range details {
+		rotate, next := "-", "-"
+		if d.RotateInterval != "" {
+			rotate = d.RotateInterval
+		}
+		if d.NextRotation != "" {
+			next = d.NextRotation
+		}
+		w.Println(d.ID, d.Owner, d.Revision, rotate, next, len(d.Grants), d.Updated)
+	}
+	return tw.Flush()
+}
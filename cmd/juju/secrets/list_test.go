@@ -0,0 +1,120 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockListAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	created := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	next := created.Add(720 * time.Hour)
+	s.mockAPI = &mockListAPI{
+		secrets: []params.SecretDetails{{
+			ID:             "mysql/root-password",
+			Owner:          "mysql",
+			Name:           "root-password",
+			Description:    "the root password",
+			Revision:       2,
+			RotateInterval: 720 * time.Hour,
+			NextRotateTime: &next,
+			Grants:         []string{"wordpress:db mysql:server"},
+			CreateTime:     created,
+			UpdateTime:     created.Add(time.Hour),
+		}, {
+			ID:         "wordpress/api-key",
+			Owner:      "wordpress",
+			Name:       "api-key",
+			Revision:   1,
+			CreateTime: created,
+			UpdateTime: created,
+		}},
+	}
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *ListSuite) TestListNone(c *gc.C) {
+	s.mockAPI.secrets = nil
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                   Owner      Revision  Rotate    Next rotation         Grants  Updated
+mysql/root-password  mysql      2         720h0m0s  2019-07-01 10:00:00Z  1       2019-06-01 11:00:00Z
+wordpress/api-key    wordpress  1         -         -                     0       2019-06-01 10:00:00Z
+
+`[1:])
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "--format", "yaml", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: mysql/root-password
+  owner: mysql
+  description: the root password
+  revision: 2
+  rotate-interval: 720h0m0s
+  next-rotation: 2019-07-01 10:00:00Z
+  grants:
+  - wordpress:db mysql:server
+  created: 2019-06-01 10:00:00Z
+  updated: 2019-06-01 11:00:00Z
+- id: wordpress/api-key
+  owner: wordpress
+  revision: 1
+  created: 2019-06-01 10:00:00Z
+  updated: 2019-06-01 10:00:00Z
+`[1:])
+}
+
+func (s *ListSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.mockAPI), args...)
+}
+
+type mockListAPI struct {
+	secrets []params.SecretDetails
+	err     error
+}
+
+func (s *mockListAPI) Close() error {
+	return nil
+}
+
+func (s *mockListAPI) ListSecrets() ([]params.SecretDetails, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.secrets, nil
+}
@@ -783,12 +783,17 @@ func (a *MachineAgent) openStateForUpgrade() (*state.StatePool, error) {
 	}
 	defer session.Close()
 
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	pool, err := state.OpenStatePool(state.OpenParams{
 		Clock:              clock.WallClock,
 		ControllerTag:      agentConfig.Controller(),
 		ControllerModelTag: agentConfig.Model(),
 		MongoSession:       session,
 		NewPolicy:          stateenvirons.GetNewPolicyFunc(),
+		SecretsKey:         secretsKey,
 		// state.InitDatabase is idempotent and needs to be called just
 		// prior to performing any upgrades since a new Juju binary may
 		// declare new indices or explicit collections.
@@ -942,6 +947,10 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 	}
 	defer session.Close()
 
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ctlr, err := state.OpenController(state.OpenParams{
 		Clock:                  clock.WallClock,
 		ControllerTag:          agentConfig.Controller(),
@@ -949,6 +958,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		SecretsKey:             secretsKey,
 	})
 	return ctlr, nil
 }
@@ -1085,6 +1095,10 @@ func openStatePool(
 	}
 	defer session.Close()
 
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	pool, err := state.OpenStatePool(state.OpenParams{
 		Clock:                  clock.WallClock,
 		ControllerTag:          agentConfig.Controller(),
@@ -1092,6 +1106,7 @@ func openStatePool(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		SecretsKey:             secretsKey,
 	})
 	if err != nil {
 		return nil, nil, err
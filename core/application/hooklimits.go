@@ -0,0 +1,77 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const (
+	// HookTimeoutConfigOptionName is the application config option
+	// holding the maximum wall time a hook may run for.
+	HookTimeoutConfigOptionName = "hook-timeout"
+
+	// HookMemoryLimitConfigOptionName is the application config option
+	// holding the maximum memory a hook may use.
+	HookMemoryLimitConfigOptionName = "hook-memory-limit"
+
+	// HookCPULimitConfigOptionName is the application config option
+	// holding the maximum share of a CPU a hook may use, as a percentage.
+	HookCPULimitConfigOptionName = "hook-cpu-limit"
+)
+
+// HookLimits holds the resource limits applied to the hooks of an
+// application's units. Zero values mean no limit.
+type HookLimits struct {
+	// Timeout is the maximum wall time a hook may run for.
+	Timeout time.Duration
+
+	// MemoryMB is the maximum memory, in MiB, a hook may use.
+	MemoryMB uint64
+
+	// CPUPercent is the maximum percentage of a single CPU
+	// a hook may use. It may exceed 100 on multi-core hosts.
+	CPUPercent int
+}
+
+// IsZero returns true if no limits are set.
+func (l HookLimits) IsZero() bool {
+	return l == HookLimits{}
+}
+
+// ParseHookLimits returns the hook limits held in the supplied
+// application config attributes.
+func ParseHookLimits(attrs ConfigAttributes) (HookLimits, error) {
+	var limits HookLimits
+	if v := attrs.GetString(HookTimeoutConfigOptionName, ""); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return HookLimits{}, errors.NotValidf("%s %q", HookTimeoutConfigOptionName, v)
+		}
+		if timeout < 0 {
+			return HookLimits{}, errors.NotValidf("negative %s %q", HookTimeoutConfigOptionName, v)
+		}
+		limits.Timeout = timeout
+	}
+	if v := attrs.GetString(HookMemoryLimitConfigOptionName, ""); v != "" {
+		mem, err := utils.ParseSize(v)
+		if err != nil {
+			return HookLimits{}, errors.NotValidf("%s %q", HookMemoryLimitConfigOptionName, v)
+		}
+		limits.MemoryMB = mem
+	}
+	if v := attrs.GetString(HookCPULimitConfigOptionName, ""); v != "" {
+		cpu, err := strconv.Atoi(strings.TrimSuffix(v, "%"))
+		if err != nil || cpu < 0 {
+			return HookLimits{}, errors.NotValidf("%s %q", HookCPULimitConfigOptionName, v)
+		}
+		limits.CPUPercent = cpu
+	}
+	return limits, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookLimitsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookLimitsSuite{})
+
+func (s *HookLimitsSuite) TestParseHookLimitsEmpty(c *gc.C) {
+	limits, err := application.ParseHookLimits(application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits.IsZero(), jc.IsTrue)
+}
+
+func (s *HookLimitsSuite) TestParseHookLimits(c *gc.C) {
+	limits, err := application.ParseHookLimits(application.ConfigAttributes{
+		"hook-timeout":      "5m",
+		"hook-memory-limit": "1G",
+		"hook-cpu-limit":    "50%",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, application.HookLimits{
+		Timeout:    5 * time.Minute,
+		MemoryMB:   1024,
+		CPUPercent: 50,
+	})
+	c.Assert(limits.IsZero(), jc.IsFalse)
+}
+
+func (s *HookLimitsSuite) TestParseHookLimitsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"hook-timeout": "soon"},
+		err:   `hook-timeout "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-timeout": "-1s"},
+		err:   `negative hook-timeout "-1s" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-memory-limit": "lots"},
+		err:   `hook-memory-limit "lots" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-cpu-limit": "-5"},
+		err:   `hook-cpu-limit "-5" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := application.ParseHookLimits(test.attrs)
+		c.Assert(err, gc.ErrorMatches, test.err)
+	}
+}
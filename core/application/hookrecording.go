@@ -0,0 +1,33 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+)
+
+const (
+	// HookRecordingConfigOptionName is the application config option
+	// controlling which hooks the application's units record for
+	// offline replay.
+	HookRecordingConfigOptionName = "hook-recording"
+
+	// HookRecordingFailed records only hooks that fail.
+	HookRecordingFailed = "failed"
+
+	// HookRecordingAll records every hook.
+	HookRecordingAll = "all"
+)
+
+// ParseHookRecording returns the hook recording mode held in the
+// supplied application config attributes. An empty mode means hooks
+// are not recorded.
+func ParseHookRecording(attrs ConfigAttributes) (string, error) {
+	mode := attrs.GetString(HookRecordingConfigOptionName, "")
+	switch mode {
+	case "", HookRecordingFailed, HookRecordingAll:
+		return mode, nil
+	}
+	return "", errors.NotValidf("%s %q", HookRecordingConfigOptionName, mode)
+}
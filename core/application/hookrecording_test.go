@@ -0,0 +1,35 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRecordingSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookRecordingSuite{})
+
+func (s *HookRecordingSuite) TestParseHookRecording(c *gc.C) {
+	for _, mode := range []string{"", "failed", "all"} {
+		got, err := application.ParseHookRecording(application.ConfigAttributes{
+			"hook-recording": mode,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(got, gc.Equals, mode)
+	}
+}
+
+func (s *HookRecordingSuite) TestParseHookRecordingInvalid(c *gc.C) {
+	_, err := application.ParseHookRecording(application.ConfigAttributes{
+		"hook-recording": "some",
+	})
+	c.Assert(err, gc.ErrorMatches, `hook-recording "some" not valid`)
+}
@@ -0,0 +1,153 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+const (
+	// HookRetryMaxAttemptsConfigOptionName is the application config
+	// option holding the maximum number of times a failed hook is
+	// automatically retried. 0 means no limit.
+	HookRetryMaxAttemptsConfigOptionName = "hook-retry-max-attempts"
+
+	// HookRetryMinBackoffConfigOptionName is the application config
+	// option holding the delay before the first automatic retry.
+	HookRetryMinBackoffConfigOptionName = "hook-retry-min-backoff"
+
+	// HookRetryMaxBackoffConfigOptionName is the application config
+	// option holding the longest delay between automatic retries.
+	HookRetryMaxBackoffConfigOptionName = "hook-retry-max-backoff"
+
+	// HookRetryHooksConfigOptionName is the application config option
+	// holding the comma separated hook kinds that are automatically
+	// retried. When unset, all hook kinds are retried.
+	HookRetryHooksConfigOptionName = "hook-retry-hooks"
+
+	// HookRetryExcludeHooksConfigOptionName is the application config
+	// option holding the comma separated hook kinds that are never
+	// automatically retried.
+	HookRetryExcludeHooksConfigOptionName = "hook-retry-exclude-hooks"
+)
+
+// HookRetryPolicy holds an application's policy for automatically
+// retrying failed hooks. Zero values mean the model defaults apply.
+type HookRetryPolicy struct {
+	// MaxAttempts is the maximum number of automatic retries of a
+	// failed hook before waiting for it to be resolved by hand.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first automatic retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the longest delay between automatic retries.
+	MaxBackoff time.Duration
+
+	// Hooks holds the hook kinds that are retried. If empty,
+	// all hook kinds not in ExcludeHooks are retried.
+	Hooks []string
+
+	// ExcludeHooks holds the hook kinds that are never retried.
+	ExcludeHooks []string
+}
+
+// Retriable returns true if failures of the hook kind may be retried
+// automatically under the policy.
+func (p HookRetryPolicy) Retriable(kind string) bool {
+	for _, excluded := range p.ExcludeHooks {
+		if excluded == kind {
+			return false
+		}
+	}
+	if len(p.Hooks) == 0 {
+		return true
+	}
+	for _, included := range p.Hooks {
+		if included == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHookRetryPolicy returns the hook retry policy held in the
+// supplied application config attributes.
+func ParseHookRetryPolicy(attrs ConfigAttributes) (HookRetryPolicy, error) {
+	var policy HookRetryPolicy
+	policy.MaxAttempts = attrs.GetInt(HookRetryMaxAttemptsConfigOptionName, 0)
+	if policy.MaxAttempts < 0 {
+		return HookRetryPolicy{}, errors.NotValidf("%s %d", HookRetryMaxAttemptsConfigOptionName, policy.MaxAttempts)
+	}
+	var err error
+	if policy.MinBackoff, err = parseBackoff(attrs, HookRetryMinBackoffConfigOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MaxBackoff, err = parseBackoff(attrs, HookRetryMaxBackoffConfigOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MinBackoff > 0 && policy.MaxBackoff > 0 && policy.MinBackoff > policy.MaxBackoff {
+		return HookRetryPolicy{}, errors.NotValidf(
+			"%s %v greater than %s %v",
+			HookRetryMinBackoffConfigOptionName, policy.MinBackoff,
+			HookRetryMaxBackoffConfigOptionName, policy.MaxBackoff,
+		)
+	}
+	if policy.Hooks, err = parseHookKinds(attrs, HookRetryHooksConfigOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.ExcludeHooks, err = parseHookKinds(attrs, HookRetryExcludeHooksConfigOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	return policy, nil
+}
+
+func parseBackoff(attrs ConfigAttributes, name string) (time.Duration, error) {
+	v := attrs.GetString(name, "")
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errors.NotValidf("%s %q", name, v)
+	}
+	return d, nil
+}
+
+// retriableHookKinds holds the hook kinds that may be named in a
+// hook retry policy. Relation and storage hooks are named by kind,
+// without the endpoint or storage name prefix.
+var retriableHookKinds = func() set.Strings {
+	kinds := set.NewStrings()
+	for _, kind := range hooks.UnitHooks() {
+		kinds.Add(string(kind))
+	}
+	for _, kind := range hooks.RelationHooks() {
+		kinds.Add(string(kind))
+	}
+	kinds.Add(string(hooks.StorageAttached))
+	kinds.Add(string(hooks.StorageDetaching))
+	return kinds
+}()
+
+func parseHookKinds(attrs ConfigAttributes, name string) ([]string, error) {
+	v := attrs.GetString(name, "")
+	var kinds []string
+	for _, kind := range strings.Split(v, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		if !retriableHookKinds.Contains(kind) {
+			return nil, errors.NotValidf("%s hook kind %q", name, kind)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
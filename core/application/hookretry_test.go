@@ -0,0 +1,84 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetrySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) TestParseHookRetryPolicyUnset(c *gc.C) {
+	policy, err := application.ParseHookRetryPolicy(application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{})
+	c.Assert(policy.Retriable("upgrade-charm"), jc.IsTrue)
+}
+
+func (s *HookRetrySuite) TestParseHookRetryPolicy(c *gc.C) {
+	policy, err := application.ParseHookRetryPolicy(application.ConfigAttributes{
+		"hook-retry-max-attempts":  3,
+		"hook-retry-min-backoff":   "10s",
+		"hook-retry-max-backoff":   "1m",
+		"hook-retry-hooks":         "install, config-changed,relation-changed",
+		"hook-retry-exclude-hooks": "config-changed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{
+		MaxAttempts:  3,
+		MinBackoff:   10 * time.Second,
+		MaxBackoff:   time.Minute,
+		Hooks:        []string{"install", "config-changed", "relation-changed"},
+		ExcludeHooks: []string{"config-changed"},
+	})
+	c.Assert(policy.Retriable("install"), jc.IsTrue)
+	c.Assert(policy.Retriable("relation-changed"), jc.IsTrue)
+	c.Assert(policy.Retriable("config-changed"), jc.IsFalse)
+	c.Assert(policy.Retriable("upgrade-charm"), jc.IsFalse)
+}
+
+func (s *HookRetrySuite) TestRetriableExcludeOnly(c *gc.C) {
+	policy := application.HookRetryPolicy{ExcludeHooks: []string{"upgrade-charm"}}
+	c.Assert(policy.Retriable("install"), jc.IsTrue)
+	c.Assert(policy.Retriable("upgrade-charm"), jc.IsFalse)
+}
+
+func (s *HookRetrySuite) TestParseHookRetryPolicyInvalid(c *gc.C) {
+	for i, t := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"hook-retry-max-attempts": -1},
+		err:   `hook-retry-max-attempts -1 not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-min-backoff": "soon"},
+		err:   `hook-retry-min-backoff "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-max-backoff": "0s"},
+		err:   `hook-retry-max-backoff "0s" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			"hook-retry-min-backoff": "5m",
+			"hook-retry-max-backoff": "1m",
+		},
+		err: `hook-retry-min-backoff 5m0s greater than hook-retry-max-backoff 1m0s not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-exclude-hooks": "db-relation-changed"},
+		err:   `hook-retry-exclude-hooks hook kind "db-relation-changed" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := application.ParseHookRetryPolicy(t.attrs)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// UpdateStatusHookIntervalConfigOptionName is the application config
+	// option overriding the model's update-status hook interval for the
+	// application's units. An interval of 0 disables update-status.
+	UpdateStatusHookIntervalConfigOptionName = "update-status-hook-interval"
+
+	// MinUpdateStatusHookInterval is the shortest update-status hook
+	// interval an application may set.
+	MinUpdateStatusHookInterval = time.Minute
+)
+
+// ParseUpdateStatusHookInterval returns the update-status hook interval
+// held in the supplied application config attributes, and whether one
+// was set at all. A zero interval means update-status is disabled.
+func ParseUpdateStatusHookInterval(attrs ConfigAttributes) (time.Duration, bool, error) {
+	v := attrs.GetString(UpdateStatusHookIntervalConfigOptionName, "")
+	if v == "" {
+		return 0, false, nil
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, false, errors.NotValidf("%s %q", UpdateStatusHookIntervalConfigOptionName, v)
+	}
+	if interval < 0 {
+		return 0, false, errors.NotValidf("negative %s %q", UpdateStatusHookIntervalConfigOptionName, v)
+	}
+	if interval > 0 && interval < MinUpdateStatusHookInterval {
+		return 0, false, errors.NotValidf(
+			"%s %q less than %v", UpdateStatusHookIntervalConfigOptionName, v, MinUpdateStatusHookInterval,
+		)
+	}
+	return interval, true, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UpdateStatusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&UpdateStatusSuite{})
+
+func (s *UpdateStatusSuite) TestParseUpdateStatusHookIntervalUnset(c *gc.C) {
+	_, ok, err := application.ParseUpdateStatusHookInterval(application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *UpdateStatusSuite) TestParseUpdateStatusHookInterval(c *gc.C) {
+	interval, ok, err := application.ParseUpdateStatusHookInterval(application.ConfigAttributes{
+		"update-status-hook-interval": "30m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(interval, gc.Equals, 30*time.Minute)
+}
+
+func (s *UpdateStatusSuite) TestParseUpdateStatusHookIntervalDisabled(c *gc.C) {
+	interval, ok, err := application.ParseUpdateStatusHookInterval(application.ConfigAttributes{
+		"update-status-hook-interval": "0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(interval, gc.Equals, time.Duration(0))
+}
+
+func (s *UpdateStatusSuite) TestParseUpdateStatusHookIntervalInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "often",
+		err:   `update-status-hook-interval "often" not valid`,
+	}, {
+		value: "-5m",
+		err:   `negative update-status-hook-interval "-5m" not valid`,
+	}, {
+		value: "10s",
+		err:   `update-status-hook-interval "10s" less than 1m0s not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, _, err := application.ParseUpdateStatusHookInterval(application.ConfigAttributes{
+			"update-status-hook-interval": test.value,
+		})
+		c.Assert(err, gc.ErrorMatches, test.err)
+	}
+}
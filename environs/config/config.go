@@ -239,6 +239,11 @@ const (
 	// list will be comma separated.
 	ContainerInheritProperiesKey = "container-inherit-properties"
 
+	// KubernetesNetworkPoliciesKey is the key for whether ingress to
+	// the applications of a Kubernetes model is restricted by network
+	// policies to the applications they are related to.
+	KubernetesNetworkPoliciesKey = "kubernetes-network-policies"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -448,6 +453,7 @@ var defaultConfigValues = map[string]interface{}{
 	CloudInitUserDataKey:         "",
 	ContainerInheritProperiesKey: "",
 	BackupDirKey:                 "",
+	KubernetesNetworkPoliciesKey: false,
 
 	// Image and agent streams and URLs.
 	"image-stream":               "released",
@@ -1372,6 +1378,14 @@ func (c *Config) ContainerInheritProperies() string {
 	return c.asString(ContainerInheritProperiesKey)
 }
 
+// KubernetesNetworkPolicies returns whether ingress to the applications
+// of a Kubernetes model is restricted to the applications they are
+// related to, unless exposed. By default this is false.
+func (c *Config) KubernetesNetworkPolicies() bool {
+	val, _ := c.defined[KubernetesNetworkPoliciesKey].(bool)
+	return val
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1498,6 +1512,7 @@ var alwaysOptional = schema.Defaults{
 	FanConfig:                    schema.Omit,
 	CloudInitUserDataKey:         schema.Omit,
 	ContainerInheritProperiesKey: schema.Omit,
+	KubernetesNetworkPoliciesKey: schema.Omit,
 	BackupDirKey:                 schema.Omit,
 }
 
@@ -2003,4 +2018,9 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	KubernetesNetworkPoliciesKey: {
+		Description: "Whether ingress to the applications of a Kubernetes model is restricted to the applications they are related to, or open to all once exposed",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
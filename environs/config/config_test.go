@@ -1114,6 +1114,17 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestKubernetesNetworkPoliciesDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.KubernetesNetworkPolicies(), gc.Equals, false)
+}
+
+func (s *ConfigSuite) TestKubernetesNetworkPoliciesEnabled(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"kubernetes-network-policies": "true"})
+	c.Assert(config.KubernetesNetworkPolicies(), gc.Equals, true)
+}
+
 func (s *ConfigSuite) TestNoBothProxy(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{
 		"http-proxy":  "http://user@10.0.0.1",
@@ -164,18 +164,50 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-autoscaling-max-replicas:
+    description: the maximum number of pods; setting it enables autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscaling-metric:
+    description: the name of a custom pod metric for the autoscaler
+    source: unset
+    type: string
+  kubernetes-autoscaling-metric-target:
+    description: the average custom pod metric value the autoscaler aims for
+    source: unset
+    type: string
+  kubernetes-autoscaling-min-replicas:
+    description: the minimum number of pods the autoscaler will run
+    source: unset
+    type: int
+  kubernetes-autoscaling-target-cpu:
+    description: the average CPU utilisation percentage the autoscaler aims for
+    source: unset
+    type: int
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
     source: default
     type: bool
     value: false
+  kubernetes-ingress-annotations:
+    description: additional annotations for the ingress, as a YAML map
+    source: unset
+    type: string
   kubernetes-ingress-class:
     default: nginx
     description: the class of the ingress controller to be used by the ingress resource
     source: default
     type: string
     value: nginx
+  kubernetes-ingress-hosts:
+    description: additional hostnames the ingress routes to the application
+    source: unset
+    type: string
+  kubernetes-ingress-paths:
+    description: the path prefixes the ingress routes to the application
+    source: unset
+    type: string
   kubernetes-ingress-ssl-passthrough:
     default: false
     description: whether to passthrough SSL traffic to the ingress controller
@@ -188,6 +220,18 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-cluster-issuer:
+    description: the cert-manager cluster issuer of the ingress TLS certificate
+    source: unset
+    type: string
+  kubernetes-ingress-tls-issuer:
+    description: the cert-manager issuer of the ingress TLS certificate
+    source: unset
+    type: string
+  kubernetes-ingress-tls-secret:
+    description: the name of the secret holding the ingress TLS certificate
+    source: unset
+    type: string
   kubernetes-service-external-ips:
     description: list of IP addresses for which nodes in the cluster will also accept
       traffic
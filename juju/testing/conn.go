@@ -589,6 +589,7 @@ func newState(controllerUUID string, environ environs.Environ, mongoInfo *mongo.
 		ControllerModelTag: modelTag,
 		MongoSession:       session,
 		NewPolicy:          newPolicyFunc,
+		SecretsKey:         testing.SecretsKey,
 	}
 	pool, err := state.OpenStatePool(args)
 	if errors.IsUnauthorized(errors.Cause(err)) {
@@ -35,6 +35,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	Secrets(owner string) ([]*state.Secret, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -125,6 +126,10 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	if err := ctx.checkSecrets(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := ctx.checkMachines(); err != nil {
 		return errors.Trace(err)
 	}
@@ -184,6 +189,20 @@ func (ctx *precheckContext) checkModel() error {
 	return nil
 }
 
+// checkSecrets refuses to migrate models with secrets, as they are not
+// exported yet, and each model's secret key is wrapped with a key held
+// only by the source controller.
+func (ctx *precheckContext) checkSecrets() error {
+	secrets, err := ctx.backend.Secrets("")
+	if err != nil {
+		return errors.Annotate(err, "retrieving secrets")
+	}
+	if len(secrets) > 0 {
+		return errors.Errorf("model has %d secrets, which cannot be migrated", len(secrets))
+	}
+	return nil
+}
+
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
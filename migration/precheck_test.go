@@ -107,6 +107,20 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secrets = make([]*state.Secret, 2)
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has 2 secrets, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -784,6 +798,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	secrets    []*state.Secret
+	secretsErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -831,6 +848,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) Secrets(string) ([]*state.Secret, error) {
+	return b.secrets, b.secretsErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -498,6 +498,15 @@ func allCollections() collectionSchema {
 		// for units, eg address, ports.
 		cloudContainersC: {},
 
+		// secretsC holds the secrets owned by applications, with their
+		// values encrypted using the model's key in secretKeysC.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretKeysC: {},
+
 		// cloudServicesC holds the CAAS service information
 		// eg addresses.
 		cloudServicesC: {},
@@ -574,6 +583,8 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretsC                   = "secrets"
+	secretKeysC                = "secretKeys"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
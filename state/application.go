@@ -399,6 +399,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove the secrets the application owns.
+	removeSecretOps, err := removeSecretsOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSecretOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -2214,6 +2221,197 @@ func (a *Application) defaultEndpointBindings() (map[string]string, error) {
 	return DefaultEndpointBindingsForCharm(charm.Meta()), nil
 }
 
+// SetEndpointBindings merges the given endpoint bindings into the
+// application's existing bindings. An empty endpoint name sets the
+// default space for endpoints without an explicit binding. Unless force
+// is true, every machine hosting a unit of the application must be
+// connected to each space an endpoint is newly bound to.
+//
+// Units of the application that are in relations on rebound endpoints
+// have their ingress and egress addresses updated in the relation
+// settings, so that the units on the other side of the relation are
+// notified of the change.
+func (a *Application) SetEndpointBindings(bindings map[string]string, force bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", a)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := a.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		merged, _, err := mergeBindings(bindings, current, ch.Meta())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changed := set.NewStrings()
+		newSpaces := set.NewStrings()
+		for endpoint, space := range merged {
+			if endpoint == defaultEndpointName || current[endpoint] == space {
+				continue
+			}
+			changed.Add(endpoint)
+			if space != "" {
+				newSpaces.Add(space)
+			}
+		}
+		if !force {
+			if err := a.checkUnitMachineSpaces(newSpaces); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err != nil {
+			return nil, err
+		}
+		networksOps, err := a.updateRelationNetworksOps(changed, current, merged)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"charmurl", a.doc.CharmURL}},
+		}, bindingsOp}
+		return append(ops, networksOps...), nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// checkUnitMachineSpaces returns an error if any machine hosting a unit
+// of the application is not connected to all of the given spaces.
+func (a *Application) checkUnitMachineSpaces(spaces set.Strings) error {
+	if spaces.IsEmpty() {
+		return nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, u := range units {
+		if !u.ShouldBeAssigned() {
+			continue
+		}
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		m, err := a.st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineSpaces, err := m.AllSpaces()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if missing := spaces.Difference(machineSpaces); !missing.IsEmpty() {
+			return errors.Errorf("unit %q is on machine %q which is not connected to space(s) %s",
+				u.Name(), machineId, network.QuoteSpaceSet(missing))
+		}
+	}
+	return nil
+}
+
+// updateRelationNetworksOps returns the operations to rewrite the ingress
+// and egress addresses in the relation settings of the application's
+// units, for the relations on the given endpoints, when the endpoints'
+// bindings change from current to updated. Addresses which no longer
+// match those derived from the current bindings have been set by the
+// charm, and are left alone.
+func (a *Application) updateRelationNetworksOps(
+	endpoints set.Strings, current, updated map[string]string,
+) ([]txn.Op, error) {
+	if endpoints.IsEmpty() {
+		return nil, nil
+	}
+	model, err := a.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(a.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !endpoints.Contains(ep.Name) {
+			continue
+		}
+		for _, u := range units {
+			ru, err := rel.Unit(u)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if inScope, err := ru.InScope(); err != nil {
+				return nil, errors.Trace(err)
+			} else if !inScope {
+				continue
+			}
+			oldIngress, oldEgress, err := networksForRelationSpace(current[ep.Name], u, rel, cfg.EgressSubnets())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ingress, egress, err := networksForRelationSpace(updated[ep.Name], u, rel, cfg.EgressSubnets())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			settings, err := ru.Settings()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			update := func(key, oldValue, newValue string) {
+				if value, ok := settings.Get(key); ok && value != oldValue {
+					return
+				}
+				settings.Set(key, newValue)
+			}
+			if len(ingress) > 0 {
+				var oldAddress string
+				if len(oldIngress) > 0 {
+					oldAddress = oldIngress[0]
+				}
+				update("private-address", oldAddress, ingress[0])
+				update("ingress-address", oldAddress, ingress[0])
+			}
+			if len(egress) > 0 {
+				update("egress-subnets", strings.Join(oldEgress, ","), strings.Join(egress, ","))
+			}
+			_, settingsOps := settings.settingsUpdateOps()
+			for _, op := range settingsOps {
+				// Fail if the uniter changes the settings meanwhile.
+				op.Assert = bson.D{{"version", settings.version}}
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops, nil
+}
+
 // MetricCredentials returns any metric credentials associated with this application.
 func (a *Application) MetricCredentials() []byte {
 	return a.doc.MetricCredentials
@@ -3286,6 +3286,74 @@ func (s *ApplicationSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertApplicationRemovedWithItsBindings(c, application)
 }
 
+func (s *ApplicationSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err = application.SetEndpointBindings(map[string]string{"server": "db"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	setBindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "",
+		"cluster": "",
+	})
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUnknownSpace(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err := application.SetEndpointBindings(map[string]string{"server": "nope"}, false)
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown space "nope" not valid`)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUnitMachineNotInSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	bindings := map[string]string{"server": "db"}
+	err = application.SetEndpointBindings(bindings, false)
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": `+
+		`unit "yoursql/0" is on machine "0" which is not connected to space\(s\) "db"`)
+
+	err = application.SetEndpointBindings(bindings, true)
+	c.Assert(err, jc.ErrorIsNil)
+	setBindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings["server"], gc.Equals, "db")
+}
+
+func (s *ApplicationSuite) TestWatchEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	w := application.WatchEndpointBindings()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = application.SetEndpointBindings(map[string]string{"server": "db"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Setting the same bindings again is a no-op.
+	err = application.SetEndpointBindings(map[string]string{"server": "db"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3526,6 +3594,28 @@ func (s *ApplicationSuite) TestUpdateApplicationConfig(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(map[string]interface{}{
+		"title": "foo",
+	}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.mysql.UpdateApplicationConfig(map[string]interface{}{
+		"title": "foo",
+	}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func sampleApplicationConfigSchema() environschema.Fields {
 	schema := environschema.Fields{
 		"title":       environschema.Attr{Type: environschema.Tstring},
@@ -857,3 +857,8 @@ func CaasApplicationDisplayStatus(appStatus status.StatusInfo, operatorStatus st
 func ApplicationOperatorStatus(st *State, appName string) (status.StatusInfo, error) {
 	return getStatus(st.db(), applicationGlobalOperatorKey(appName), "operator")
 }
+
+// SetSecretsKey sets the controller secrets key of the state.
+func SetSecretsKey(st *State, key []byte) {
+	st.secretsKey = key
+}
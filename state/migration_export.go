@@ -1017,6 +1017,13 @@ func (e *exporter) relations() error {
 				Limit:           ep.Limit,
 				Scope:           string(ep.Scope),
 			})
+			// Application settings are only present once the application
+			// has published some.
+			appKey := relationApplicationSettingsKey(relation.Id(), ep.ApplicationName)
+			if appSettingsDoc, found := e.modelSettings[appKey]; found {
+				delete(e.modelSettings, appKey)
+				exEndPoint.SetApplicationSettings(appSettingsDoc.Settings)
+			}
 			// We expect a relationScope and settings for each of the
 			// units of the specified application, unless it is a
 			// remote application.
@@ -1159,7 +1159,7 @@ func (i *importer) relation(rel description.Relation) error {
 	dbRelation := newRelation(i.st, relationDoc)
 	// Add an op that adds the relation scope document for each
 	// unit of the application, and an op that adds the relation settings
-	// for each unit.
+	// for each unit and for the application itself.
 	for _, endpoint := range rel.Endpoints() {
 		units := i.applicationUnits[endpoint.ApplicationName()]
 		for unitName, settings := range endpoint.AllSettings() {
@@ -1183,6 +1183,10 @@ func (i *importer) relation(rel description.Relation) error {
 				createSettingsOp(settingsC, ruKey, settings),
 			)
 		}
+		if appSettings := endpoint.ApplicationSettings(); len(appSettings) > 0 {
+			appKey := relationApplicationSettingsKey(rel.Id(), endpoint.ApplicationName())
+			ops = append(ops, createSettingsOp(settingsC, appKey, appSettings))
+		}
 	}
 
 	if err := i.st.db().RunTransaction(ops); err != nil {
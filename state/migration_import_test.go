@@ -916,6 +916,11 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	}
 	err = ru.EnterScope(relSettings)
 	c.Assert(err, jc.ErrorIsNil)
+	appSettings := map[string]interface{}{
+		"shared": "value",
+	}
+	err = rel.ReplaceApplicationSettings("wordpress", appSettings)
+	c.Assert(err, jc.ErrorIsNil)
 
 	_, newSt := s.importModel(c, s.State)
 
@@ -939,6 +944,10 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	settings, err := ru.Settings()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(settings.Map(), gc.DeepEquals, relSettings)
+
+	newAppSettings, err := rels[0].ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newAppSettings, gc.DeepEquals, appSettings)
 }
 
 func (s *MigrationImportSuite) assertRelationsMissingStatus(c *gc.C, hasUnits bool) {
@@ -209,6 +209,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		relationNetworksC,
 		firewallRulesC,
 		dockerResourcesC,
+		// Secrets aren't exported yet; the migration
+		// prechecks refuse models with secrets.
+		secretsC,
+		secretKeysC,
 		// TODO(raftlease)
 		// This collection shouldn't be migrated, but we need to make
 		// sure the leader units' leases are claimed in the target
@@ -429,6 +433,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// DeferredEvents are reported again by the unit agent.
+		"DeferredEvents",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -388,6 +388,7 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.secretsKey = st.secretsKey
 
 	modelOps, modelStatusDoc, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
@@ -47,6 +47,11 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// SecretsKey is the controller key, held in agent config, which
+	// wraps the keys encrypting each model's secrets. Secrets cannot
+	// be saved or read if it is empty.
+	SecretsKey []byte
 }
 
 // Validate validates the OpenParams.
@@ -105,6 +110,7 @@ func Open(args OpenParams) (*State, error) {
 		session.Close()
 		return nil, errors.Trace(err)
 	}
+	st.secretsKey = args.SecretsKey
 	if _, err := st.Model(); err != nil {
 		if err := st.Close(); err != nil {
 			logger.Errorf("closing State for %s: %v", args.ControllerModelTag, err)
@@ -142,6 +142,7 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 		session.Close()
 		return nil, errors.Trace(err)
 	}
+	st.secretsKey = args.SecretsKey
 	defer func() {
 		if err != nil {
 			if closeErr := st.Close(); closeErr != nil {
@@ -246,6 +247,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = p.systemState.secretsKey
 	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -329,6 +331,18 @@ func (p *StatePool) SystemState() *State {
 	return p.systemState
 }
 
+// SetSecretsKey sets the controller secrets key, which wraps the keys
+// encrypting each model's secrets, of every State in the pool. It is
+// used when upgrading a controller which did not have a key.
+func (p *StatePool) SetSecretsKey(key []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.systemState.secretsKey = key
+	for _, item := range p.pool {
+		item.state.secretsKey = key
+	}
+}
+
 // Close closes all State instances in the pool.
 func (p *StatePool) Close() error {
 	p.mu.Lock()
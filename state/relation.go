@@ -16,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/permission"
 )
@@ -569,6 +570,133 @@ func (r *Relation) IsCrossModel() (bool, error) {
 	return false, nil
 }
 
+// ApplicationSettings returns the application-level settings for the
+// given application in the relation. Unlike unit settings, these are
+// shared by all of the application's units; they are written by the
+// leader and read by the units of the related applications.
+func (r *Relation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	if _, err := r.Endpoint(appName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s, err := readSettings(r.st.db(), settingsC, r.applicationSettingsKey(appName))
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", appName)
+	}
+	return s.Map(), nil
+}
+
+// UpdateApplicationSettings updates the application-level settings for
+// the given application in the relation, but will fail (with a suitable
+// error) if the supplied Token, which must be the application's
+// leadership token, loses validity. Empty string values in the supplied
+// map will be cleared in the database.
+func (r *Relation) UpdateApplicationSettings(appName string, token leadership.Token, updates map[string]interface{}) error {
+	buildTxn, err := r.updateApplicationSettingsTxn(appName, updates, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return r.st.db().Run(buildTxnWithLeadership(buildTxn, token))
+}
+
+// ReplaceApplicationSettings replaces the application-level settings
+// for the given application in the relation. It is used to record the
+// settings published by an application in another model, which has no
+// leader in this one, so no leadership check is made.
+func (r *Relation) ReplaceApplicationSettings(appName string, settings map[string]interface{}) error {
+	buildTxn, err := r.updateApplicationSettingsTxn(appName, settings, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return r.st.db().Run(buildTxn)
+}
+
+func (r *Relation) updateApplicationSettingsTxn(
+	appName string, updates map[string]interface{}, replace bool,
+) (jujutxn.TransactionSource, error) {
+	if _, err := r.Endpoint(appName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := r.applicationSettingsKey(appName)
+	return func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Life == Dead {
+			return nil, errors.Errorf("relation %q is dead", r)
+		}
+		relationOp := txn.Op{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: notDeadDoc,
+		}
+		current := make(map[string]interface{})
+		doc, err := readSettingsDoc(r.st.db(), settingsC, key)
+		if err == nil {
+			current = doc.Settings
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "application %q", appName)
+		}
+
+		sets := bson.M{}
+		unsets := bson.M{}
+		for k, v := range updates {
+			escaped := escapeReplacer.Replace(k)
+			if v == "" {
+				if _, ok := current[escaped]; ok {
+					unsets[escaped] = 1
+				}
+			} else if current[escaped] != v {
+				sets[escaped] = v
+			}
+		}
+		if replace {
+			for escaped := range current {
+				if _, ok := updates[unescapeReplacer.Replace(escaped)]; !ok {
+					unsets[escaped] = 1
+				}
+			}
+		}
+
+		if doc == nil {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			values := make(map[string]interface{})
+			for k, v := range sets {
+				values[unescapeReplacer.Replace(k)] = v
+			}
+			return []txn.Op{relationOp, createSettingsOp(settingsC, key, values)}, nil
+		}
+		if len(sets)+len(unsets) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{relationOp, {
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}}, nil
+	}, nil
+}
+
+// applicationSettingsKey returns the key of the application-level
+// settings for the given application in the relation.
+func (r *Relation) applicationSettingsKey(appName string) string {
+	return relationApplicationSettingsKey(r.doc.Id, appName)
+}
+
+// relationApplicationSettingsKey returns the settings key for the
+// application-level settings of an application in the relation with
+// the given id. Application names cannot contain "/", so the key cannot
+// clash with those of the units' settings.
+func relationApplicationSettingsKey(id int, appName string) string {
+	return fmt.Sprintf("%s#%s", relationGlobalScope(id), appName)
+}
+
 func (r *Relation) unit(
 	unitName string,
 	principal string,
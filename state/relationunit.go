@@ -20,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 )
@@ -51,6 +52,11 @@ func (ru *RelationUnit) Endpoint() Endpoint {
 	return ru.endpoint
 }
 
+// UnitName returns the name of the unit taking part in the relation.
+func (ru *RelationUnit) UnitName() string {
+	return ru.unitName
+}
+
 // ErrCannotEnterScope indicates that a relation unit failed to enter its scope
 // due to either the unit or the relation not being Alive.
 var ErrCannotEnterScope = stderrors.New("cannot enter scope: unit or relation is not alive")
@@ -459,6 +465,51 @@ func (ru *RelationUnit) Settings() (*Settings, error) {
 	return s, nil
 }
 
+// UpdateSettings changes the unit's settings within the relation and,
+// if appUpdates is not empty, the application-level settings of the
+// unit's application, in a single transaction. Keys with empty string
+// values are deleted. The application-level settings are only changed
+// while the supplied leadership token remains valid.
+func (ru *RelationUnit) UpdateSettings(
+	unitUpdates map[string]interface{}, appUpdates map[string]interface{}, token leadership.Token,
+) error {
+	var appTxn jujutxn.TransactionSource
+	if len(appUpdates) > 0 {
+		var err error
+		appTxn, err = ru.relation.updateApplicationSettingsTxn(ru.endpoint.ApplicationName, appUpdates, false)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appTxn = buildTxnWithLeadership(appTxn, token)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		settings, err := ru.Settings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for k, v := range unitUpdates {
+			if v == "" {
+				settings.Delete(k)
+			} else {
+				settings.Set(k, v)
+			}
+		}
+		_, ops := settings.settingsUpdateOps()
+		if appTxn != nil {
+			appOps, err := appTxn(attempt)
+			if err != nil && err != jujutxn.ErrNoOperations {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, appOps...)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return ru.st.db().Run(buildTxn)
+}
+
 // ReadSettings returns a map holding the settings of the unit with the
 // supplied name within this relation. An error will be returned if the
 // relation no longer exists, or if the unit's application is not part of the
@@ -498,29 +549,41 @@ var PreferredAddressRetryArgs = func() retry.CallArgs {
 func NetworksForRelation(
 	binding string, unit *Unit, rel *Relation, defaultEgress []string,
 ) (boundSpace string, ingress []string, egress []string, _ error) {
+	boundSpace, err := unit.GetSpaceForBinding(binding)
+	if err != nil && !errors.IsNotValid(err) {
+		return "", nil, nil, errors.Trace(err)
+	}
+	ingress, egress, err = networksForRelationSpace(boundSpace, unit, rel, defaultEgress)
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
+	return boundSpace, ingress, egress, nil
+}
+
+// networksForRelationSpace returns the ingress and egress addresses for a
+// relation and unit, when the relation endpoint is bound to the given space.
+func networksForRelationSpace(
+	boundSpace string, unit *Unit, rel *Relation, defaultEgress []string,
+) (ingress []string, egress []string, _ error) {
 	st := unit.st
 
 	relEgress := NewRelationEgressNetworks(st)
 	egressSubnets, err := relEgress.Networks(rel.Tag().Id())
 	if err != nil && !errors.IsNotFound(err) {
-		return "", nil, nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	} else if err == nil {
 		egress = egressSubnets.CIDRS()
 	} else {
 		egress = defaultEgress
 	}
 
-	boundSpace, err = unit.GetSpaceForBinding(binding)
-	if err != nil && !errors.IsNotValid(err) {
-		return "", nil, nil, errors.Trace(err)
-	}
 	// If the endpoint for this relation is not bound to a space, or
 	// is bound to the default space, we need to look up the ingress
 	// address info which is aware of cross model relations.
-	if boundSpace == environs.DefaultSpaceName || err != nil {
+	if boundSpace == environs.DefaultSpaceName {
 		crossmodel, err := rel.IsCrossModel()
 		if err != nil {
-			return "", nil, nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		// TODO(caas) - we might need to use the service address
 		if crossmodel && unit.ShouldBeAssigned() {
@@ -543,7 +606,7 @@ func NetworksForRelation(
 					unit.Name(), rel)
 				address, err = unit.PrivateAddress()
 				if err != nil {
-					return "", nil, nil, errors.Trace(err)
+					return nil, nil, errors.Trace(err)
 				}
 			}
 			ingress = []string{address.Value}
@@ -555,12 +618,12 @@ func NetworksForRelation(
 			// which the endpoint is bound.
 			machineID, err := unit.AssignedMachineId()
 			if err != nil {
-				return "", nil, nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			machine, err := st.Machine(machineID)
 			if err != nil {
-				return "", nil, nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			networkInfos := machine.GetNetworkInfoForSpaces(set.NewStrings(boundSpace))
@@ -592,10 +655,10 @@ func NetworksForRelation(
 	if len(egress) == 0 && len(ingress) > 0 {
 		egress, err = network.FormatAsCIDR([]string{ingress[0]})
 		if err != nil {
-			return "", nil, nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	}
-	return boundSpace, ingress, egress, nil
+	return ingress, egress, nil
 }
 
 // unitKey returns a string, based on the relation and the supplied unit name,
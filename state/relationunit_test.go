@@ -80,6 +80,47 @@ func (s *RelationUnitSuite) TestReadSettingsErrors(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot read settings for unit "riak/1" in relation "riak:ring": unit "riak/1": settings not found`)
 }
 
+func (s *RelationUnitSuite) TestUpdateSettings(c *gc.C) {
+	pr := newPeerRelation(c, s.State)
+	err := pr.ru0.EnterScope(map[string]interface{}{"gene": "kelly", "meme": "lol"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = pr.ru0.UpdateSettings(
+		map[string]interface{}{"gene": "", "foo": "bar"},
+		map[string]interface{}{"leader": "riak/0"},
+		&fakeToken{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := pr.ru1.ReadSettings("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"meme": "lol", "foo": "bar"})
+	appSettings, err := pr.rel.ApplicationSettings("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appSettings, gc.DeepEquals, map[string]interface{}{"leader": "riak/0"})
+}
+
+func (s *RelationUnitSuite) TestUpdateSettingsTokenError(c *gc.C) {
+	pr := newPeerRelation(c, s.State)
+	err := pr.ru0.EnterScope(map[string]interface{}{"gene": "kelly"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = pr.ru0.UpdateSettings(
+		map[string]interface{}{"gene": "simmons"},
+		map[string]interface{}{"leader": "riak/0"},
+		&failToken{},
+	)
+	c.Assert(err, gc.ErrorMatches, "prerequisites failed: something bad happened")
+
+	// Neither the unit nor the application settings were changed.
+	settings, err := pr.ru1.ReadSettings("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"gene": "kelly"})
+	appSettings, err := pr.rel.ApplicationSettings("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appSettings, gc.HasLen, 0)
+}
+
 func (s *RelationUnitSuite) TestPeerSettings(c *gc.C) {
 	pr := newPeerRelation(c, s.State)
 	rus := RUs{pr.ru0, pr.ru1}
@@ -953,6 +994,40 @@ func (s *RelationUnitSuite) TestNetworksForRelationWithSpaces(c *gc.C) {
 	c.Assert(egress, gc.DeepEquals, []string{"2.2.3.4/32"})
 }
 
+func (s *RelationUnitSuite) TestSetEndpointBindingsUpdatesRelationNetworks(c *gc.C) {
+	s.State.AddSubnet(state.SubnetInfo{CIDR: "1.2.0.0/16"})
+	s.State.AddSpace("space-1", "pid-1", []string{"1.2.0.0/16"}, false)
+	s.State.AddSubnet(state.SubnetInfo{CIDR: "2.2.0.0/16"})
+	s.State.AddSpace("space-2", "pid-2", []string{"2.2.0.0/16"}, false)
+
+	bindings := map[string]string{"server": "space-1"}
+	prr := newProReqRelationWithBindings(c, &s.ConnSuite, charm.ScopeGlobal, bindings, nil)
+	err := prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addDevicesWithAddresses(c, machine, "1.2.3.4/16", "2.2.3.4/16")
+
+	// The egress subnets have been set by the charm.
+	err = prr.pru0.EnterScope(map[string]interface{}{
+		"ingress-address": "1.2.3.4",
+		"egress-subnets":  "10.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = prr.papp.SetEndpointBindings(map[string]string{"server": "space-2"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := prr.pru0.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, map[string]interface{}{
+		"private-address": "2.2.3.4",
+		"ingress-address": "2.2.3.4",
+		"egress-subnets":  "10.0.0.0/8",
+	})
+}
+
 func (s *RelationUnitSuite) TestNetworksForRelationRemoteRelation(c *gc.C) {
 	prr := newRemoteProReqRelation(c, &s.ConnSuite)
 	err := prr.ru0.AssignToNewMachine()
@@ -0,0 +1,538 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// secretKeyId is the id of the document in secretKeysC holding the key
+// used to encrypt a model's secret values. The key is only stored
+// wrapped with the controller's secrets key, which is held in the
+// agent config of the controllers and never written to the database.
+const secretKeyId = "secrets"
+
+var validSecretName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// SecretID returns the id of the secret with the given name owned by
+// the named application.
+func SecretID(owner, name string) string {
+	return owner + "/" + name
+}
+
+// IsValidSecretID returns whether id is a valid secret id.
+func IsValidSecretID(id string) bool {
+	parts := strings.Split(id, "/")
+	return len(parts) == 2 && names.IsValidApplication(parts[0]) && validSecretName.MatchString(parts[1])
+}
+
+// secretDoc holds a secret owned by an application.
+type secretDoc struct {
+	DocID       string `bson:"_id"`
+	Owner       string `bson:"owner"`
+	Name        string `bson:"name"`
+	Description string `bson:"description,omitempty"`
+	Revision    int    `bson:"revision"`
+
+	// Value holds the secret value, encrypted with the model's
+	// secret key and prefixed with the nonce used.
+	Value []byte `bson:"value"`
+
+	RotateInterval time.Duration `bson:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `bson:"next-rotate-time,omitempty"`
+
+	// Grants holds the relations whose remote
+	// applications may read the secret.
+	Grants []secretGrantDoc `bson:"grants,omitempty"`
+
+	CreateTime time.Time `bson:"create-time"`
+	UpdateTime time.Time `bson:"update-time"`
+}
+
+// secretGrantDoc records a relation on which a secret was granted.
+// Relation keys are reused when a relation is removed and added again,
+// so the grant is identified by the relation's id, which is not.
+type secretGrantDoc struct {
+	RelationID  int    `bson:"relation-id"`
+	RelationKey string `bson:"relation-key"`
+}
+
+type secretKeyDoc struct {
+	DocID string `bson:"_id"`
+
+	// WrappedKey holds the model's secret key, encrypted with the
+	// controller's secrets key and prefixed with the nonce used.
+	WrappedKey []byte `bson:"wrapped-key"`
+}
+
+// Secret represents a secret owned by an application. Its value is
+// encrypted at rest.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// ID returns the id of the secret, which identifies its owner.
+func (s *Secret) ID() string {
+	return SecretID(s.doc.Owner, s.doc.Name)
+}
+
+// Owner returns the name of the application owning the secret.
+func (s *Secret) Owner() string {
+	return s.doc.Owner
+}
+
+// Name returns the name of the secret.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Description returns the description of the secret.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the revision of the secret's value, which
+// increments each time the value changes.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// RotateInterval returns how often the secret should be rotated, or
+// zero if it is not rotated.
+func (s *Secret) RotateInterval() time.Duration {
+	return s.doc.RotateInterval
+}
+
+// NextRotateTime returns when the secret is next due to be rotated,
+// or nil if it is not rotated.
+func (s *Secret) NextRotateTime() *time.Time {
+	return s.doc.NextRotateTime
+}
+
+// Grants returns the keys of the relations whose remote applications
+// have been granted access to the secret.
+func (s *Secret) Grants() []string {
+	keys := make([]string, len(s.doc.Grants))
+	for i, grant := range s.doc.Grants {
+		keys[i] = grant.RelationKey
+	}
+	return keys
+}
+
+// CreateTime returns when the secret was created.
+func (s *Secret) CreateTime() time.Time {
+	return s.doc.CreateTime
+}
+
+// UpdateTime returns when the secret's value was last changed.
+func (s *Secret) UpdateTime() time.Time {
+	return s.doc.UpdateTime
+}
+
+// Value returns the decrypted value of the secret.
+func (s *Secret) Value() (string, error) {
+	key, err := s.st.secretKey()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(s.doc.Value) < gcm.NonceSize() {
+		return "", errors.Errorf("secret %q value corrupt", s.ID())
+	}
+	nonce, ciphertext := s.doc.Value[:gcm.NonceSize()], s.doc.Value[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(s.ID()))
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot decrypt secret %q", s.ID())
+	}
+	return string(plaintext), nil
+}
+
+// CanRead returns whether the named application may read the secret:
+// either it owns the secret, or it has been granted access on a relation
+// that still exists.
+func (s *Secret) CanRead(application string) (bool, error) {
+	if application == s.doc.Owner {
+		return true, nil
+	}
+	for _, grant := range s.doc.Grants {
+		rel, err := s.st.Relation(grant.RelationID)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if _, err := rel.Endpoint(application); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SecretParams holds the details of a secret to save.
+type SecretParams struct {
+	// Owner is the name of the application owning the secret.
+	Owner string
+
+	// Name is the name of the secret, unique to its owner.
+	Name string
+
+	// Value is the value of the secret.
+	Value string
+
+	// Description describes the secret.
+	Description string
+
+	// RotateInterval is how often the secret should be rotated,
+	// or zero if it is not rotated.
+	RotateInterval time.Duration
+}
+
+// Validate returns an error if the params are not valid.
+func (p SecretParams) Validate() error {
+	if !names.IsValidApplication(p.Owner) {
+		return errors.NotValidf("secret owner %q", p.Owner)
+	}
+	if !validSecretName.MatchString(p.Name) {
+		return errors.NotValidf("secret name %q", p.Name)
+	}
+	if p.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval %v", p.RotateInterval)
+	}
+	return nil
+}
+
+// SaveSecret creates the secret described by p, or updates its value,
+// description and rotate interval if it already exists. The secret's
+// revision increments whenever its value changes.
+func (st *State) SaveSecret(p SecretParams) (*Secret, error) {
+	if err := p.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := st.Application(p.Owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := SecretID(p.Owner, p.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := app.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q not alive", p.Owner)
+		}
+		keyOps, key, err := st.ensureSecretKeyOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := append(keyOps, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		})
+
+		now := st.clock().Now().Round(time.Second).UTC()
+		var nextRotateTime *time.Time
+		if p.RotateInterval > 0 {
+			next := now.Add(p.RotateInterval)
+			nextRotateTime = &next
+		}
+		existing, err := st.Secret(id)
+		if errors.IsNotFound(err) {
+			value, err := encryptSecret(key, id, p.Value)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &secretDoc{
+					Owner:          p.Owner,
+					Name:           p.Name,
+					Description:    p.Description,
+					Revision:       1,
+					Value:          value,
+					RotateInterval: p.RotateInterval,
+					NextRotateTime: nextRotateTime,
+					CreateTime:     now,
+					UpdateTime:     now,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		set := bson.D{
+			{"description", p.Description},
+			{"rotate-interval", p.RotateInterval},
+		}
+		if p.RotateInterval != existing.doc.RotateInterval {
+			set = append(set, bson.DocElem{"next-rotate-time", nextRotateTime})
+		}
+		oldValue, err := existing.Value()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldValue != p.Value {
+			value, err := encryptSecret(key, id, p.Value)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			set = append(set,
+				bson.DocElem{"value", value},
+				bson.DocElem{"revision", existing.doc.Revision + 1},
+				bson.DocElem{"update-time", now},
+			)
+		} else if p.Description == existing.doc.Description && p.RotateInterval == existing.doc.RotateInterval {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      secretsC,
+			Id:     id,
+			Assert: bson.D{{"revision", existing.doc.Revision}},
+			Update: bson.D{{"$set", set}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot save secret %q", id)
+	}
+	return st.Secret(id)
+}
+
+// Secret returns the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	if !IsValidSecretID(id) {
+		return nil, errors.NotValidf("secret id %q", id)
+	}
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var doc secretDoc
+	if err := secrets.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secrets returns the secrets owned by the named application, or all
+// secrets in the model if owner is empty, ordered by id.
+func (st *State) Secrets(owner string) ([]*Secret, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	query := bson.D{}
+	if owner != "" {
+		query = bson.D{{"owner", owner}}
+	}
+	var docs []secretDoc
+	if err := secrets.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// GrantSecret gives the remote application on the relation with the
+// given key access to the secret. The grant lasts as long as the
+// relation.
+func (st *State) GrantSecret(id string, relationKey string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rel, err := st.KeyRelation(relationKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if rel.Life() != Alive {
+			return nil, errors.Errorf("relation %q not alive", relationKey)
+		}
+		if _, err := rel.Endpoint(secret.doc.Owner); err != nil {
+			return nil, errors.Errorf("relation %q does not involve application %q", relationKey, secret.doc.Owner)
+		}
+		for _, grant := range secret.doc.Grants {
+			if grant.RelationID == rel.Id() {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		grant := secretGrantDoc{
+			RelationID:  rel.Id(),
+			RelationKey: relationKey,
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     rel.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     id,
+			Assert: bson.D{{"grants.relation-id", bson.D{{"$ne", rel.Id()}}}},
+			Update: bson.D{{"$push", bson.D{{"grants", grant}}}},
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "cannot grant secret %q", id)
+}
+
+// SecretRotated records that the secret has been rotated, so that it is
+// next due to be rotated after its rotate interval.
+func (st *State) SecretRotated(id string) error {
+	secret, err := st.Secret(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.doc.RotateInterval == 0 {
+		return nil
+	}
+	next := st.clock().Now().Round(time.Second).UTC().Add(secret.doc.RotateInterval)
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"next-rotate-time", next}}}},
+	}}
+	return errors.Annotatef(st.db().RunTransaction(ops), "cannot record rotation of secret %q", id)
+}
+
+// WatchSecrets returns a watcher that notifies when the secrets owned by
+// the named application change.
+func (st *State) WatchSecrets(owner string) NotifyWatcher {
+	prefix := owner + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newNotifyCollWatcher(st, secretsC, filter)
+}
+
+// removeSecretsOps returns the operations to remove the secrets owned
+// by the named application.
+func removeSecretsOps(st *State, owner string) ([]txn.Op, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+	var docs []secretDoc
+	if err := secrets.Find(bson.D{{"owner", owner}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading application %q secrets", owner)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     st.localID(doc.DocID),
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// SecretsKey returns the controller key which wraps each model's secret
+// key, so that it can be handed to controllers joining the controller.
+func (st *State) SecretsKey() []byte {
+	return st.secretsKey
+}
+
+// secretKey returns the model's secret key, unwrapped
+// with the controller's secrets key.
+func (st *State) secretKey() ([]byte, error) {
+	if len(st.secretsKey) == 0 {
+		return nil, errors.NotSupportedf("secrets without a controller secrets key")
+	}
+	keys, closer := st.db().GetCollection(secretKeysC)
+	defer closer()
+	var doc secretKeyDoc
+	if err := keys.FindId(secretKeyId).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret key")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get secret key")
+	}
+	gcm, err := newSecretCipher(st.secretsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(doc.WrappedKey) < gcm.NonceSize() {
+		return nil, errors.New("secret key corrupt")
+	}
+	nonce, ciphertext := doc.WrappedKey[:gcm.NonceSize()], doc.WrappedKey[gcm.NonceSize():]
+	key, err := gcm.Open(nil, nonce, ciphertext, []byte(st.ModelUUID()))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unwrap secret key")
+	}
+	return key, nil
+}
+
+// ensureSecretKeyOps returns the model's secret key, along with the
+// operations needed to create it if it does not exist yet.
+func (st *State) ensureSecretKeyOps() ([]txn.Op, []byte, error) {
+	key, err := st.secretKey()
+	if err == nil {
+		return []txn.Op{{
+			C:      secretKeysC,
+			Id:     secretKeyId,
+			Assert: txn.DocExists,
+		}}, key, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	key = make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// The key is bound to the model, so that it
+	// cannot be unwrapped if copied to another.
+	wrappedKey, err := encryptSecret(st.secretsKey, st.ModelUUID(), string(key))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      secretKeysC,
+		Id:     secretKeyId,
+		Assert: txn.DocMissing,
+		Insert: &secretKeyDoc{WrappedKey: wrappedKey},
+	}}, key, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptSecret encrypts the value of the secret with the given id,
+// returning the ciphertext prefixed with the nonce used.
+func encryptSecret(key []byte, id, value string) ([]byte, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, []byte(value), []byte(id)), nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	mysql     *state.Application
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *SecretsSuite) saveSecret(c *gc.C, value string) *state.Secret {
+	secret, err := s.State.SaveSecret(state.SecretParams{
+		Owner:          "mysql",
+		Name:           "root-password",
+		Value:          value,
+		Description:    "the root password",
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestSaveSecret(c *gc.C) {
+	secret := s.saveSecret(c, "sekrit")
+	c.Assert(secret.ID(), gc.Equals, "mysql/root-password")
+	c.Assert(secret.Owner(), gc.Equals, "mysql")
+	c.Assert(secret.Name(), gc.Equals, "root-password")
+	c.Assert(secret.Description(), gc.Equals, "the root password")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	c.Assert(secret.RotateInterval(), gc.Equals, time.Hour)
+	c.Assert(secret.NextRotateTime(), gc.NotNil)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "sekrit")
+
+	secret, err = s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Revision(), gc.Equals, 1)
+}
+
+func (s *SecretsSuite) TestSaveSecretUpdatesValue(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	secret := s.saveSecret(c, "sekrit")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+
+	secret = s.saveSecret(c, "new-sekrit")
+	c.Assert(secret.Revision(), gc.Equals, 2)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "new-sekrit")
+}
+
+func (s *SecretsSuite) TestSaveSecretInvalid(c *gc.C) {
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "mysql", Name: "Root Password"})
+	c.Assert(err, gc.ErrorMatches, `secret name "Root Password" not valid`)
+	_, err = s.State.SaveSecret(state.SecretParams{Owner: "mysql", Name: "password", RotateInterval: -time.Hour})
+	c.Assert(err, gc.ErrorMatches, `negative rotate interval -1h0m0s not valid`)
+	_, err = s.State.SaveSecret(state.SecretParams{Owner: "nope", Name: "password"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretValueEncrypted(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	secrets, closer := state.GetCollection(s.State, "secrets")
+	defer closer()
+	var doc bson.M
+	err := secrets.FindId("mysql/root-password").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	value, ok := doc["value"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(bytes.Contains(value, []byte("sekrit")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretKeyWrapped(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	keys, closer := state.GetCollection(s.State, "secretKeys")
+	defer closer()
+	var doc bson.M
+	err := keys.FindId("secrets").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["key"], gc.IsNil)
+	_, ok := doc["wrapped-key"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+
+	secret, err := s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	state.SetSecretsKey(s.State, []byte("fedcba9876543210fedcba9876543210"))
+	_, err = secret.Value()
+	c.Assert(err, gc.ErrorMatches, "cannot unwrap secret key: .*")
+
+	state.SetSecretsKey(s.State, nil)
+	_, err = secret.Value()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *SecretsSuite) TestSecretsKeySetOnUpgrade(c *gc.C) {
+	// A controller upgraded from a version without secrets
+	// has no secrets key until the upgrade step sets one.
+	state.SetSecretsKey(s.State, nil)
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "mysql", Name: "root-password", Value: "sekrit"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	s.StatePool.SetSecretsKey([]byte("0123456789abcdef0123456789abcdef"))
+	secret := s.saveSecret(c, "sekrit")
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "sekrit")
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Secret("root-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SecretsSuite) TestSecrets(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "wordpress", Name: "api-key", Value: "key"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := s.State.Secrets("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 2)
+	c.Assert(secrets[0].ID(), gc.Equals, "mysql/root-password")
+	c.Assert(secrets[1].ID(), gc.Equals, "wordpress/api-key")
+
+	secrets, err = s.State.Secrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Assert(secrets[0].ID(), gc.Equals, "wordpress/api-key")
+}
+
+func (s *SecretsSuite) TestGrantSecret(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	canRead, err := secret.CanRead("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead, jc.IsFalse)
+
+	err = s.State.GrantSecret("mysql/root-password", rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []string{rel.String()})
+	canRead, err = secret.CanRead("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead, jc.IsTrue)
+
+	// The grant lasts only as long as the relation.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	canRead, err = secret.CanRead("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead, jc.IsFalse)
+
+	// A new relation between the same endpoints does
+	// not inherit the grant, despite having the same key.
+	newRel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newRel.String(), gc.Equals, rel.String())
+	canRead, err = secret.CanRead("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(canRead, jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestGrantSecretOtherRelation(c *gc.C) {
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "wordpress", Name: "api-key", Value: "key"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.GrantSecret("wordpress/api-key", rel.String())
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "wordpress/api-key": relation ".*" does not involve application "wordpress"`)
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	secret := s.saveSecret(c, "sekrit")
+	before := *secret.NextRotateTime()
+	s.Clock.Advance(time.Minute)
+
+	err := s.State.SecretRotated("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.NextRotateTime().After(before), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestWatchSecrets(c *gc.C) {
+	w := s.State.WatchSecrets("mysql")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.saveSecret(c, "sekrit")
+	wc.AssertOneChange()
+
+	_, err := s.State.SaveSecret(state.SecretParams{Owner: "wordpress", Name: "api-key", Value: "key"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	s.saveSecret(c, "sekrit")
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret("mysql/root-password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
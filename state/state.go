@@ -93,6 +93,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// secretsKey is the controller key wrapping
+	// the keys encrypting each model's secrets.
+	secretsKey []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -147,6 +151,7 @@ func (st *State) newStateNoWorkers(modelUUID string) (*State, error) {
 		session.Close()
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	return newSt, nil
 }
 
@@ -49,6 +49,47 @@ func (s *UnitStatusSuite) TestSetUnknownStatus(c *gc.C) {
 	s.checkInitialStatus(c)
 }
 
+func (s *UnitStatusSuite) TestSetErrorStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Error,
+		Message: "broken",
+		Since:   &now,
+	}
+	err := s.unit.SetStatus(sInfo)
+	c.Check(err, gc.ErrorMatches, `cannot set invalid status "error"`)
+
+	s.checkInitialStatus(c)
+}
+
+func (s *UnitStatusSuite) TestSetHealthStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Error,
+		Message: `health probe "web" failed: timed out`,
+		Since:   &now,
+	}
+	err := s.unit.SetHealthStatus(sInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Error)
+	c.Check(statusInfo.Message, gc.Equals, `health probe "web" failed: timed out`)
+}
+
+func (s *UnitStatusSuite) TestSetHealthStatusInvalid(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status: status.Status("vliegkat"),
+		Since:  &now,
+	}
+	err := s.unit.SetHealthStatus(sInfo)
+	c.Check(err, gc.ErrorMatches, `cannot set invalid status "vliegkat"`)
+
+	s.checkInitialStatus(c)
+}
+
 func (s *UnitStatusSuite) TestSetOverwritesData(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
@@ -96,6 +96,14 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	DeferredEvents         []DeferredEvent `bson:"deferred-events,omitempty"`
+}
+
+// DeferredEvent is a custom event that one of a unit's hooks has
+// asked to be delivered to a later hook.
+type DeferredEvent struct {
+	Name string    `bson:"name"`
+	Time time.Time `bson:"time"`
 }
 
 // Unit represents the state of an application unit.
@@ -283,6 +291,30 @@ func (u *Unit) SetAgentVersion(v version.Binary) (err error) {
 	return nil
 }
 
+// DeferredEvents returns the custom events that the unit's hooks have
+// asked to be delivered to later hooks, as last reported by the unit.
+func (u *Unit) DeferredEvents() []DeferredEvent {
+	return u.doc.DeferredEvents
+}
+
+// SetDeferredEvents records the custom events that the unit's hooks
+// have asked to be delivered to later hooks, replacing any previously
+// recorded.
+func (u *Unit) SetDeferredEvents(events []DeferredEvent) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set deferred events for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"deferred-events", events}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	u.doc.DeferredEvents = events
+	return nil
+}
+
 // SetPassword sets the password for the machine's agent.
 func (u *Unit) SetPassword(password string) error {
 	if len(password) < utils.MinAgentPasswordLength {
@@ -1186,7 +1218,20 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 	if !status.ValidWorkloadStatus(unitStatus.Status) {
 		return errors.Errorf("cannot set invalid status %q", unitStatus.Status)
 	}
+	return u.setWorkloadStatus(unitStatus)
+}
+
+// SetHealthStatus sets the workload status of the unit while its
+// workload health probes are failing. Unlike SetStatus, it accepts
+// the error status, which is reserved for failing health probes.
+func (u *Unit) SetHealthStatus(unitStatus status.StatusInfo) error {
+	if unitStatus.Status != status.Error && !status.ValidWorkloadStatus(unitStatus.Status) {
+		return errors.Errorf("cannot set invalid status %q", unitStatus.Status)
+	}
+	return u.setWorkloadStatus(unitStatus)
+}
 
+func (u *Unit) setWorkloadStatus(unitStatus status.StatusInfo) error {
 	var newHistory *statusDoc
 	if u.modelType == ModelTypeCAAS {
 		// Caas Charms currently have no way to query workload status;
@@ -1055,6 +1055,27 @@ func (s *UnitSuite) TestTag(c *gc.C) {
 	c.Assert(s.unit.Tag().String(), gc.Equals, "unit-wordpress-0")
 }
 
+func (s *UnitSuite) TestSetDeferredEvents(c *gc.C) {
+	c.Assert(s.unit.DeferredEvents(), gc.HasLen, 0)
+	at := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	events := []state.DeferredEvent{{Name: "check-again", Time: at}}
+	err := s.unit.SetDeferredEvents(events)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DeferredEvents(), jc.DeepEquals, events)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.DeferredEvents(), gc.HasLen, 1)
+	c.Assert(unit.DeferredEvents()[0].Name, gc.Equals, "check-again")
+	c.Assert(unit.DeferredEvents()[0].Time.Equal(at), jc.IsTrue)
+
+	err = unit.SetDeferredEvents(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DeferredEvents(), gc.HasLen, 0)
+}
+
 func (s *UnitSuite) TestSetPassword(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	testSetPassword(c, func() (state.Authenticator, error) {
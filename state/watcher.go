@@ -1154,19 +1154,35 @@ func (w *RelationScopeWatcher) loop() error {
 
 // relationUnitsWatcher sends notifications of units entering and leaving the
 // scope of a RelationUnit, and changes to the settings of those units known
-// to have entered.
+// to have entered, and to the application settings of the watched
+// applications.
 type relationUnitsWatcher struct {
 	commonWatcher
 	sw       *RelationScopeWatcher
 	watching set.Strings
 	updates  chan watcher.Change
 	out      chan params.RelationUnitsChange
+
+	// appSettingsKeys maps the doc ids of the watched application
+	// settings to their application names.
+	appSettingsKeys map[string]string
 }
 
 // Watch returns a watcher that notifies of changes to conterpart units in
-// the relation.
+// the relation, and to the application settings of the counterpart
+// applications.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
+	related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName)
+	if err != nil {
+		// The unit's endpoint is always part of the relation, so
+		// this cannot happen.
+		logger.Errorf("cannot get related endpoints for %q: %v", ru.unitName, err)
+	}
+	var appNames []string
+	for _, ep := range related {
+		appNames = append(appNames, ep.ApplicationName)
+	}
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), ru.relation.Id(), appNames)
 }
 
 // WatchUnits returns a watcher that notifies of changes to the units of the
@@ -1185,20 +1201,36 @@ func (r *Relation) watchUnits(applicationName string, counterpart bool) (Relatio
 		return nil, errors.Errorf("%q endpoint is not globally scoped", ep.Name)
 	}
 	role := ep.Role
+	appNames := []string{applicationName}
 	if counterpart {
 		role = counterpartRole(role)
+		related, err := r.RelatedEndpoints(applicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appNames = nil
+		for _, ep := range related {
+			appNames = append(appNames, ep.ApplicationName)
+		}
 	}
 	rsw := watchRelationScope(r.st, r.globalScope(), role, "")
-	return newRelationUnitsWatcher(r.st, rsw), nil
+	return newRelationUnitsWatcher(r.st, rsw, r.Id(), appNames), nil
 }
 
-func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher) RelationUnitsWatcher {
+func newRelationUnitsWatcher(
+	backend modelBackend, sw *RelationScopeWatcher, relationId int, appNames []string,
+) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
-		commonWatcher: newCommonWatcher(backend),
-		sw:            sw,
-		watching:      make(set.Strings),
-		updates:       make(chan watcher.Change),
-		out:           make(chan params.RelationUnitsChange),
+		commonWatcher:   newCommonWatcher(backend),
+		sw:              sw,
+		watching:        make(set.Strings),
+		updates:         make(chan watcher.Change),
+		out:             make(chan params.RelationUnitsChange),
+		appSettingsKeys: make(map[string]string),
+	}
+	for _, appName := range appNames {
+		docID := backend.docID(relationApplicationSettingsKey(relationId, appName))
+		w.appSettingsKeys[docID] = appName
 	}
 	w.tomb.Go(func() error {
 		defer w.finish()
@@ -1216,7 +1248,7 @@ func (w *relationUnitsWatcher) Changes() <-chan params.RelationUnitsChange {
 }
 
 func emptyRelationUnitsChanges(changes *params.RelationUnitsChange) bool {
-	return len(changes.Changed)+len(changes.Departed) == 0
+	return len(changes.Changed)+len(changes.AppChanged)+len(changes.Departed) == 0
 }
 
 func setRelationUnitChangeVersion(changes *params.RelationUnitsChange, key string, version int64) {
@@ -1243,6 +1275,42 @@ func (w *relationUnitsWatcher) mergeSettings(changes *params.RelationUnitsChange
 	return doc.TxnRevno, nil
 }
 
+// mergeAppSettings reads the application settings node with the supplied
+// doc id, and sets a value in the AppChanged field keyed on the
+// application's name. It returns the mgo/txn revision number of the
+// settings node, or -1 if the application has no settings yet.
+func (w *relationUnitsWatcher) mergeAppSettings(changes *params.RelationUnitsChange, docID string) (int64, error) {
+	var doc struct {
+		TxnRevno int64 `bson:"txn-revno"`
+		Version  int64 `bson:"version"`
+	}
+	err := readSettingsDocInto(w.backend.db(), settingsC, docID, &doc)
+	if errors.IsNotFound(err) {
+		return -1, nil
+	} else if err != nil {
+		return -1, err
+	}
+	if changes.AppChanged == nil {
+		changes.AppChanged = map[string]int64{}
+	}
+	changes.AppChanged[w.appSettingsKeys[docID]] = doc.Version
+	return doc.TxnRevno, nil
+}
+
+// watchAppSettings starts watching the settings of the watched
+// applications, and merges their current versions into the supplied
+// RelationUnitsChange event.
+func (w *relationUnitsWatcher) watchAppSettings(changes *params.RelationUnitsChange) error {
+	for docID, appName := range w.appSettingsKeys {
+		revno, err := w.mergeAppSettings(changes, docID)
+		if err != nil {
+			return errors.Annotatef(err, "while merging application settings for %q", appName)
+		}
+		w.watcher.Watch(settingsC, docID, revno, w.updates)
+	}
+	return nil
+}
+
 // mergeScope starts and stops settings watches on the units entering and
 // leaving the scope in the supplied RelationScopeChange event, and applies
 // the expressed changes to the supplied RelationUnitsChange event.
@@ -1287,6 +1355,9 @@ func (w *relationUnitsWatcher) finish() {
 	for _, watchedValue := range w.watching.Values() {
 		w.watcher.Unwatch(settingsC, watchedValue, w.updates)
 	}
+	for docID := range w.appSettingsKeys {
+		w.watcher.Unwatch(settingsC, docID, w.updates)
+	}
 	close(w.updates)
 	close(w.out)
 	// w.tomb.Done()
@@ -1298,6 +1369,9 @@ func (w *relationUnitsWatcher) loop() (err error) {
 		changes     params.RelationUnitsChange
 		out         chan<- params.RelationUnitsChange
 	)
+	if err := w.watchAppSettings(&changes); err != nil {
+		return errors.Trace(err)
+	}
 	for {
 		select {
 		case <-w.watcher.Dead():
@@ -1321,6 +1395,13 @@ func (w *relationUnitsWatcher) loop() (err error) {
 			if !ok {
 				logger.Warningf("ignoring bad relation scope id: %#v", c.Id)
 			}
+			if _, ok := w.appSettingsKeys[id]; ok {
+				if _, err := w.mergeAppSettings(&changes, id); err != nil {
+					return errors.Annotatef(err, "application settings id %q", id)
+				}
+				out = w.out
+				continue
+			}
 			if _, err := w.mergeSettings(&changes, id); err != nil {
 				return errors.Annotatef(err, "relation scope id %q", id)
 			}
@@ -1764,6 +1845,12 @@ func (a *Application) WatchLeaderSettings() NotifyWatcher {
 	return newEntityWatcher(a.st, settingsC, docId)
 }
 
+// WatchEndpointBindings returns a watcher for observing changes to an
+// application's endpoint bindings.
+func (a *Application) WatchEndpointBindings() NotifyWatcher {
+	return newEntityWatcher(a.st, endpointBindingsC, a.st.docID(a.globalKey()))
+}
+
 // Watch returns a watcher for observing changes to a unit.
 func (u *Unit) Watch() NotifyWatcher {
 	return newEntityWatcher(u.st, unitsC, u.doc.DocID)
@@ -1850,6 +1937,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's configuration settings, as opposed to its charm
+// configuration settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -0,0 +1,39 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+)
+
+// EnsureSecretsKey generates the controller secrets key, which wraps the
+// keys encrypting each model's secrets, when upgrading a controller that
+// does not have one. The key is held in the state serving info of the
+// agent config rather than the database; the other controllers are sent
+// it with their state serving info by the agent facade.
+func EnsureSecretsKey(context Context) error {
+	agentConfig := context.AgentConfig()
+	info, ok := agentConfig.StateServingInfo()
+	if !ok {
+		return errors.New("state serving info not available")
+	}
+	if info.SecretsKey == "" {
+		secretsKey, err := agent.NewSecretsKey()
+		if err != nil {
+			return errors.Annotate(err, "generating secrets key")
+		}
+		info.SecretsKey = secretsKey
+		agentConfig.SetStateServingInfo(info)
+	}
+	secretsKey, err := agent.SecretsKey(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The state was opened without the key, so it must be
+	// given the key before any secrets can be saved.
+	context.State().SetSecretsKey(secretsKey)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+type secretsKeyState struct {
+	upgrades.StateBackend
+	secretsKey []byte
+}
+
+func (s *secretsKeyState) SetSecretsKey(key []byte) {
+	s.secretsKey = key
+}
+
+func (s *secretsSuite) TestEnsureSecretsKeyGenerated(c *gc.C) {
+	agentConfig := &mockAgentConfig{servingInfo: params.StateServingInfo{APIPort: 1234}}
+	st := &secretsKeyState{}
+	ctx := &mockContext{agentConfig: agentConfig, state: st}
+
+	err := upgrades.EnsureSecretsKey(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentConfig.servingInfo.APIPort, gc.Equals, 1234)
+	key, err := base64.StdEncoding.DecodeString(agentConfig.servingInfo.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.HasLen, 32)
+	c.Assert(st.secretsKey, jc.DeepEquals, key)
+}
+
+func (s *secretsSuite) TestEnsureSecretsKeyExisting(c *gc.C) {
+	existing := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	agentConfig := &mockAgentConfig{servingInfo: params.StateServingInfo{SecretsKey: existing}}
+	st := &secretsKeyState{}
+	ctx := &mockContext{agentConfig: agentConfig, state: st}
+
+	err := upgrades.EnsureSecretsKey(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentConfig.servingInfo.SecretsKey, gc.Equals, existing)
+	c.Assert(st.secretsKey, jc.DeepEquals, []byte("0123456789abcdef0123456789abcdef"))
+}
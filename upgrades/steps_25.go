@@ -25,5 +25,10 @@ func stateStepsFor25() []Step {
 				return context.State().MigrateAddModelPermissions()
 			},
 		},
+		&upgradeStep{
+			description: "generate controller secrets key",
+			targets:     []Target{DatabaseMaster},
+			run:         EnsureSecretsKey,
+		},
 	}
 }
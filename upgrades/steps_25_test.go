@@ -37,3 +37,9 @@ func (s *steps25Suite) TestMigrateAddModelPermissions(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps25Suite) TestEnsureSecretsKey(c *gc.C) {
+	step := findStateStep(c, v25, `generate controller secrets key`)
+	// Logic for step itself is tested in secrets_test.go.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -11,6 +12,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -21,11 +23,17 @@ type applicationWorker struct {
 	application       string
 	applicationGetter ApplicationGetter
 	serviceExposer    ServiceExposer
+	policyEnforcer    NetworkPolicyEnforcer
 
 	lifeGetter LifeGetter
 
+	// networkPolicies is true if ingress to the application
+	// is restricted to the applications it is related to.
+	networkPolicies bool
+
 	initial           bool
 	previouslyExposed bool
+	lastPolicy        *caas.NetworkPolicy
 }
 
 func newApplicationWorker(
@@ -34,7 +42,9 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	policyEnforcer NetworkPolicyEnforcer,
 	lifeGetter LifeGetter,
+	networkPolicies bool,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
 		controllerUUID:    controllerUUID,
@@ -42,7 +52,9 @@ func newApplicationWorker(
 		application:       application,
 		applicationGetter: applicationGetter,
 		serviceExposer:    applicationExposer,
+		policyEnforcer:    policyEnforcer,
 		lifeGetter:        lifeGetter,
+		networkPolicies:   networkPolicies,
 		initial:           true,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
@@ -76,6 +88,13 @@ func (w *applicationWorker) loop() (err error) {
 	if err := w.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	configWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
 
 	for {
 		select {
@@ -91,31 +110,59 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+			if err := w.processConfigChange(); err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					return nil
+				}
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
+// processConfigChange updates the ingress of an exposed application
+// so changes to its config take effect without exposing it again.
+func (w *applicationWorker) processConfigChange() error {
+	if w.initial || !w.previouslyExposed {
+		return nil
+	}
+	return errors.Trace(w.updateExposure(true))
+}
+
 func (w *applicationWorker) processApplicationChange() (err error) {
 	exposed, err := w.applicationGetter.IsExposed(w.application)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
-		return nil
+	initial := w.initial
+	w.initial = false
+	if initial || exposed != w.previouslyExposed {
+		w.previouslyExposed = exposed
+		if err := w.updateExposure(exposed); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	return errors.Trace(w.updateNetworkPolicy(initial, exposed))
+}
 
-	w.initial = false
-	w.previouslyExposed = exposed
+func (w *applicationWorker) resourceTags() map[string]string {
+	return tags.ResourceTags(
+		names.NewModelTag(w.modelUUID),
+		names.NewControllerTag(w.controllerUUID),
+	)
+}
+
+func (w *applicationWorker) updateExposure(exposed bool) error {
 	if exposed {
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		resourceTags := tags.ResourceTags(
-			names.NewModelTag(w.modelUUID),
-			names.NewControllerTag(w.controllerUUID),
-		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		if err := w.serviceExposer.ExposeService(w.application, w.resourceTags(), appConfig); err != nil {
 			return errors.Trace(err)
 		}
 		return nil
@@ -125,3 +172,34 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+// updateNetworkPolicy allows ingress to the application from the
+// applications it is related to, or from anywhere once exposed.
+// Relations change the application, so its watcher fires when
+// they are added or removed.
+func (w *applicationWorker) updateNetworkPolicy(initial, exposed bool) error {
+	if !w.networkPolicies {
+		if initial {
+			// Remove any policy created while network
+			// policies were enabled for the model.
+			return errors.Trace(w.policyEnforcer.DeleteNetworkPolicy(w.application))
+		}
+		return nil
+	}
+	related, err := w.applicationGetter.RelatedApplications(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := caas.NetworkPolicy{
+		AllowedApplications: related,
+		AllowAll:            exposed,
+	}
+	if w.lastPolicy != nil && reflect.DeepEqual(*w.lastPolicy, policy) {
+		return nil
+	}
+	if err := w.policyEnforcer.EnsureNetworkPolicy(w.application, w.resourceTags(), policy); err != nil {
+		return errors.Trace(err)
+	}
+	w.lastPolicy = &policy
+	return nil
+}
@@ -3,9 +3,19 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyEnforcer provides an interface for restricting
+// ingress to the pods of an application.
+type NetworkPolicyEnforcer interface {
+	EnsureNetworkPolicy(appName string, resourceTags map[string]string, policy caas.NetworkPolicy) error
+	DeleteNetworkPolicy(appName string) error
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 // Client provides an interface for interacting with the
@@ -15,6 +16,7 @@ import (
 type Client interface {
 	ApplicationGetter
 	LifeGetter
+	ModelConfigGetter
 }
 
 // ApplicationGetter provides an interface for
@@ -24,8 +26,17 @@ type Client interface {
 type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	WatchApplication(string) (watcher.NotifyWatcher, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	RelatedApplications(string) ([]string, error)
+}
+
+// ModelConfigGetter provides an interface for watching
+// and fetching the model config.
+type ModelConfigGetter interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -76,11 +76,13 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 
 	client := config.NewClient(apiCaller)
 	w, err := config.NewWorker(Config{
-		ControllerUUID:    config.ControllerUUID,
-		ModelUUID:         config.ModelUUID,
-		ApplicationGetter: client,
-		LifeGetter:        client,
-		ServiceExposer:    broker,
+		ControllerUUID:        config.ControllerUUID,
+		ModelUUID:             config.ModelUUID,
+		ApplicationGetter:     client,
+		LifeGetter:            client,
+		ModelConfigGetter:     client,
+		ServiceExposer:        broker,
+		NetworkPolicyEnforcer: broker,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -142,10 +142,12 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	config := args[0].(caasfirewaller.Config)
 
 	c.Assert(config, jc.DeepEquals, caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.client,
-		ServiceExposer:    &s.broker,
-		LifeGetter:        &s.client,
+		ControllerUUID:        coretesting.ControllerTag.Id(),
+		ModelUUID:             coretesting.ModelTag.Id(),
+		ApplicationGetter:     &s.client,
+		LifeGetter:            &s.client,
+		ModelConfigGetter:     &s.client,
+		ServiceExposer:        &s.broker,
+		NetworkPolicyEnforcer: &s.broker,
 	})
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
 
@@ -47,9 +48,11 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher    *watchertest.MockStringsWatcher
+	appWatcher    *watchertest.MockNotifyWatcher
+	configWatcher *watchertest.MockNotifyWatcher
+	exposed       bool
+	related       []string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -68,6 +71,14 @@ func (m *mockApplicationGetter) WatchApplication(appName string) (watcher.Notify
 	return m.appWatcher, nil
 }
 
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
 func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 	m.MethodCall(m, "IsExposed", appName)
 	if err := m.NextErr(); err != nil {
@@ -81,6 +92,14 @@ func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.C
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (m *mockApplicationGetter) RelatedApplications(appName string) ([]string, error) {
+	m.MethodCall(m, "RelatedApplications", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.related, nil
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	life life.Value
@@ -93,3 +112,42 @@ func (m *mockLifeGetter) Life(entityName string) (life.Value, error) {
 	}
 	return m.life, nil
 }
+
+type mockModelConfigGetter struct {
+	testing.Stub
+	watcher *watchertest.MockNotifyWatcher
+	config  *config.Config
+}
+
+func (m *mockModelConfigGetter) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+func (m *mockModelConfigGetter) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.config, nil
+}
+
+type mockNetworkPolicyEnforcer struct {
+	testing.Stub
+	changed chan<- struct{}
+}
+
+func (m *mockNetworkPolicyEnforcer) EnsureNetworkPolicy(appName string, resourceTags map[string]string, policy caas.NetworkPolicy) error {
+	m.MethodCall(m, "EnsureNetworkPolicy", appName, resourceTags, policy)
+	m.changed <- struct{}{}
+	return m.NextErr()
+}
+
+func (m *mockNetworkPolicyEnforcer) DeleteNetworkPolicy(appName string) error {
+	m.MethodCall(m, "DeleteNetworkPolicy", appName)
+	m.changed <- struct{}{}
+	return m.NextErr()
+}
@@ -16,11 +16,13 @@ var logger = loggo.GetLogger("juju.workers.caasfirewaller")
 
 // Config holds configuration for the CAAS unit firewaller worker.
 type Config struct {
-	ControllerUUID    string
-	ModelUUID         string
-	ApplicationGetter ApplicationGetter
-	LifeGetter        LifeGetter
-	ServiceExposer    ServiceExposer
+	ControllerUUID        string
+	ModelUUID             string
+	ApplicationGetter     ApplicationGetter
+	LifeGetter            LifeGetter
+	ModelConfigGetter     ModelConfigGetter
+	ServiceExposer        ServiceExposer
+	NetworkPolicyEnforcer NetworkPolicyEnforcer
 }
 
 // Validate validates the worker configuration.
@@ -40,6 +42,12 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.ModelConfigGetter == nil {
+		return errors.NotValidf("missing ModelConfigGetter")
+	}
+	if config.NetworkPolicyEnforcer == nil {
+		return errors.NotValidf("missing NetworkPolicyEnforcer")
+	}
 	return nil
 }
 
@@ -72,6 +80,18 @@ func (p *firewaller) Wait() error {
 }
 
 func (p *firewaller) loop() error {
+	configWatcher, err := p.config.ModelConfigGetter.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	networkPolicies, err := p.networkPolicies()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	w, err := p.config.ApplicationGetter.WatchApplications()
 	if err != nil {
 		return errors.Trace(err)
@@ -85,6 +105,31 @@ func (p *firewaller) loop() error {
 		select {
 		case <-p.catacomb.Dying():
 			return p.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed channel")
+			}
+			enabled, err := p.networkPolicies()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if enabled == networkPolicies {
+				continue
+			}
+			logger.Debugf("network policies enabled: %v", enabled)
+			networkPolicies = enabled
+			// Restart the application workers so they
+			// create or delete their network policies.
+			for appId, w := range appWorkers {
+				if err := worker.Stop(w); err != nil {
+					logger.Errorf("error stopping caas firewaller: %v", err)
+				}
+				w, err := p.startApplicationWorker(appId, networkPolicies)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				appWorkers[appId] = w
+			}
 		case apps, ok := <-w.Changes():
 			if !ok {
 				return errors.New("watcher closed channel")
@@ -109,20 +154,40 @@ func (p *firewaller) loop() error {
 					// not yet watching it and it's dead.
 					continue
 				}
-				w, err := newApplicationWorker(
-					p.config.ControllerUUID,
-					p.config.ModelUUID,
-					appId,
-					p.config.ApplicationGetter,
-					p.config.ServiceExposer,
-					p.config.LifeGetter,
-				)
+				w, err := p.startApplicationWorker(appId, networkPolicies)
 				if err != nil {
 					return errors.Trace(err)
 				}
 				appWorkers[appId] = w
-				p.catacomb.Add(w)
 			}
 		}
 	}
 }
+
+func (p *firewaller) startApplicationWorker(appId string, networkPolicies bool) (worker.Worker, error) {
+	w, err := newApplicationWorker(
+		p.config.ControllerUUID,
+		p.config.ModelUUID,
+		appId,
+		p.config.ApplicationGetter,
+		p.config.ServiceExposer,
+		p.config.NetworkPolicyEnforcer,
+		p.config.LifeGetter,
+		networkPolicies,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p.catacomb.Add(w)
+	return w, nil
+}
+
+// networkPolicies returns whether the model config
+// enables network policies for the applications.
+func (p *firewaller) networkPolicies() (bool, error) {
+	cfg, err := p.config.ModelConfigGetter.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.KubernetesNetworkPolicies(), nil
+}
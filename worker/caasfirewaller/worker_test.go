@@ -12,9 +12,11 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
@@ -26,11 +28,16 @@ type WorkerSuite struct {
 	applicationGetter mockApplicationGetter
 	serviceExposer    mockServiceExposer
 	lifeGetter        mockLifeGetter
-
-	applicationChanges chan []string
-	appExposedChange   chan struct{}
-	serviceExposed     chan struct{}
-	serviceUnexposed   chan struct{}
+	modelConfigGetter mockModelConfigGetter
+	policyEnforcer    mockNetworkPolicyEnforcer
+
+	applicationChanges  chan []string
+	appExposedChange    chan struct{}
+	appConfigChange     chan struct{}
+	serviceExposed      chan struct{}
+	serviceUnexposed    chan struct{}
+	modelConfigChanges  chan struct{}
+	networkPolicyChange chan struct{}
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -40,12 +47,16 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.appExposedChange = make(chan struct{})
+	s.appConfigChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.modelConfigChanges = make(chan struct{})
+	s.networkPolicyChange = make(chan struct{}, 10)
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:    watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:    watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		configWatcher: watchertest.NewMockNotifyWatcher(s.appConfigChange),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
 
@@ -57,12 +68,39 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		unexposed: s.serviceUnexposed,
 	}
 
+	s.modelConfigGetter = mockModelConfigGetter{
+		watcher: watchertest.NewMockNotifyWatcher(s.modelConfigChanges),
+		config:  s.modelConfig(c, false),
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.modelConfigGetter.watcher) })
+	s.policyEnforcer = mockNetworkPolicyEnforcer{
+		changed: s.networkPolicyChange,
+	}
+
 	s.config = caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
+		ControllerUUID:        coretesting.ControllerTag.Id(),
+		ModelUUID:             coretesting.ModelTag.Id(),
+		ApplicationGetter:     &s.applicationGetter,
+		ServiceExposer:        &s.serviceExposer,
+		LifeGetter:            &s.lifeGetter,
+		ModelConfigGetter:     &s.modelConfigGetter,
+		NetworkPolicyEnforcer: &s.policyEnforcer,
+	}
+}
+
+func (s *WorkerSuite) modelConfig(c *gc.C, networkPolicies bool) *config.Config {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"kubernetes-network-policies": networkPolicies,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *WorkerSuite) waitNetworkPolicyChange(c *gc.C) {
+	select {
+	case <-s.networkPolicyChange:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy change")
 	}
 }
 
@@ -94,6 +132,14 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.ModelConfigGetter = nil
+	}, `missing ModelConfigGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyEnforcer = nil
+	}, `missing NetworkPolicyEnforcer not valid`)
 }
 
 func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasfirewaller.Config), expect string) {
@@ -153,6 +199,67 @@ func (s *WorkerSuite) TestExposedChange(c *gc.C) {
 		application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestConfigChangeUpdatesExposed(c *gc.C) {
+	s.applicationGetter.exposed = true
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	select {
+	case s.appConfigChange <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed again")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+}
+
+func (s *WorkerSuite) TestConfigChangeIgnoredWhenUnexposed(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+
+	select {
+	case s.appConfigChange <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.serviceExposer.CheckCallNames(c, "UnexposeService")
+}
+
 func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -252,3 +359,114 @@ func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
 	err = workertest.CheckKilled(c, w)
 	c.Assert(err, gc.ErrorMatches, "splat")
 }
+
+func (s *WorkerSuite) TestNetworkPoliciesDisabled(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	<-s.serviceUnexposed
+
+	// Any policy left from when network policies were
+	// enabled is removed when the worker starts.
+	s.waitNetworkPolicyChange(c)
+	s.policyEnforcer.CheckCallNames(c, "DeleteNetworkPolicy")
+	s.policyEnforcer.CheckCall(c, 0, "DeleteNetworkPolicy", "gitlab")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplication", "WatchApplicationConfig", "IsExposed")
+}
+
+func (s *WorkerSuite) TestNetworkPolicyRelated(c *gc.C) {
+	s.modelConfigGetter.config = s.modelConfig(c, true)
+	s.applicationGetter.related = []string{"mariadb"}
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	<-s.serviceUnexposed
+	s.waitNetworkPolicyChange(c)
+
+	// No change to the relations or exposure means
+	// the policy is left alone.
+	s.sendApplicationExposedChange(c)
+
+	s.applicationGetter.related = []string{"mariadb", "redis"}
+	s.sendApplicationExposedChange(c)
+	s.waitNetworkPolicyChange(c)
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	<-s.serviceExposed
+	s.waitNetworkPolicyChange(c)
+
+	resourceTags := map[string]string{
+		"juju-controller-uuid": coretesting.ControllerTag.Id(),
+		"juju-model-uuid":      coretesting.ModelTag.Id(),
+	}
+	s.policyEnforcer.CheckCalls(c, []testing.StubCall{{
+		"EnsureNetworkPolicy", []interface{}{"gitlab", resourceTags, caas.NetworkPolicy{
+			AllowedApplications: []string{"mariadb"},
+		}},
+	}, {
+		"EnsureNetworkPolicy", []interface{}{"gitlab", resourceTags, caas.NetworkPolicy{
+			AllowedApplications: []string{"mariadb", "redis"},
+		}},
+	}, {
+		"EnsureNetworkPolicy", []interface{}{"gitlab", resourceTags, caas.NetworkPolicy{
+			AllowedApplications: []string{"mariadb", "redis"},
+			AllowAll:            true,
+		}},
+	}})
+}
+
+func (s *WorkerSuite) TestNetworkPoliciesEnabledByModelConfig(c *gc.C) {
+	s.applicationGetter.related = []string{"mariadb"}
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	<-s.serviceUnexposed
+	s.waitNetworkPolicyChange(c)
+
+	// The application watchers are stopped along with the
+	// application worker, so the restarted worker needs new ones.
+	s.applicationGetter.appWatcher = watchertest.NewMockNotifyWatcher(s.appExposedChange)
+	s.applicationGetter.configWatcher = watchertest.NewMockNotifyWatcher(s.appConfigChange)
+	s.modelConfigGetter.config = s.modelConfig(c, true)
+	select {
+	case s.modelConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending model config change")
+	}
+
+	// The application worker is restarted with
+	// network policies enabled.
+	s.sendApplicationExposedChange(c)
+	<-s.serviceUnexposed
+	s.waitNetworkPolicyChange(c)
+	s.policyEnforcer.CheckCallNames(c, "DeleteNetworkPolicy", "EnsureNetworkPolicy")
+	s.policyEnforcer.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id(),
+		},
+		caas.NetworkPolicy{AllowedApplications: []string{"mariadb"}},
+	)
+}
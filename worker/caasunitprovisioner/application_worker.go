@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -102,6 +103,8 @@ func (aw *applicationWorker) loop() error {
 	// Cache the last reported status information
 	// so we only report true changes.
 	lastReportedStatus := make(map[string]status.StatusInfo)
+	var lastRollout *caas.RolloutStatus
+	var lastWarning *status.StatusInfo
 
 	for {
 		// The caas watcher can just die from underneath so recreate if needed.
@@ -200,12 +203,48 @@ func (aw *applicationWorker) loop() error {
 				}
 				args.Units = append(args.Units, unitParams)
 			}
+			// An autoscaler decides how many units the application
+			// has, so report its choice as the application's scale.
+			autoscaler, err := aw.containerBroker.AutoscalerStatus(aw.application)
+			if err == nil && autoscaler.DesiredReplicas > 0 {
+				args.Scale = &autoscaler.DesiredReplicas
+			} else if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			// The units of a daemon application follow the nodes of
+			// the cluster, so report their number as its scale.
+			daemonScale, err := aw.containerBroker.DaemonScale(aw.application)
+			if err == nil && daemonScale > 0 {
+				args.Scale = &daemonScale
+			} else if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			rollout, err := aw.containerBroker.RolloutStatus(aw.application)
+			if errors.IsNotFound(err) {
+				rollout = nil
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			warning, err := aw.containerBroker.ApplicationWarning(aw.application)
+			if errors.IsNotFound(err) {
+				warning = nil
+			} else if err != nil {
+				return errors.Trace(err)
+			}
 			if err := aw.unitUpdater.UpdateUnits(args); err != nil {
 				// We can ignore not found errors as the worker will get stopped anyway.
 				if !errors.IsNotFound(err) {
 					return errors.Trace(err)
 				}
 			}
+			if !reflect.DeepEqual(rollout, lastRollout) || !reflect.DeepEqual(warning, lastWarning) {
+				logger.Debugf("rollout for %v: %+v, warning: %+v", aw.application, rollout, warning)
+				lastRollout = rollout
+				lastWarning = warning
+				if err := aw.updateOperatorStatus(lastRollout, lastWarning); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case _, ok := <-appOperatorWatcher.Changes():
 			if !ok {
 				logger.Debugf("%v", appOperatorWatcher.Wait())
@@ -214,20 +253,46 @@ func (aw *applicationWorker) loop() error {
 				continue
 			}
 			logger.Debugf("operator update for %v", aw.application)
-			operator, err := aw.containerBroker.Operator(aw.application)
-			if errors.IsNotFound(err) {
-				logger.Debugf("pod not found for application %q", aw.application)
-				if err := aw.provisioningStatusSetter.SetOperatorStatus(aw.application, status.Terminated, "", nil); err != nil {
-					return errors.Trace(err)
-				}
-			} else if err != nil {
+			if err := aw.updateOperatorStatus(lastRollout, lastWarning); err != nil {
 				return errors.Trace(err)
-			} else {
-				if err := aw.provisioningStatusSetter.SetOperatorStatus(aw.application, operator.Status.Status, operator.Status.Message, operator.Status.Data); err != nil {
-					return errors.Trace(err)
-				}
 			}
 		}
 
 	}
 }
+
+// updateOperatorStatus sets the operator status, which is shown as the
+// application status, from the operator pod. While the application's
+// pods are being replaced after a pod spec change, the progress or
+// failure of the rollout is shown instead, and while the substrate
+// cannot create the application's pods, the reason is shown.
+func (aw *applicationWorker) updateOperatorStatus(rollout *caas.RolloutStatus, warning *status.StatusInfo) error {
+	operator, err := aw.containerBroker.Operator(aw.application)
+	if errors.IsNotFound(err) {
+		logger.Debugf("pod not found for application %q", aw.application)
+		return errors.Trace(aw.provisioningStatusSetter.SetOperatorStatus(aw.application, status.Terminated, "", nil))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	operatorStatus := operator.Status
+	operatorRunning := operatorStatus.Status == status.Running || operatorStatus.Status == status.Active
+	if rollout != nil && !rollout.Complete && operatorRunning {
+		if rollout.Failed {
+			operatorStatus = status.StatusInfo{
+				Status:  status.Error,
+				Message: "rollout failed: " + rollout.Message,
+			}
+		} else {
+			operatorStatus = status.StatusInfo{
+				Status:  status.Maintenance,
+				Message: "rolling out: " + rollout.Message,
+			}
+		}
+	}
+	if warning != nil && operatorRunning {
+		operatorStatus = *warning
+	}
+	return errors.Trace(aw.provisioningStatusSetter.SetOperatorStatus(
+		aw.application, operatorStatus.Status, operatorStatus.Message, operatorStatus.Data,
+	))
+}
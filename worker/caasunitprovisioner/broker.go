@@ -6,6 +6,7 @@ package caasunitprovisioner
 import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -13,8 +14,12 @@ type ContainerBroker interface {
 	Provider() caas.ContainerEnvironProvider
 	WatchUnits(appName string) (watcher.NotifyWatcher, error)
 	Units(appName string) ([]caas.Unit, error)
+	DaemonScale(appName string) (int, error)
 	WatchOperator(string) (watcher.NotifyWatcher, error)
 	Operator(string) (*caas.Operator, error)
+	RolloutStatus(appName string) (*caas.RolloutStatus, error)
+	AutoscalerStatus(appName string) (*caas.AutoscalerStatus, error)
+	ApplicationWarning(appName string) (*status.StatusInfo, error)
 }
 
 type ServiceBroker interface {
@@ -68,6 +68,12 @@ func (w *deploymentWorker) loop() error {
 	}
 	w.catacomb.Add(appScaleWatcher)
 
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.catacomb.Add(appConfigWatcher)
+
 	var (
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
@@ -78,6 +84,9 @@ func (w *deploymentWorker) loop() error {
 
 	gotSpecNotify := false
 	serviceUpdated := false
+	// The config of the application, such as its autoscaler
+	// settings, is passed to the broker along with the spec.
+	configChanged := false
 	scale := 0
 	for {
 		select {
@@ -106,6 +115,14 @@ func (w *deploymentWorker) loop() error {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-appConfigWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			configChanged = true
+			if scale == 0 {
+				continue
+			}
 		}
 		if scale == 0 {
 			if cw != nil {
@@ -134,12 +151,13 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
-		if scale == currentScale && specStr == currentSpec {
+		if scale == currentScale && specStr == currentSpec && !configChanged {
 			continue
 		}
 
 		currentScale = scale
 		currentSpec = specStr
+		configChanged = false
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
 
@@ -87,6 +88,10 @@ type mockContainerBroker struct {
 	operatorWatcher        *watchertest.MockNotifyWatcher
 	reportedUnitStatus     status.Status
 	reportedOperatorStatus status.Status
+	rolloutStatus          *caas.RolloutStatus
+	autoscalerStatus       *caas.AutoscalerStatus
+	daemonScale            int
+	applicationWarning     *status.StatusInfo
 	podSpec                *caas.PodSpec
 }
 
@@ -123,6 +128,14 @@ func (m *mockContainerBroker) Units(appName string) ([]caas.Unit, error) {
 		m.NextErr()
 }
 
+func (m *mockContainerBroker) DaemonScale(appName string) (int, error) {
+	m.MethodCall(m, "DaemonScale", appName)
+	if m.daemonScale == 0 {
+		return 0, errors.NotFoundf("daemon set for application %q", appName)
+	}
+	return m.daemonScale, nil
+}
+
 func (m *mockContainerBroker) Operator(appName string) (*caas.Operator, error) {
 	m.MethodCall(m, "Operator", appName)
 	return &caas.Operator{
@@ -135,6 +148,30 @@ func (m *mockContainerBroker) Operator(appName string) (*caas.Operator, error) {
 	}, nil
 }
 
+func (m *mockContainerBroker) RolloutStatus(appName string) (*caas.RolloutStatus, error) {
+	m.MethodCall(m, "RolloutStatus", appName)
+	if m.rolloutStatus == nil {
+		return nil, errors.NotFoundf("pods for application %q", appName)
+	}
+	return m.rolloutStatus, nil
+}
+
+func (m *mockContainerBroker) AutoscalerStatus(appName string) (*caas.AutoscalerStatus, error) {
+	m.MethodCall(m, "AutoscalerStatus", appName)
+	if m.autoscalerStatus == nil {
+		return nil, errors.NotFoundf("autoscaler for application %q", appName)
+	}
+	return m.autoscalerStatus, nil
+}
+
+func (m *mockContainerBroker) ApplicationWarning(appName string) (*status.StatusInfo, error) {
+	m.MethodCall(m, "ApplicationWarning", appName)
+	if m.applicationWarning == nil {
+		return nil, errors.NotFoundf("warning for application %q", appName)
+	}
+	return m.applicationWarning, nil
+}
+
 func (m *mockContainerBroker) WatchOperator(appName string) (watcher.NotifyWatcher, error) {
 	m.MethodCall(m, "WatchOperator", appName)
 	return m.operatorWatcher, m.NextErr()
@@ -145,6 +182,9 @@ type mockApplicationGetter struct {
 	watcher      *watchertest.MockStringsWatcher
 	scaleWatcher *watchertest.MockNotifyWatcher
 	scale        int
+
+	configWatcher *watchertest.MockNotifyWatcher
+	config        application.ConfigAttributes
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -157,11 +197,22 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
 }
 
+func (a *mockApplicationGetter) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationConfig", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.configWatcher, nil
+}
+
 func (a *mockApplicationGetter) WatchApplicationScale(application string) (watcher.NotifyWatcher, error) {
 	a.MethodCall(a, "WatchApplicationScale", application)
 	if err := a.NextErr(); err != nil {
@@ -45,6 +45,7 @@ type WorkerSuite struct {
 
 	applicationChanges      chan []string
 	applicationScaleChanges chan struct{}
+	appConfigChanges        chan struct{}
 	caasUnitsChanges        chan struct{}
 	caasOperatorChanges     chan struct{}
 	containerSpecChanges    chan struct{}
@@ -101,6 +102,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.applicationScaleChanges = make(chan struct{})
+	s.appConfigChanges = make(chan struct{})
 	s.caasUnitsChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
@@ -109,8 +111,9 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.serviceUpdated = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		watcher:      watchertest.NewMockStringsWatcher(s.applicationChanges),
-		scaleWatcher: watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		watcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		scaleWatcher:  watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		configWatcher: watchertest.NewMockNotifyWatcher(s.appConfigChanges),
 	}
 	s.applicationUpdater = mockApplicationUpdater{
 		updated: s.serviceUpdated,
@@ -261,7 +264,7 @@ func (s *WorkerSuite) TestScaleChanged(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationScale", "WatchApplicationConfig", "ApplicationScale", "ApplicationConfig")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
@@ -449,6 +452,49 @@ crd:
 	s.serviceBroker.CheckCall(c, 0, "EnsureCustomResourceDefinition", "gitlab", &anotherParsedSpec)
 }
 
+func (s *WorkerSuite) TestApplicationConfigChanged(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	s.applicationGetter.config = application.ConfigAttributes{
+		"kubernetes-autoscaling-max-replicas": 5,
+	}
+	select {
+	case s.appConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedServiceParams, 1, application.ConfigAttributes{"kubernetes-autoscaling-max-replicas": 5})
+
+	// Unsetting the maximum passes the config without it,
+	// so the broker removes the autoscaler.
+	s.serviceBroker.ResetCalls()
+	s.applicationGetter.config = application.ConfigAttributes{}
+	select {
+	case s.appConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedServiceParams, 1, application.ConfigAttributes{})
+}
+
 func (s *WorkerSuite) TestUnitAllRemoved(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -680,30 +726,186 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	})
 }
 
-func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expected status.Status) {
-	s.containerBroker.ResetCalls()
-	s.unitUpdater.ResetCalls()
-	s.containerBroker.reportedUnitStatus = reported
+func (s *WorkerSuite) TestUnitsChangeRollout(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	defer workertest.CleanKill(c, w)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) > 0 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+
+	s.containerBroker.reportedOperatorStatus = status.Active
+	s.containerBroker.rolloutStatus = &caas.RolloutStatus{
+		Desired: 2, Updated: 1, Ready: 2, Message: "1 of 2 pods updated",
+	}
+	s.assertRolloutStatus(c, status.Maintenance, "rolling out: 1 of 2 pods updated")
+
+	s.containerBroker.rolloutStatus = &caas.RolloutStatus{
+		Desired: 2, Updated: 1, Ready: 2, Failed: true, Message: "timed out progressing",
+	}
+	s.assertRolloutStatus(c, status.Error, "rollout failed: timed out progressing")
+
+	s.containerBroker.rolloutStatus = &caas.RolloutStatus{
+		Desired: 2, Updated: 2, Ready: 2, Complete: true,
+	}
+	s.assertRolloutStatus(c, status.Active, "testing 1. 2. 3.")
+}
+
+func (s *WorkerSuite) TestUnitsChangeApplicationWarning(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
 
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	defer workertest.CleanKill(c, w)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) > 0 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+
+	s.containerBroker.reportedOperatorStatus = status.Active
+	s.containerBroker.applicationWarning = &status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "exceeded quota: compute-resources",
+	}
+	s.assertRolloutStatus(c, status.Blocked, "exceeded quota: compute-resources")
+
+	s.containerBroker.applicationWarning = nil
+	s.assertRolloutStatus(c, status.Active, "testing 1. 2. 3.")
+}
+
+func (s *WorkerSuite) TestUnitsChangeAutoscaled(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	defer workertest.CleanKill(c, w)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) > 0 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+
+	s.containerBroker.autoscalerStatus = &caas.AutoscalerStatus{
+		MinReplicas: 1, MaxReplicas: 5, CurrentReplicas: 1, DesiredReplicas: 3,
+	}
+	s.unitUpdater.ResetCalls()
 	select {
 	case s.caasUnitsChanges <- struct{}{}:
 	case <-time.After(coretesting.LongWait):
 		c.Fatal("timed out sending units change")
 	}
 
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.unitUpdater.Calls()) > 0 {
+			break
+		}
+	}
+	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
+	args := s.unitUpdater.Calls()[0].Args[0].(params.UpdateApplicationUnits)
+	c.Assert(args.Scale, gc.NotNil)
+	c.Assert(*args.Scale, gc.Equals, 3)
+}
+
+func (s *WorkerSuite) TestUnitsChangeDaemon(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	defer workertest.CleanKill(c, w)
+
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
 		if len(s.containerBroker.Calls()) > 0 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "Units")
-	c.Assert(s.containerBroker.Calls()[0].Args, jc.DeepEquals, []interface{}{"gitlab"})
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+
+	s.containerBroker.daemonScale = 4
+	s.unitUpdater.ResetCalls()
+	select {
+	case s.caasUnitsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.unitUpdater.Calls()) > 0 {
+			break
+		}
+	}
+	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
+	args := s.unitUpdater.Calls()[0].Args[0].(params.UpdateApplicationUnits)
+	c.Assert(args.Scale, gc.NotNil)
+	c.Assert(*args.Scale, gc.Equals, 4)
+}
+
+func (s *WorkerSuite) assertRolloutStatus(c *gc.C, expected status.Status, message string) {
+	s.statusSetter.ResetCalls()
+	s.containerBroker.reportedUnitStatus = status.Running
+
+	select {
+	case s.caasUnitsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.statusSetter.Calls()) > 0 {
+			break
+		}
+	}
+	s.statusSetter.CheckCallNames(c, "SetOperatorStatus")
+	args := s.statusSetter.Calls()[0].Args
+	c.Assert(args[:3], jc.DeepEquals, []interface{}{"gitlab", expected, message})
+}
+
+func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expected status.Status) {
+	s.containerBroker.ResetCalls()
+	s.unitUpdater.ResetCalls()
+	s.containerBroker.reportedUnitStatus = reported
+
+	select {
+	case s.caasUnitsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
 		if len(s.unitUpdater.Calls()) > 0 {
 			break
 		}
 	}
+	s.containerBroker.CheckCallNames(c, "Units", "AutoscalerStatus", "DaemonScale", "RolloutStatus", "ApplicationWarning")
+	c.Assert(s.containerBroker.Calls()[0].Args, jc.DeepEquals, []interface{}{"gitlab"})
 	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
 	c.Assert(s.unitUpdater.Calls()[0].Args, jc.DeepEquals, []interface{}{
 		params.UpdateApplicationUnits{
@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -81,6 +83,17 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookLimits implements runner.Context.
+func (ctx *limitedContext) HookLimits() application.HookLimits {
+	return application.HookLimits{}
+}
+
+// HookRecording implements runner.Context.
+func (ctx *limitedContext) HookRecording() string { return "" }
+
+// DeferredEvents implements runner.Context.
+func (ctx *limitedContext) DeferredEvents() []hook.DeferredEvent { return nil }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -10,7 +10,9 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/worker/metrics/spool"
+	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -88,6 +90,17 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// HookLimits implements runner.Context.
+func (ctx *hookContext) HookLimits() application.HookLimits {
+	return application.HookLimits{}
+}
+
+// HookRecording implements runner.Context.
+func (ctx *hookContext) HookRecording() string { return "" }
+
+// DeferredEvents implements runner.Context.
+func (ctx *hookContext) DeferredEvents() []hook.DeferredEvent { return nil }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
@@ -17,8 +17,20 @@ import (
 	"github.com/juju/juju/core/watcher"
 )
 
+// relationUnitsSettingsFunc returns the relation settings for the named
+// units or applications.
 type relationUnitsSettingsFunc func([]string) ([]params.SettingsResult, error)
 
+// relationSettingsTag returns the tag used to request relation settings
+// for the named unit, or for the named application if name is not a
+// unit name.
+func relationSettingsTag(name string) names.Tag {
+	if names.IsValidUnit(name) {
+		return names.NewUnitTag(name)
+	}
+	return names.NewApplicationTag(name)
+}
+
 // relationUnitsWorker uses instances of watcher.RelationUnitsWatcher to
 // listen to changes to relation settings in a model, local or remote.
 // Local changes are exported to the remote model.
@@ -110,7 +122,7 @@ func (w *relationUnitsWorker) relationUnitsChangeEvent(
 	change watcher.RelationUnitsChange,
 ) (*params.RemoteRelationChangeEvent, error) {
 	logger.Debugf("update relation units for %v", w.relationTag)
-	if len(change.Changed)+len(change.Departed) == 0 {
+	if len(change.Changed)+len(change.AppChanged)+len(change.Departed) == 0 {
 		return nil, nil
 	}
 	// Ensure all the changed units have been exported.
@@ -169,5 +181,21 @@ func (w *relationUnitsWorker) relationUnitsChangeEvent(
 			event.ChangedUnits = append(event.ChangedUnits, change)
 		}
 	}
+
+	// Only the application on this side of the relation has its
+	// settings watched, so there is at most one changed application.
+	for appName := range change.AppChanged {
+		results, err := w.unitSettingsFunc([]string{appName})
+		if err != nil {
+			return nil, errors.Annotate(err, "fetching relation application settings")
+		}
+		if results[0].Error != nil {
+			return nil, errors.Annotatef(results[0].Error, "fetching relation application settings for %v", appName)
+		}
+		event.ApplicationSettings = make(map[string]interface{})
+		for k, v := range results[0].Settings {
+			event.ApplicationSettings[k] = v
+		}
+	}
 	return event, nil
 }
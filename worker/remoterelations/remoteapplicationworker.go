@@ -325,7 +325,7 @@ func (w *remoteApplicationWorker) startUnitsWorkers(
 		for i, changedName := range changedUnitNames {
 			relationUnits[i] = params.RelationUnit{
 				Relation: relationTag.String(),
-				Unit:     names.NewUnitTag(changedName).String(),
+				Unit:     relationSettingsTag(changedName).String(),
 			}
 		}
 		return w.localModelFacade.RelationUnitSettings(relationUnits)
@@ -365,7 +365,7 @@ func (w *remoteApplicationWorker) startUnitsWorkers(
 		for i, changedName := range changedUnitNames {
 			relationUnits[i] = params.RemoteRelationUnit{
 				RelationToken: relationToken,
-				Unit:          names.NewUnitTag(changedName).String(),
+				Unit:          relationSettingsTag(changedName).String(),
 				Macaroons:     macaroon.Slice{mac},
 			}
 		}
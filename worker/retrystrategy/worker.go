@@ -5,6 +5,8 @@
 package retrystrategy
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -34,8 +36,7 @@ func (c WorkerConfig) Validate() error {
 	if c.AgentTag == nil {
 		return errors.NotValidf("nil AgentTag")
 	}
-	empty := params.RetryStrategy{}
-	if c.RetryStrategy == empty {
+	if reflect.DeepEqual(c.RetryStrategy, params.RetryStrategy{}) {
 		return errors.NotValidf("empty RetryStrategy")
 	}
 	return nil
@@ -86,7 +87,7 @@ func (h retryStrategyHandler) Handle(_ <-chan struct{}) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if newRetryStrategy != h.config.RetryStrategy {
+	if !reflect.DeepEqual(newRetryStrategy, h.config.RetryStrategy) {
 		return errors.Errorf("bouncing retrystrategy worker to get new values")
 	}
 	return nil
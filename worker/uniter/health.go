@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/healthprobe"
+)
+
+// startHealthProbes starts the worker running the workload health
+// probes once the start hook has run; until then the workload is not
+// expected to be healthy.
+func (u *Uniter) startHealthProbes() error {
+	if u.healthProbesStarted || !u.operationExecutor.State().Started {
+		return nil
+	}
+	healthWorker, err := healthprobe.NewWorker(healthprobe.Config{
+		CharmDir: u.paths.State.CharmDir,
+		Clock:    u.clock,
+		Check:    healthprobe.Check,
+		Changed:  u.healthChanged,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.catacomb.Add(healthWorker); err != nil {
+		return errors.Trace(err)
+	}
+	u.healthProbesStarted = true
+	return nil
+}
+
+// currentHealth returns the latest results of the workload health probes.
+func (u *Uniter) currentHealth() healthprobe.Health {
+	u.healthMu.Lock()
+	defer u.healthMu.Unlock()
+	return u.health
+}
+
+// healthChanged records the latest results of the workload health probes,
+// updates the workload status to match, and signals the remote state
+// watcher so that the health-changed hook is run.
+func (u *Uniter) healthChanged(health healthprobe.Health) error {
+	u.healthMu.Lock()
+	defer u.healthMu.Unlock()
+
+	if err := u.setHealthStatus(health); err != nil {
+		return errors.Trace(err)
+	}
+	u.health = health
+	select {
+	case u.healthChannel <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// setHealthStatus sets the workload status for the failing probes, saving
+// the status it replaces so that it can be restored when the probes pass
+// again. The saved status is only restored if nothing has changed the
+// workload status in the meantime.
+func (u *Uniter) setHealthStatus(health healthprobe.Health) error {
+	if !health.Healthy() {
+		if u.health.Healthy() {
+			current, err := u.unit.UnitStatus()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			u.healthyStatus = &current
+		}
+		logger.Infof("workload unhealthy: %s", health.Message())
+		return u.unit.SetUnitHealthStatus(health.Status(), health.Message(), nil)
+	}
+
+	logger.Infof("workload healthy")
+	saved := u.healthyStatus
+	u.healthyStatus = nil
+	if saved == nil {
+		return nil
+	}
+	current, err := u.unit.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isHealthStatus(current, u.health) {
+		return nil
+	}
+	return u.unit.SetUnitStatus(status.Status(saved.Status), saved.Info, saved.Data)
+}
+
+// isHealthStatus returns true if current is the workload status set for
+// the failing probes in health.
+func isHealthStatus(current params.StatusResult, health healthprobe.Health) bool {
+	return status.Status(current.Status) == health.Status() && current.Info == health.Message()
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// maxOutput is the most probe output kept to describe a failure.
+const maxOutput = 256
+
+// CheckFunc runs a probe once for the charm in charmDir, returning an
+// error describing the failure if the probe fails. The probe is given
+// up when the abort channel is closed.
+type CheckFunc func(probe Probe, charmDir string, abort <-chan struct{}) error
+
+// Check is a CheckFunc that runs the probe against the workload.
+func Check(probe Probe, charmDir string, abort <-chan struct{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), probe.Timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	switch {
+	case probe.HTTP != "":
+		return checkHTTP(ctx, probe.HTTP)
+	case probe.TCP != "":
+		return checkTCP(ctx, probe.TCP)
+	case probe.Exec != "":
+		return checkExec(ctx, probe.Exec, charmDir)
+	}
+	return errors.NotValidf("health probe %q", probe.Name)
+}
+
+func checkHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxOutput))
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.New(trimOutput(fmt.Sprintf("%s: %s", resp.Status, body)))
+	}
+	return nil
+}
+
+func checkTCP(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+// checkExec runs the probe command in its own process group. If the
+// probe times out or is aborted, the whole group is killed and the probe
+// fails without waiting for the output pipe to close, as a process
+// started by the command may hold it open.
+func checkExec(ctx context.Context, command, charmDir string) error {
+	var out bytes.Buffer
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Dir = charmDir
+	cmd.Env = append(os.Environ(), "CHARM_DIR="+charmDir, "JUJU_CHARM_DIR="+charmDir)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return errors.Trace(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			if output := trimOutput(out.String()); output != "" {
+				return errors.Errorf("%v: %s", err, output)
+			}
+			return errors.Trace(err)
+		}
+		return nil
+	case <-ctx.Done():
+		if err := killProcessGroup(cmd); err != nil {
+			logger.Warningf("cannot kill health probe command: %v", err)
+		}
+		if ctx.Err() == context.Canceled {
+			return errors.New("aborted")
+		}
+		return errors.New("timed out")
+	}
+}
+
+// trimOutput returns the first line of output, truncated to maxOutput.
+func trimOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if i := strings.IndexByte(output, '\n'); i >= 0 {
+		output = output[:i]
+	}
+	if len(output) > maxOutput {
+		output = output[:maxOutput]
+	}
+	return strings.TrimSpace(output)
+}
@@ -0,0 +1,20 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package healthprobe
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing; probe commands are only run in their
+// own process group on Linux.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the probe command. Processes started by the
+// command are only killed with it on Linux.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/juju/core/status"
+)
+
+// Failure describes a failing probe.
+type Failure struct {
+	// Output describes why the probe failed.
+	Output string
+
+	// Status is the workload status to set while the probe is failing.
+	Status status.Status
+}
+
+// Health holds the results of a unit's health probes.
+type Health struct {
+	// Failures holds the failing probes, keyed by probe name.
+	Failures map[string]Failure
+}
+
+// Healthy returns true if no probes are failing.
+func (h Health) Healthy() bool {
+	return len(h.Failures) == 0
+}
+
+// Failed returns the names of the failing probes, in order.
+func (h Health) Failed() []string {
+	names := make([]string, 0, len(h.Failures))
+	for name := range h.Failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Status returns the workload status to set for the failing probes:
+// error if any of them call for it, and blocked otherwise.
+func (h Health) Status() status.Status {
+	for _, failure := range h.Failures {
+		if failure.Status == status.Error {
+			return status.Error
+		}
+	}
+	return status.Blocked
+}
+
+// Message returns a workload status message describing the failing
+// probes.
+func (h Health) Message() string {
+	var messages []string
+	for _, name := range h.Failed() {
+		messages = append(messages, fmt.Sprintf("health probe %q failed: %s", name, h.Failures[name].Output))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// equal returns true if the same probes are failing with the same
+// statuses in both. The output of a failing probe may differ on every
+// run, so it is not compared.
+func (h Health) equal(other Health) bool {
+	if len(h.Failures) != len(other.Failures) {
+		return false
+	}
+	for name, failure := range h.Failures {
+		if otherFailure, ok := other.Failures[name]; !ok || otherFailure.Status != failure.Status {
+			return false
+		}
+	}
+	return true
+}
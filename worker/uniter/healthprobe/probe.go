@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthprobe runs the workload health probes that a charm
+// declares in its metadata, so that the unit agent notices a failing
+// workload without waiting for a hook to report it.
+//
+// Probes are declared under the "health-probes" key of the charm's
+// metadata.yaml:
+//
+//     health-probes:
+//       web:
+//         http: http://localhost:8080/healthz
+//         interval: 30s
+//         timeout: 5s
+//       db:
+//         tcp: localhost:5432
+//         failure-status: blocked
+//       worker:
+//         exec: ./bin/check-worker
+//
+// Each probe has exactly one of http, tcp or exec. An http probe fails
+// if the request fails or returns a status of 400 or above, a tcp probe
+// fails if it cannot connect, and an exec probe fails if the command,
+// run with sh in the charm directory, exits with a non-zero status.
+package healthprobe
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/status"
+)
+
+const (
+	// DefaultInterval is how often a probe is run if its
+	// interval is not specified.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultTimeout is how long a probe may take before it is
+	// considered to have failed, if its timeout is not specified.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Probe describes a single workload health probe.
+type Probe struct {
+	// Name is the name of the probe, as declared in the metadata.
+	Name string
+
+	// HTTP holds the URL requested by an http probe.
+	HTTP string
+
+	// TCP holds the host:port address connected to by a tcp probe.
+	TCP string
+
+	// Exec holds the shell command run by an exec probe.
+	Exec string
+
+	// Interval is how often the probe is run.
+	Interval time.Duration
+
+	// Timeout is how long the probe may take before it fails.
+	Timeout time.Duration
+
+	// FailureStatus is the workload status set while the probe
+	// is failing: either error or blocked.
+	FailureStatus status.Status
+}
+
+// Validate returns an error if the probe is not valid.
+func (p Probe) Validate() error {
+	kinds := 0
+	for _, v := range []string{p.HTTP, p.TCP, p.Exec} {
+		if v != "" {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.NotValidf("health probe %q without exactly one of http, tcp or exec", p.Name)
+	}
+	if p.HTTP != "" {
+		u, err := url.Parse(p.HTTP)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NotValidf("health probe %q http URL %q", p.Name, p.HTTP)
+		}
+	}
+	if p.TCP != "" {
+		if _, _, err := net.SplitHostPort(p.TCP); err != nil {
+			return errors.NotValidf("health probe %q tcp address %q", p.Name, p.TCP)
+		}
+	}
+	if p.Interval <= 0 {
+		return errors.NotValidf("health probe %q interval %v", p.Name, p.Interval)
+	}
+	if p.Timeout <= 0 {
+		return errors.NotValidf("health probe %q timeout %v", p.Name, p.Timeout)
+	}
+	switch p.FailureStatus {
+	case status.Error, status.Blocked:
+	default:
+		return errors.NotValidf("health probe %q failure status %q", p.Name, p.FailureStatus)
+	}
+	return nil
+}
+
+type probeDoc struct {
+	HTTP          string `yaml:"http"`
+	TCP           string `yaml:"tcp"`
+	Exec          string `yaml:"exec"`
+	Interval      string `yaml:"interval"`
+	Timeout       string `yaml:"timeout"`
+	FailureStatus string `yaml:"failure-status"`
+}
+
+type metadataDoc struct {
+	HealthProbes map[string]probeDoc `yaml:"health-probes"`
+}
+
+// MetadataPath returns the path of the metadata file declaring the
+// probes of the charm in charmDir.
+func MetadataPath(charmDir string) string {
+	return filepath.Join(charmDir, "metadata.yaml")
+}
+
+// ReadProbes returns the health probes declared in the metadata of the
+// charm in charmDir, ordered by name. It returns no probes if the charm
+// has not been deployed yet.
+func ReadProbes(charmDir string) ([]Probe, error) {
+	data, err := ioutil.ReadFile(MetadataPath(charmDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var doc metadataDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	var probes []Probe
+	for name, pd := range doc.HealthProbes {
+		probe := Probe{
+			Name:          name,
+			HTTP:          pd.HTTP,
+			TCP:           pd.TCP,
+			Exec:          pd.Exec,
+			Interval:      DefaultInterval,
+			Timeout:       DefaultTimeout,
+			FailureStatus: status.Error,
+		}
+		if pd.Interval != "" {
+			if probe.Interval, err = time.ParseDuration(pd.Interval); err != nil {
+				return nil, errors.NotValidf("health probe %q interval %q", name, pd.Interval)
+			}
+		}
+		if pd.Timeout != "" {
+			if probe.Timeout, err = time.ParseDuration(pd.Timeout); err != nil {
+				return nil, errors.NotValidf("health probe %q timeout %q", name, pd.Timeout)
+			}
+		}
+		if pd.FailureStatus != "" {
+			probe.FailureStatus = status.Status(pd.FailureStatus)
+		}
+		if err := probe.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		probes = append(probes, probe)
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		return probes[i].Name < probes[j].Name
+	})
+	return probes, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/healthprobe"
+)
+
+type ProbeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProbeSuite{})
+
+func writeMetadata(c *gc.C, charmDir, content string) {
+	err := ioutil.WriteFile(healthprobe.MetadataPath(charmDir), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ProbeSuite) TestReadProbes(c *gc.C) {
+	charmDir := c.MkDir()
+	writeMetadata(c, charmDir, `
+name: wordpress
+health-probes:
+  web:
+    http: http://localhost:8080/healthz
+    interval: 1m
+    timeout: 5s
+  db:
+    tcp: localhost:5432
+    failure-status: blocked
+  worker:
+    exec: ./bin/check-worker
+`)
+	probes, err := healthprobe.ReadProbes(charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, jc.DeepEquals, []healthprobe.Probe{{
+		Name:          "db",
+		TCP:           "localhost:5432",
+		Interval:      healthprobe.DefaultInterval,
+		Timeout:       healthprobe.DefaultTimeout,
+		FailureStatus: status.Blocked,
+	}, {
+		Name:          "web",
+		HTTP:          "http://localhost:8080/healthz",
+		Interval:      time.Minute,
+		Timeout:       5 * time.Second,
+		FailureStatus: status.Error,
+	}, {
+		Name:          "worker",
+		Exec:          "./bin/check-worker",
+		Interval:      healthprobe.DefaultInterval,
+		Timeout:       healthprobe.DefaultTimeout,
+		FailureStatus: status.Error,
+	}})
+}
+
+func (s *ProbeSuite) TestReadProbesNoMetadata(c *gc.C) {
+	probes, err := healthprobe.ReadProbes(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, gc.HasLen, 0)
+}
+
+func (s *ProbeSuite) TestReadProbesNoProbes(c *gc.C) {
+	charmDir := c.MkDir()
+	writeMetadata(c, charmDir, "name: wordpress\n")
+	probes, err := healthprobe.ReadProbes(charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, gc.HasLen, 0)
+}
+
+func (s *ProbeSuite) TestReadProbesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		probe string
+		err   string
+	}{{
+		probe: "{}",
+		err:   `health probe "p" without exactly one of http, tcp or exec not valid`,
+	}, {
+		probe: "{http: http://localhost, tcp: localhost:80}",
+		err:   `health probe "p" without exactly one of http, tcp or exec not valid`,
+	}, {
+		probe: "{http: ftp://localhost}",
+		err:   `health probe "p" http URL "ftp://localhost" not valid`,
+	}, {
+		probe: "{tcp: localhost}",
+		err:   `health probe "p" tcp address "localhost" not valid`,
+	}, {
+		probe: "{exec: 'true', interval: soon}",
+		err:   `health probe "p" interval "soon" not valid`,
+	}, {
+		probe: "{exec: 'true', timeout: -1s}",
+		err:   `health probe "p" timeout -1s not valid`,
+	}, {
+		probe: "{exec: 'true', failure-status: active}",
+		err:   `health probe "p" failure status "active" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.probe)
+		charmDir := c.MkDir()
+		writeMetadata(c, charmDir, "health-probes:\n  p: "+test.probe+"\n")
+		_, err := healthprobe.ReadProbes(charmDir)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ProbeSuite) TestCheckHTTP(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bad" {
+			http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	probe := healthprobe.Probe{Name: "web", HTTP: server.URL + "/good", Timeout: time.Second}
+	c.Assert(healthprobe.Check(probe, c.MkDir(), nil), jc.ErrorIsNil)
+
+	probe.HTTP = server.URL + "/bad"
+	err := healthprobe.Check(probe, c.MkDir(), nil)
+	c.Assert(err, gc.ErrorMatches, "503 Service Unavailable: database unavailable")
+}
+
+func (s *ProbeSuite) TestCheckTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	address := listener.Addr().String()
+
+	probe := healthprobe.Probe{Name: "db", TCP: address, Timeout: time.Second}
+	c.Assert(healthprobe.Check(probe, c.MkDir(), nil), jc.ErrorIsNil)
+
+	listener.Close()
+	c.Assert(healthprobe.Check(probe, c.MkDir(), nil), gc.NotNil)
+}
+
+func (s *ProbeSuite) TestCheckExec(c *gc.C) {
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "state"), []byte("ok"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	probe := healthprobe.Probe{Name: "worker", Exec: `test "$(cat state)" = ok`, Timeout: time.Second}
+	c.Assert(healthprobe.Check(probe, charmDir, nil), jc.ErrorIsNil)
+
+	probe.Exec = "echo worker stalled; echo ignored; exit 3"
+	err = healthprobe.Check(probe, charmDir, nil)
+	c.Assert(err, gc.ErrorMatches, "exit status 3: worker stalled")
+}
+
+func (s *ProbeSuite) TestCheckExecTimeout(c *gc.C) {
+	probe := healthprobe.Probe{Name: "worker", Exec: "sleep 10", Timeout: 10 * time.Millisecond}
+	err := healthprobe.Check(probe, c.MkDir(), nil)
+	c.Assert(err, gc.ErrorMatches, "timed out")
+}
+
+func (s *ProbeSuite) TestCheckExecTimeoutChildHoldsOutput(c *gc.C) {
+	// The background sleep keeps the output pipe open after the
+	// shell is killed; the probe must not wait for it.
+	probe := healthprobe.Probe{Name: "worker", Exec: "sleep 10 & wait", Timeout: 10 * time.Millisecond}
+	start := time.Now()
+	err := healthprobe.Check(probe, c.MkDir(), nil)
+	c.Assert(err, gc.ErrorMatches, "timed out")
+	c.Assert(time.Since(start) < 5*time.Second, jc.IsTrue)
+}
+
+func (s *ProbeSuite) TestCheckExecAborted(c *gc.C) {
+	probe := healthprobe.Probe{Name: "worker", Exec: "sleep 10", Timeout: time.Minute}
+	abort := make(chan struct{})
+	close(abort)
+	start := time.Now()
+	err := healthprobe.Check(probe, c.MkDir(), abort)
+	c.Assert(err, gc.ErrorMatches, "aborted")
+	c.Assert(time.Since(start) < 5*time.Second, jc.IsTrue)
+}
+
+func (s *ProbeSuite) TestHealth(c *gc.C) {
+	health := healthprobe.Health{}
+	c.Check(health.Healthy(), jc.IsTrue)
+	c.Check(health.Failed(), gc.HasLen, 0)
+
+	health.Failures = map[string]healthprobe.Failure{
+		"web": {Output: "connection refused", Status: status.Blocked},
+		"db":  {Output: "timed out", Status: status.Blocked},
+	}
+	c.Check(health.Healthy(), jc.IsFalse)
+	c.Check(health.Failed(), jc.DeepEquals, []string{"db", "web"})
+	c.Check(health.Status(), gc.Equals, status.Blocked)
+	c.Check(health.Message(), gc.Equals,
+		`health probe "db" failed: timed out; health probe "web" failed: connection refused`)
+
+	health.Failures["worker"] = healthprobe.Failure{Output: "exit status 1", Status: status.Error}
+	c.Check(health.Status(), gc.Equals, status.Error)
+}
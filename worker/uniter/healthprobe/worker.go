@@ -0,0 +1,185 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe
+
+import (
+	"os"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.healthprobe")
+
+// reloadInterval is how often the worker checks whether the charm's
+// probes have changed, for example after an upgrade.
+const reloadInterval = time.Minute
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	// CharmDir is the directory holding the deployed charm.
+	CharmDir string
+
+	// Clock is used to schedule the probes.
+	Clock clock.Clock
+
+	// Check runs a single probe.
+	Check CheckFunc
+
+	// Changed is called with the unit's health whenever it changes.
+	Changed func(Health) error
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Check == nil {
+		return errors.NotValidf("nil Check")
+	}
+	if config.Changed == nil {
+		return errors.NotValidf("nil Changed")
+	}
+	return nil
+}
+
+// Worker runs the health probes declared by a charm, reporting
+// changes in the unit's health.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	probes   []Probe
+	modTime  time.Time
+	due      map[string]time.Time
+	failures map[string]Failure
+	reported Health
+}
+
+// NewWorker returns a worker that runs the probes declared by the charm
+// in config.CharmDir, calling config.Changed when the unit's health
+// changes.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:   config,
+		due:      make(map[string]time.Time),
+		failures: make(map[string]Failure),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		w.reload()
+		now := w.config.Clock.Now()
+		next := now.Add(reloadInterval)
+		for _, probe := range w.probes {
+			if due, ok := w.due[probe.Name]; !ok || !due.After(now) {
+				if err := w.run(probe); err != nil {
+					return errors.Trace(err)
+				}
+				w.due[probe.Name] = now.Add(probe.Interval)
+			}
+			if due := w.due[probe.Name]; due.Before(next) {
+				next = due
+			}
+		}
+		health := Health{Failures: make(map[string]Failure)}
+		for name, failure := range w.failures {
+			health.Failures[name] = failure
+		}
+		if !health.equal(w.reported) {
+			if err := w.config.Changed(health); err != nil {
+				return errors.Trace(err)
+			}
+			w.reported = health
+		}
+
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(next.Sub(now)):
+		}
+	}
+}
+
+// reload reads the charm's probes again if its metadata has changed.
+// Invalid metadata is logged and treated as declaring no probes, so a
+// broken charm does not stop the unit agent.
+func (w *Worker) reload() {
+	var modTime time.Time
+	if info, err := os.Stat(MetadataPath(w.config.CharmDir)); err == nil {
+		modTime = info.ModTime()
+	}
+	if w.probes != nil && modTime.Equal(w.modTime) {
+		return
+	}
+	w.modTime = modTime
+	probes, err := ReadProbes(w.config.CharmDir)
+	if err != nil {
+		logger.Errorf("cannot read health probes: %v", err)
+	}
+	w.probes = make([]Probe, 0, len(probes))
+	w.due = make(map[string]time.Time)
+	failures := make(map[string]Failure)
+	for _, probe := range probes {
+		w.probes = append(w.probes, probe)
+		if failure, ok := w.failures[probe.Name]; ok {
+			failures[probe.Name] = failure
+		}
+	}
+	w.failures = failures
+}
+
+// run runs probe, recording whether it failed in w.failures. A probe
+// is aborted when the worker is killed, so as not to delay it.
+func (w *Worker) run(probe Probe) error {
+	err := w.config.Check(probe, w.config.CharmDir, w.catacomb.Dying())
+	select {
+	case <-w.catacomb.Dying():
+		return w.catacomb.ErrDying()
+	default:
+	}
+	if err == nil {
+		if _, ok := w.failures[probe.Name]; ok {
+			logger.Infof("health probe %q passed", probe.Name)
+		}
+		delete(w.failures, probe.Name)
+		return nil
+	}
+	logger.Debugf("health probe %q failed: %v", probe.Name, err)
+	w.failures[probe.Name] = Failure{
+		Output: err.Error(),
+		Status: probe.FailureStatus,
+	}
+	return nil
+}
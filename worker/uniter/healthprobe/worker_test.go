@@ -0,0 +1,202 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthprobe"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	charmDir string
+	clock    *testclock.Clock
+	changes  chan healthprobe.Health
+
+	mu      sync.Mutex
+	failing map[string]error
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+	s.clock = testclock.NewClock(time.Now())
+	s.changes = make(chan healthprobe.Health, 10)
+	s.failing = make(map[string]error)
+	writeMetadata(c, s.charmDir, `
+health-probes:
+  web:
+    http: http://localhost:8080/
+    interval: 10s
+  db:
+    tcp: localhost:5432
+    interval: 20s
+    failure-status: blocked
+`)
+}
+
+func (s *WorkerSuite) setFailing(name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.failing, name)
+	} else {
+		s.failing[name] = err
+	}
+}
+
+func (s *WorkerSuite) check(probe healthprobe.Probe, charmDir string, abort <-chan struct{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failing[probe.Name]
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) *healthprobe.Worker {
+	w, err := healthprobe.NewWorker(healthprobe.Config{
+		CharmDir: s.charmDir,
+		Clock:    s.clock,
+		Check:    s.check,
+		Changed: func(health healthprobe.Health) error {
+			s.changes <- health
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) nextChange(c *gc.C) healthprobe.Health {
+	select {
+	case health := <-s.changes:
+		return health
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health change")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoChange(c *gc.C) {
+	select {
+	case health := <-s.changes:
+		c.Fatalf("unexpected health change: %#v", health)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := healthprobe.NewWorker(healthprobe.Config{
+		CharmDir: s.charmDir,
+		Clock:    s.clock,
+		Check:    s.check,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Changed not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestHealthyNotReported(c *gc.C) {
+	w := s.newWorker(c)
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoChange(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestFailureAndRecovery(c *gc.C) {
+	s.setFailing("db", errors.New("connection refused"))
+	s.newWorker(c)
+
+	health := s.nextChange(c)
+	c.Assert(health.Failures, jc.DeepEquals, map[string]healthprobe.Failure{
+		"db": {Output: "connection refused", Status: status.Blocked},
+	})
+
+	// The web probe is run again after 10s, and fails.
+	s.setFailing("web", errors.New("500 Internal Server Error"))
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	health = s.nextChange(c)
+	c.Assert(health.Failed(), jc.DeepEquals, []string{"db", "web"})
+	c.Assert(health.Status(), gc.Equals, status.Error)
+
+	// Both probes are run after 20s, and pass.
+	s.setFailing("db", nil)
+	s.setFailing("web", nil)
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	health = s.nextChange(c)
+	c.Assert(health.Healthy(), jc.IsTrue)
+}
+
+func (s *WorkerSuite) TestUnchangedFailureNotReported(c *gc.C) {
+	s.setFailing("web", errors.New("connection refused"))
+	s.newWorker(c)
+	s.nextChange(c)
+
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoChange(c)
+}
+
+func (s *WorkerSuite) TestChangedOutputNotReported(c *gc.C) {
+	s.setFailing("web", errors.New("connection refused"))
+	s.newWorker(c)
+	s.nextChange(c)
+
+	// The probe still fails with the same status, so the
+	// different output is not reported as a change.
+	s.setFailing("web", errors.New("connection reset by peer"))
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoChange(c)
+}
+
+func (s *WorkerSuite) TestKillAbortsProbe(c *gc.C) {
+	started := make(chan struct{})
+	w, err := healthprobe.NewWorker(healthprobe.Config{
+		CharmDir: s.charmDir,
+		Clock:    s.clock,
+		Check: func(probe healthprobe.Probe, charmDir string, abort <-chan struct{}) error {
+			close(started)
+			<-abort
+			return errors.New("aborted")
+		},
+		Changed: func(health healthprobe.Health) error {
+			s.changes <- health
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for probe to start")
+	}
+	workertest.CleanKill(c, w)
+	s.assertNoChange(c)
+}
+
+func (s *WorkerSuite) TestChangedError(c *gc.C) {
+	s.setFailing("web", errors.New("connection refused"))
+	w, err := healthprobe.NewWorker(healthprobe.Config{
+		CharmDir: s.charmDir,
+		Clock:    s.clock,
+		Check:    s.check,
+		Changed: func(healthprobe.Health) error {
+			return errors.New("boom")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
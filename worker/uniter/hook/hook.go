@@ -6,6 +6,8 @@ package hook
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
@@ -18,6 +20,18 @@ const (
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
 )
 
+// Deferred is the kind of hook run to deliver a custom event that an
+// earlier hook asked to be delivered later, using defer-hook.
+const Deferred hooks.Kind = "deferred"
+
+// HealthChanged is the kind of hook run when the results of the
+// workload health probes declared in the charm metadata change.
+const HealthChanged hooks.Kind = "health-changed"
+
+// SecretRotate is the kind of hook run on the leader when a secret
+// owned by its application is due to be rotated.
+const SecretRotate hooks.Kind = "secret-rotate"
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -31,18 +45,36 @@ type Info struct {
 	// set when Kind indicates a relation hook other than relation-broken.
 	RemoteUnit string `yaml:"remote-unit,omitempty"`
 
+	// RemoteApplication is the name of the application whose relation
+	// settings triggered the hook. It is only set for relation-changed
+	// hooks fired by an application settings change, in which case
+	// RemoteUnit is empty.
+	RemoteApplication string `yaml:"remote-application,omitempty"`
+
 	// ChangeVersion identifies the most recent unit settings change
-	// associated with RemoteUnit. It is only set when RemoteUnit is set.
+	// associated with RemoteUnit, or the most recent application settings
+	// change associated with RemoteApplication.
 	ChangeVersion int64 `yaml:"change-version,omitempty"`
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// EventName is the name of the custom event delivered by a
+	// deferred hook. It is only set when Kind is Deferred.
+	EventName string `yaml:"event-name,omitempty"`
+
+	// SecretID is the id of the secret to be rotated. It is only set
+	// when Kind is SecretRotate.
+	SecretID string `yaml:"secret-id,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
 func (hi Info) Validate() error {
 	switch hi.Kind {
 	case hooks.RelationJoined, hooks.RelationChanged, hooks.RelationDeparted:
+		if hi.Kind == hooks.RelationChanged && hi.RemoteUnit == "" && hi.RemoteApplication != "" {
+			return nil
+		}
 		if hi.RemoteUnit == "" {
 			return fmt.Errorf("%q hook requires a remote unit", hi.Kind)
 		}
@@ -58,12 +90,72 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, HealthChanged:
+		return nil
+	case Deferred:
+		if hi.EventName == "" {
+			return fmt.Errorf("%q hook requires an event name", hi.Kind)
+		}
+		return nil
+	case SecretRotate:
+		if hi.SecretID == "" {
+			return fmt.Errorf("%q hook requires a secret id", hi.Kind)
+		}
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
 
+// DeferredEvent is a custom event a hook has asked to be delivered,
+// by running the deferred hook, at a later time.
+type DeferredEvent struct {
+	// Name is the name of the event, passed to the deferred hook.
+	Name string `yaml:"name"`
+
+	// Time is when the event is due to be delivered.
+	Time time.Time `yaml:"time"`
+}
+
+// SortDeferredEvents sorts events by time, and then by name.
+func SortDeferredEvents(events []DeferredEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].Name < events[j].Name
+	})
+}
+
+// MergeDeferredEvents returns the events in existing and added, sorted
+// by time. Events in added replace those of the same name in existing.
+func MergeDeferredEvents(existing, added []DeferredEvent) []DeferredEvent {
+	byName := make(map[string]time.Time)
+	for _, event := range existing {
+		byName[event.Name] = event.Time
+	}
+	for _, event := range added {
+		byName[event.Name] = event.Time
+	}
+	var merged []DeferredEvent
+	for name, at := range byName {
+		merged = append(merged, DeferredEvent{Name: name, Time: at})
+	}
+	SortDeferredEvents(merged)
+	return merged
+}
+
+// RemoveDeferredEvent returns events without the one with the
+// supplied name.
+func RemoveDeferredEvent(events []DeferredEvent, name string) []DeferredEvent {
+	var remaining []DeferredEvent
+	for _, event := range events {
+		if event.Name != name {
+			remaining = append(remaining, event)
+		}
+	}
+	return remaining
+}
+
 // Committer is an interface that may be used to convey the fact that the
 // specified hook has been successfully executed, and committed.
 type Committer interface {
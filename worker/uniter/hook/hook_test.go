@@ -4,6 +4,8 @@
 package hook_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6/hooks"
@@ -31,6 +33,9 @@ var validateTests = []struct {
 	}, {
 		hook.Info{Kind: hooks.RelationDeparted},
 		`"relation-departed" hook requires a remote unit`,
+	}, {
+		hook.Info{Kind: hooks.RelationJoined, RemoteApplication: "x"},
+		`"relation-joined" hook requires a remote unit`,
 	}, {
 		hook.Info{Kind: hooks.Kind("grok")},
 		`unknown hook kind "grok"`,
@@ -45,11 +50,17 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.Stop}, ""},
 	{hook.Info{Kind: hooks.RelationJoined, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationChanged, RemoteUnit: "x"}, ""},
+	{hook.Info{Kind: hooks.RelationChanged, RemoteApplication: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationDeparted, RemoteUnit: "x"}, ""},
 	{hook.Info{Kind: hooks.RelationBroken}, ""},
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.Deferred}, `"deferred" hook requires an event name`},
+	{hook.Info{Kind: hook.Deferred, EventName: "check-again"}, ""},
+	{hook.Info{Kind: hook.HealthChanged}, ""},
+	{hook.Info{Kind: hook.SecretRotate}, `"secret-rotate" hook requires a secret id`},
+	{hook.Info{Kind: hook.SecretRotate, SecretID: "mysql/root-password"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -63,3 +74,32 @@ func (s *InfoSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *InfoSuite) TestMergeDeferredEvents(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	existing := []hook.DeferredEvent{
+		{Name: "a", Time: t0},
+		{Name: "b", Time: t0.Add(time.Hour)},
+	}
+	added := []hook.DeferredEvent{
+		{Name: "a", Time: t0.Add(2 * time.Hour)},
+		{Name: "c", Time: t0.Add(time.Minute)},
+	}
+	c.Assert(hook.MergeDeferredEvents(existing, added), jc.DeepEquals, []hook.DeferredEvent{
+		{Name: "c", Time: t0.Add(time.Minute)},
+		{Name: "b", Time: t0.Add(time.Hour)},
+		{Name: "a", Time: t0.Add(2 * time.Hour)},
+	})
+}
+
+func (s *InfoSuite) TestRemoveDeferredEvent(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	events := []hook.DeferredEvent{
+		{Name: "a", Time: t0},
+		{Name: "b", Time: t0.Add(time.Hour)},
+	}
+	c.Assert(hook.RemoveDeferredEvent(events, "a"), jc.DeepEquals, []hook.DeferredEvent{
+		{Name: "b", Time: t0.Add(time.Hour)},
+	})
+	c.Assert(hook.RemoveDeferredEvent(events, "c"), jc.DeepEquals, events)
+}
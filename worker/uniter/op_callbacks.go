@@ -124,6 +124,20 @@ func (opc *operationCallbacks) SetExecutingStatus(message string) error {
 	return setAgentStatus(opc.u, status.Executing, message, nil)
 }
 
+// SetHookLimitStatus is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) SetHookLimitStatus(message string) error {
+	return setAgentStatus(opc.u, status.Error, message, nil)
+}
+
+// SetDeferredEvents is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) SetDeferredEvents(events []hook.DeferredEvent) error {
+	args := make([]params.DeferredEvent, len(events))
+	for i, event := range events {
+		args[i] = params.DeferredEvent{Name: event.Name, Time: event.Time}
+	}
+	return opc.u.unit.SetDeferredEvents(args)
+}
+
 // SetUpgradeSeriesStatus is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) SetUpgradeSeriesStatus(upgradeSeriesStatus model.UpgradeSeriesStatus, reason string) error {
 	return setUpgradeSeriesStatus(opc.u, upgradeSeriesStatus, reason)
@@ -149,6 +149,14 @@ type Callbacks interface {
 	// SetExecutingStatus sets the agent state to "Executing" with a message.
 	SetExecutingStatus(string) error
 
+	// SetHookLimitStatus records in the agent's status history that a
+	// hook was killed for exceeding one of its hook limits.
+	SetHookLimitStatus(string) error
+
+	// SetDeferredEvents records the unit's pending deferred events
+	// with the controller.
+	SetDeferredEvents([]hook.DeferredEvent) error
+
 	// NotifyHook* exist so that we can defer worrying about how to untangle the
 	// callbacks inserted for uniter_test. They're only used by RunHook operations.
 	NotifyHookCompleted(string, runner.Context)
@@ -45,6 +45,10 @@ func (rh *runHook) String() string {
 		}
 	case rh.info.Kind.IsStorage():
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.Deferred:
+		suffix = fmt.Sprintf(" (%s)", rh.info.EventName)
+	case rh.info.Kind == hook.SecretRotate:
+		suffix = fmt.Sprintf(" (%s)", rh.info.SecretID)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -82,6 +86,16 @@ func (rh *runHook) Prepare(state State) (*State, error) {
 	rh.name = name
 	rh.runner = rnr
 
+	if rh.info.Kind == hook.Deferred {
+		// The event is delivered by this hook, and will not be due
+		// again unless the hook defers it again.
+		remaining := hook.RemoveDeferredEvent(state.Deferred, rh.info.EventName)
+		if len(remaining) != len(state.Deferred) {
+			state.Deferred = remaining
+			rh.syncDeferredEvents(&state)
+		}
+	}
+
 	return stateChange{
 		Kind: RunHook,
 		Step: Pending,
@@ -130,6 +144,11 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		if runner.IsHookLimitError(cause) {
+			if err := rh.callbacks.SetHookLimitStatus(cause.Error()); err != nil {
+				return nil, err
+			}
+		}
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
@@ -137,6 +156,10 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	if rh.hookFound {
 		logger.Infof("ran %q hook", rh.name)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
+		if deferred := rh.runner.Context().DeferredEvents(); len(deferred) > 0 {
+			state.Deferred = hook.MergeDeferredEvents(state.Deferred, deferred)
+			rh.syncDeferredEvents(&state)
+		}
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
 	}
@@ -154,6 +177,19 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// syncDeferredEvents reports the deferred events in state to the
+// controller. The events are kept in the local state regardless, so
+// a failure only marks them to be reported again later rather than
+// failing the hook.
+func (rh *runHook) syncDeferredEvents(state *State) {
+	if err := rh.callbacks.SetDeferredEvents(state.Deferred); err != nil {
+		logger.Warningf("cannot record deferred events: %v", err)
+		state.DeferredUnsynced = true
+		return
+	}
+	state.DeferredUnsynced = false
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -271,6 +307,11 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		err = rh.callbacks.SetUpgradeSeriesStatus(model.UpgradeSeriesCompleted, message)
 	}
 
+	if state.DeferredUnsynced {
+		// An earlier report of the deferred events failed; the
+		// local state still holds them, so try again.
+		rh.syncDeferredEvents(&state)
+	}
 	newState := change.apply(state)
 
 	switch rh.info.Kind {
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -297,6 +300,22 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(callbacks.hookLimitMessage, gc.Equals, "")
+}
+
+func (s *RunHookSuite) TestExecuteHookLimitError(c *gc.C) {
+	runErr := errors.Trace(runner.NewHookLimitError("hook %q exceeded its time limit of %v", "some-hook-name", time.Minute))
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.UpdateStatus, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(callbacks.hookLimitMessage, gc.Equals, `hook "some-hook-name" exceeded its time limit of 1m0s`)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
@@ -449,6 +468,111 @@ func (s *RunHookSuite) testBeforeHookExecute(c *gc.C, newHook newHook, kind hook
 	testBeforeHookStatus(c, kind, status)
 }
 
+func (s *RunHookSuite) TestPrepareDeferredHookRemovesEvent(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: NewRunHookRunnerFactory(nil),
+		Callbacks:     callbacks,
+	})
+	info := hook.Info{Kind: hook.Deferred, EventName: "check-again"}
+	op, err := factory.NewRunHook(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{
+		Deferred: []hook.DeferredEvent{
+			{Name: "check-again", Time: t0},
+			{Name: "rotate-logs", Time: t0.Add(time.Hour)},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	remaining := []hook.DeferredEvent{{Name: "rotate-logs", Time: t0.Add(time.Hour)}}
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:     operation.RunHook,
+		Step:     operation.Pending,
+		Hook:     &info,
+		Deferred: remaining,
+	})
+	c.Assert(callbacks.deferredEvents, jc.DeepEquals, remaining)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsDeferredEvents(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	op, callbacks, _ := s.getExecuteRunnerTest(
+		c, operation.Factory.NewRunHook, hooks.ConfigChanged, nil,
+		func(ctx *MockContext) {
+			ctx.deferredEvents = []hook.DeferredEvent{
+				{Name: "check-again", Time: t0.Add(time.Minute)},
+			}
+		},
+	)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{
+		Deferred: []hook.DeferredEvent{
+			{Name: "check-again", Time: t0.Add(time.Hour)},
+			{Name: "rotate-logs", Time: t0},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []hook.DeferredEvent{
+		{Name: "rotate-logs", Time: t0},
+		{Name: "check-again", Time: t0.Add(time.Minute)},
+	}
+	c.Assert(newState.Deferred, jc.DeepEquals, expected)
+	c.Assert(callbacks.deferredEvents, jc.DeepEquals, expected)
+}
+
+func (s *RunHookSuite) TestExecuteDeferredEventsErrorNotFatal(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	op, callbacks, _ := s.getExecuteRunnerTest(
+		c, operation.Factory.NewRunHook, hooks.ConfigChanged, nil,
+		func(ctx *MockContext) {
+			ctx.deferredEvents = []hook.DeferredEvent{
+				{Name: "check-again", Time: t0},
+			}
+		},
+	)
+	callbacks.deferredEventsErr = errors.New("connection is shut down")
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Step, gc.Equals, operation.Done)
+	c.Assert(newState.Deferred, jc.DeepEquals, []hook.DeferredEvent{
+		{Name: "check-again", Time: t0},
+	})
+	c.Assert(newState.DeferredUnsynced, jc.IsTrue)
+}
+
+func (s *RunHookSuite) TestCommitRetriesUnsyncedDeferredEvents(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	deferred := []hook.DeferredEvent{{Name: "check-again", Time: t0}}
+	callbacks := &CommitHookCallbacks{
+		MockCommitHook: &MockCommitHook{},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		Callbacks: callbacks,
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Commit(operation.State{
+		Deferred:         deferred,
+		DeferredUnsynced: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Deferred, jc.DeepEquals, deferred)
+	c.Assert(newState.DeferredUnsynced, jc.IsFalse)
+	c.Assert(callbacks.deferredEvents, jc.DeepEquals, deferred)
+}
+
 func (s *RunHookSuite) TestBeforeHookStatus(c *gc.C) {
 	for _, kind := range hooks.UnitHooks() {
 		c.Logf("hook %v", kind)
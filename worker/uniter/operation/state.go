@@ -93,6 +93,14 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// Deferred holds the custom events that hooks have asked to be
+	// delivered later, ordered by time.
+	Deferred []hook.DeferredEvent `yaml:"deferred,omitempty"`
+
+	// DeferredUnsynced is true when Deferred has changed since it was
+	// last successfully reported to the controller.
+	DeferredUnsynced bool `yaml:"deferred-unsynced,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -183,6 +183,9 @@ type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	hookLimitMessage        string
+	deferredEvents          []hook.DeferredEvent
+	deferredEventsErr       error
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -193,6 +196,19 @@ func (cb *ExecuteHookCallbacks) NotifyHookFailed(hookName string, ctx runner.Con
 	cb.MockNotifyHookFailed.Call(hookName, ctx)
 }
 
+func (cb *ExecuteHookCallbacks) SetHookLimitStatus(message string) error {
+	cb.hookLimitMessage = message
+	return nil
+}
+
+func (cb *ExecuteHookCallbacks) SetDeferredEvents(events []hook.DeferredEvent) error {
+	if cb.deferredEventsErr != nil {
+		return cb.deferredEventsErr
+	}
+	cb.deferredEvents = events
+	return nil
+}
+
 type MockCommitHook struct {
 	gotHook *hook.Info
 	err     error
@@ -206,12 +222,18 @@ func (mock *MockCommitHook) Call(hookInfo hook.Info) error {
 type CommitHookCallbacks struct {
 	operation.Callbacks
 	*MockCommitHook
+	deferredEvents []hook.DeferredEvent
 }
 
 func (cb *CommitHookCallbacks) CommitHook(hookInfo hook.Info) error {
 	return cb.MockCommitHook.Call(hookInfo)
 }
 
+func (cb *CommitHookCallbacks) SetDeferredEvents(events []hook.DeferredEvent) error {
+	cb.deferredEvents = events
+	return nil
+}
+
 type MockNewActionRunner struct {
 	gotActionId *string
 	runner      *MockRunner
@@ -271,6 +293,7 @@ type MockContext struct {
 	status          jujuc.StatusInfo
 	isLeader        bool
 	relation        *MockRelation
+	deferredEvents  []hook.DeferredEvent
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -302,6 +325,10 @@ func (mock *MockContext) UnitStatus() (*jujuc.StatusInfo, error) {
 	return &mock.status, nil
 }
 
+func (mock *MockContext) DeferredEvents() []hook.DeferredEvent {
+	return mock.deferredEvents
+}
+
 func (mock *MockContext) Prepare() error {
 	mock.MethodCall(mock, "Prepare")
 	return mock.NextErr()
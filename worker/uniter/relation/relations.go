@@ -360,6 +360,25 @@ func nextRelationHook(
 		}
 	}
 
+	// Likewise scan for remote applications whose latest settings version
+	// is not reflected in local state.
+	allAppNames := set.NewStrings()
+	for appName := range remote.ApplicationMembers {
+		allAppNames.Add(appName)
+	}
+	for _, appName := range allAppNames.SortedValues() {
+		remoteChangeVersion := remote.ApplicationMembers[appName]
+		localChangeVersion, found := local.ApplicationMembers[appName]
+		if !found || remoteChangeVersion != localChangeVersion {
+			return hook.Info{
+				Kind:              hooks.RelationChanged,
+				RelationId:        relationId,
+				RemoteApplication: appName,
+				ChangeVersion:     remoteChangeVersion,
+			}, nil
+		}
+	}
+
 	// Nothing left to do for this relation.
 	return hook.Info{}, resolver.ErrNoOperation
 }
@@ -430,6 +430,52 @@ func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 	}, &numCalls)
 }
 
+func (s *relationsSuite) TestHookRelationChangedApplication(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedAPICalls()
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+	}, &numCalls)
+
+	// A change to the remote application's settings should trigger
+	// a relation-changed hook with no remote unit.
+	remoteRelationSnapshot := remotestate.RelationSnapshot{
+		Life: params.Alive,
+		ApplicationMembers: map[string]int64{
+			"wordpress": 3,
+		},
+	}
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: remoteRelationSnapshot,
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	hookInfo := op.(*mockOperation).hookInfo
+	c.Assert(hookInfo.Kind, gc.Equals, hooks.RelationChanged)
+	c.Assert(hookInfo.RemoteUnit, gc.Equals, "")
+	c.Assert(hookInfo.RemoteApplication, gc.Equals, "wordpress")
+	c.Assert(hookInfo.ChangeVersion, gc.Equals, int64(3))
+
+	_, err = r.PrepareHook(hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.CommitHook(hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once committed, the same version is not seen as a change.
+	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *relationsSuite) TestHookRelationChangedSuspended(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedAPICalls()
@@ -32,6 +32,11 @@ type State struct {
 	// ChangedPending indicates that a "relation-changed" hook for the given
 	// unit name must be the first hook.Info to be sent to the output channel.
 	ChangedPending string
+
+	// ApplicationMembers is a map from application name to the last
+	// application settings change version for which a hook.Info was
+	// delivered on the output channel.
+	ApplicationMembers map[string]int64
 }
 
 // copy returns an independent copy of the state.
@@ -46,6 +51,12 @@ func (s *State) copy() *State {
 			copy.Members[m] = v
 		}
 	}
+	if s.ApplicationMembers != nil {
+		copy.ApplicationMembers = map[string]int64{}
+		for app, v := range s.ApplicationMembers {
+			copy.ApplicationMembers[app] = v
+		}
+	}
 	return copy
 }
 
@@ -68,6 +79,12 @@ func (s *State) Validate(hi hook.Info) (err error) {
 		}
 		return fmt.Errorf(`cannot run "relation-broken" while units still present`)
 	}
+	if unit == "" && hi.RemoteApplication != "" && kind == hooks.RelationChanged {
+		if s.ChangedPending != "" {
+			return fmt.Errorf(`expected "relation-changed" for %q`, s.ChangedPending)
+		}
+		return nil
+	}
 	if s.ChangedPending != "" {
 		if unit != s.ChangedPending || kind != hooks.RelationChanged {
 			return fmt.Errorf(`expected "relation-changed" for %q`, s.ChangedPending)
@@ -104,7 +121,11 @@ func (d *StateDir) State() *State {
 func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 	d = &StateDir{
 		filepath.Join(dirPath, strconv.Itoa(relationId)),
-		State{relationId, map[string]int64{}, ""},
+		State{
+			RelationId:         relationId,
+			Members:            map[string]int64{},
+			ApplicationMembers: map[string]int64{},
+		},
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot load relation state from %q", d.path)
 	if _, err := os.Stat(d.path); os.IsNotExist(err) {
@@ -118,7 +139,10 @@ func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 	}
 	for _, fi := range fis {
 		// Entries with names ending in "-" followed by an integer must be
-		// files containing valid unit data; all other names are ignored.
+		// files containing valid unit data. Application names can never
+		// end that way, so other entries prefixed with appFilePrefix must
+		// be files containing valid application data; all other names are
+		// ignored.
 		name := fi.Name()
 		i := strings.LastIndex(name, "-")
 		if i == -1 {
@@ -127,6 +151,18 @@ func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 		svcName := name[:i]
 		unitId := name[i+1:]
 		if _, err := strconv.Atoi(unitId); err != nil {
+			if !strings.HasPrefix(name, appFilePrefix) {
+				continue
+			}
+			appName := strings.TrimPrefix(name, appFilePrefix)
+			var info diskInfo
+			if err = utils.ReadYaml(filepath.Join(d.path, name), &info); err != nil {
+				return nil, fmt.Errorf("invalid application file %q: %v", name, err)
+			}
+			if info.ChangeVersion == nil {
+				return nil, fmt.Errorf(`invalid application file %q: "changed-version" not set`, name)
+			}
+			d.state.ApplicationMembers[appName] = *info.ChangeVersion
 			continue
 		}
 		unitName := svcName + "/" + unitId
@@ -199,6 +235,16 @@ func (d *StateDir) Write(hi hook.Info) (err error) {
 	if hi.Kind == hooks.RelationBroken {
 		return d.Remove()
 	}
+	if hi.RemoteUnit == "" && hi.RemoteApplication != "" {
+		path := filepath.Join(d.path, appFilePrefix+hi.RemoteApplication)
+		di := diskInfo{ChangeVersion: &hi.ChangeVersion}
+		if err := utils.WriteYaml(path, &di); err != nil {
+			return err
+		}
+		// If write was successful, update own state.
+		d.state.ApplicationMembers[hi.RemoteApplication] = hi.ChangeVersion
+		return nil
+	}
 	name := strings.Replace(hi.RemoteUnit, "/", "-", 1)
 	path := filepath.Join(d.path, name)
 	if hi.Kind == hooks.RelationDeparted {
@@ -223,16 +269,28 @@ func (d *StateDir) Write(hi hook.Info) (err error) {
 	return nil
 }
 
-// Remove removes the directory if it exists and is empty.
+// Remove removes the directory if it exists and is empty of
+// unit data. Any application data is removed along with it.
 func (d *StateDir) Remove() error {
+	for appName := range d.state.ApplicationMembers {
+		path := filepath.Join(d.path, appFilePrefix+appName)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	if err := os.Remove(d.path); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.Members = nil
+	d.state.ApplicationMembers = nil
 	return nil
 }
 
+// appFilePrefix prefixes the names of files holding application
+// settings change versions.
+const appFilePrefix = "app-"
+
 // diskInfo defines the relation unit data serialization.
 type diskInfo struct {
 	ChangeVersion  *int64 `yaml:"change-version"`
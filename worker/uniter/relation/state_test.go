@@ -68,6 +68,49 @@ func (s *StateDirSuite) TestReadStateDirValid(c *gc.C) {
 	c.Assert(state.ChangedPending, gc.Equals, "baz-qux/7")
 }
 
+func (s *StateDirSuite) TestReadStateDirApplication(c *gc.C) {
+	basedir := c.MkDir()
+	setUpDir(c, basedir, "123", map[string]string{
+		"app-1":       "change-version: 99\n",
+		"app-foo-bar": "change-version: 5\n",
+	})
+
+	dir, err := relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	state := dir.State()
+	c.Assert(msi(state.Members), gc.DeepEquals, msi{"app/1": 99})
+	c.Assert(msi(state.ApplicationMembers), gc.DeepEquals, msi{"foo-bar": 5})
+}
+
+func (s *StateDirSuite) TestWriteApplication(c *gc.C) {
+	basedir := c.MkDir()
+	dir, err := relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dir.Ensure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	hi := hook.Info{
+		Kind:              hooks.RelationChanged,
+		RelationId:        123,
+		RemoteApplication: "foo",
+		ChangeVersion:     7,
+	}
+	err = dir.State().Validate(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dir.Write(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msi(dir.State().ApplicationMembers), gc.DeepEquals, msi{"foo": 7})
+
+	fresh, err := relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msi(fresh.State().ApplicationMembers), gc.DeepEquals, msi{"foo": 7})
+
+	err = dir.Write(hook.Info{Kind: hooks.RelationBroken, RelationId: 123})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(basedir, "123"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 var badRelationsTests = []struct {
 	contents map[string]string
 	subdirs  []string
@@ -347,6 +390,9 @@ func assertState(c *gc.C, dir *relation.StateDir, relsdir string, relationId int
 		Members:        map[string]int64(members),
 		ChangedPending: pending,
 	}
+	if members != nil {
+		expect.ApplicationMembers = map[string]int64{}
+	}
 	c.Assert(dir.State(), gc.DeepEquals, expect)
 	if deleted {
 		_, err := os.Stat(filepath.Join(relsdir, strconv.Itoa(relationId)))
@@ -105,7 +105,6 @@ type mockState struct {
 	storageAttachment           map[params.StorageAttachmentId]params.StorageAttachment
 	relationUnitsWatchers       map[names.RelationTag]*mockRelationUnitsWatcher
 	storageAttachmentWatchers   map[names.StorageTag]*mockNotifyWatcher
-	updateStatusInterval        time.Duration
 	updateStatusIntervalWatcher *mockNotifyWatcher
 }
 
@@ -186,10 +185,6 @@ func (st *mockState) WatchStorageAttachment(
 	return watcher, nil
 }
 
-func (st *mockState) UpdateStatusHookInterval() (time.Duration, error) {
-	return st.updateStatusInterval, nil
-}
-
 func (st *mockState) WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error) {
 	return st.updateStatusIntervalWatcher, nil
 }
@@ -207,6 +202,9 @@ type mockUnit struct {
 	storageWatcher                   *mockStringsWatcher
 	actionWatcher                    *mockStringsWatcher
 	relationsWatcher                 *mockStringsWatcher
+	updateStatusInterval             time.Duration
+	secretRotationsWatcher           *mockNotifyWatcher
+	secretRotations                  map[string]time.Time
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -265,6 +263,18 @@ func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesPrepareStarted, nil
 }
 
+func (u *mockUnit) UpdateStatusHookInterval() (time.Duration, error) {
+	return u.updateStatusInterval, nil
+}
+
+func (u *mockUnit) WatchSecretRotations() (watcher.NotifyWatcher, error) {
+	return u.secretRotationsWatcher, nil
+}
+
+func (u *mockUnit) SecretRotations() (map[string]time.Time, error) {
+	return u.secretRotations, nil
+}
+
 func (m *mockUnit) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
 	return nil
 }
@@ -4,6 +4,8 @@
 package remotestate
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
@@ -46,6 +48,23 @@ type Snapshot struct {
 	// set to ResolvedNone.
 	RetryHookVersion int
 
+	// DeferredHookVersion increments each time a hook
+	// deferred with defer-hook may have become due.
+	DeferredHookVersion int
+
+	// HealthVersion increments each time the results of
+	// the workload health probes change.
+	HealthVersion int
+
+	// SecretRotations holds when each secret owned by the
+	// unit's application is next due to be rotated, keyed
+	// by secret id.
+	SecretRotations map[string]time.Time
+
+	// SecretRotationVersion increments each time a secret
+	// rotation may have become due.
+	SecretRotationVersion int
+
 	// ConfigVersion is the last published version of
 	// the unit's config settings.
 	ConfigVersion int
@@ -79,6 +98,10 @@ type RelationSnapshot struct {
 	Life      params.Life
 	Suspended bool
 	Members   map[string]int64
+
+	// ApplicationMembers maps the names of the remote applications
+	// in the relation to the versions of their relation settings.
+	ApplicationMembers map[string]int64
 }
 
 // StorageSnapshot has information relating to a storage
@@ -29,7 +29,6 @@ type State interface {
 	WatchRelationUnits(names.RelationTag, names.UnitTag) (watcher.RelationUnitsWatcher, error)
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error)
-	UpdateStatusHookInterval() (time.Duration, error)
 }
 
 type Unit interface {
@@ -49,6 +48,15 @@ type Unit interface {
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	// UpdateStatusHookInterval returns how often the unit should run
+	// its update-status hook; zero means update-status is disabled.
+	UpdateStatusHookInterval() (time.Duration, error)
+	// WatchSecretRotations returns a watcher that fires when the
+	// secrets owned by the unit's application change.
+	WatchSecretRotations() (watcher.NotifyWatcher, error)
+	// SecretRotations returns when each secret owned by the unit's
+	// application is next due to be rotated, keyed by secret id.
+	SecretRotations() (map[string]time.Time, error)
 }
 
 type Application interface {
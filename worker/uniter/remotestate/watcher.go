@@ -38,6 +38,9 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       UpdateStatusTimerFunc
 	commandChannel            <-chan string
 	retryHookChannel          watcher.NotifyChannel
+	deferredHookChannel       watcher.NotifyChannel
+	healthChannel             watcher.NotifyChannel
+	secretRotationChannel     watcher.NotifyChannel
 	applicationChannel        watcher.NotifyChannel
 
 	catacomb catacomb.Catacomb
@@ -50,14 +53,17 @@ type RemoteStateWatcher struct {
 // WatcherConfig holds configuration parameters for the
 // remote state watcher.
 type WatcherConfig struct {
-	State               State
-	LeadershipTracker   leadership.Tracker
-	UpdateStatusChannel UpdateStatusTimerFunc
-	CommandChannel      <-chan string
-	RetryHookChannel    watcher.NotifyChannel
-	ApplicationChannel  watcher.NotifyChannel
-	UnitTag             names.UnitTag
-	ModelType           model.ModelType
+	State                 State
+	LeadershipTracker     leadership.Tracker
+	UpdateStatusChannel   UpdateStatusTimerFunc
+	CommandChannel        <-chan string
+	RetryHookChannel      watcher.NotifyChannel
+	DeferredHookChannel   watcher.NotifyChannel
+	HealthChannel         watcher.NotifyChannel
+	SecretRotationChannel watcher.NotifyChannel
+	ApplicationChannel    watcher.NotifyChannel
+	UnitTag               names.UnitTag
+	ModelType             model.ModelType
 }
 
 func (w WatcherConfig) validate() error {
@@ -83,6 +89,9 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		deferredHookChannel:       config.DeferredHookChannel,
+		healthChannel:             config.HealthChannel,
+		secretRotationChannel:     config.SecretRotationChannel,
 		applicationChannel:        config.ApplicationChannel,
 		modelType:                 config.ModelType,
 		// Note: it is important that the out channel be buffered!
@@ -128,13 +137,17 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	snapshot.Relations = make(map[int]RelationSnapshot)
 	for id, relationSnapshot := range w.current.Relations {
 		relationSnapshotCopy := RelationSnapshot{
-			Life:      relationSnapshot.Life,
-			Suspended: relationSnapshot.Suspended,
-			Members:   make(map[string]int64),
+			Life:               relationSnapshot.Life,
+			Suspended:          relationSnapshot.Suspended,
+			Members:            make(map[string]int64),
+			ApplicationMembers: make(map[string]int64),
 		}
 		for name, version := range relationSnapshot.Members {
 			relationSnapshotCopy.Members[name] = version
 		}
+		for name, version := range relationSnapshot.ApplicationMembers {
+			relationSnapshotCopy.ApplicationMembers[name] = version
+		}
 		snapshot.Relations[id] = relationSnapshotCopy
 	}
 	snapshot.Storage = make(map[names.StorageTag]StorageSnapshot)
@@ -145,6 +158,12 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	if w.current.SecretRotations != nil {
+		snapshot.SecretRotations = make(map[string]time.Time)
+		for id, next := range w.current.SecretRotations {
+			snapshot.SecretRotations[id] = next
+		}
+	}
 	return snapshot
 }
 
@@ -323,6 +342,20 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	// Secrets are not supported by older controllers, so the
+	// secrets watcher is not required for the initial event.
+	var secretRotationChanges watcher.NotifyChannel
+	secretRotationsw, err := w.unit.WatchSecretRotations()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		if err := w.catacomb.Add(secretRotationsw); err != nil {
+			return errors.Trace(err)
+		}
+		secretRotationChanges = secretRotationsw.Changes()
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -369,8 +402,29 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	var updateStatusInterval time.Duration
 	var updateStatusTimer <-chan time.Time
 	resetUpdateStatusTimer := func() {
+		if updateStatusInterval == 0 {
+			// The application has disabled update-status.
+			updateStatusTimer = nil
+			return
+		}
 		updateStatusTimer = w.updateStatusChannel(updateStatusInterval).After()
 	}
+	// updateStatusIntervalChanged fetches the unit's update status
+	// interval, which the application may override, and resets the
+	// timer if it has changed or has not yet been started.
+	updateStatusIntervalChanged := func(started bool) error {
+		interval, err := w.unit.UpdateStatusHookInterval()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if started && interval == updateStatusInterval {
+			return nil
+		}
+		logger.Debugf("update status interval is %v", interval)
+		updateStatusInterval = interval
+		resetUpdateStatusTimer()
+		return nil
+	}
 	configChanged := func(changeOccured bool, event *bool) error {
 		logger.Debugf("got config change: ok=%t", changeOccured)
 		if !changeOccured {
@@ -418,6 +472,14 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			// Application config may override the update status interval;
+			// until the model's interval has been seen there is no timer
+			// to adjust.
+			if seenUpdateStatusIntervalChange {
+				if err := updateStatusIntervalChanged(true); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case _, ok := <-upgradeSeriesChanges:
 			logger.Debugf("got upgrade series change")
 			if !ok {
@@ -482,15 +544,11 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if !ok {
 				return errors.New("update status interval watcher closed")
 			}
+			wasActive := seenUpdateStatusIntervalChange
 			observedEvent(&seenUpdateStatusIntervalChange)
-
-			var err error
-			updateStatusInterval, err = w.st.UpdateStatusHookInterval()
-			if err != nil {
+			if err := updateStatusIntervalChanged(wasActive); err != nil {
 				return errors.Trace(err)
 			}
-			wasActive := updateStatusTimer != nil
-			resetUpdateStatusTimer()
 			if wasActive {
 				// This is not the first time we've seen an update
 				// status interval change, so there's no need to
@@ -550,6 +608,42 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.retryHookTimerTriggered(); err != nil {
 				return err
 			}
+
+		case _, ok := <-w.deferredHookChannel:
+			if !ok {
+				return errors.New("deferredHookChannel closed")
+			}
+			logger.Debugf("deferred hook timer triggered")
+			if err := w.deferredHookTimerTriggered(); err != nil {
+				return err
+			}
+
+		case _, ok := <-w.healthChannel:
+			if !ok {
+				return errors.New("healthChannel closed")
+			}
+			logger.Debugf("workload health changed")
+			if err := w.healthChanged(); err != nil {
+				return err
+			}
+
+		case _, ok := <-secretRotationChanges:
+			logger.Debugf("got secret rotations change: ok=%t", ok)
+			if !ok {
+				return errors.New("secret rotations watcher closed")
+			}
+			if err := w.secretRotationsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+
+		case _, ok := <-w.secretRotationChannel:
+			if !ok {
+				return errors.New("secretRotationChannel closed")
+			}
+			logger.Debugf("secret rotation timer triggered")
+			if err := w.secretRotationTimerTriggered(); err != nil {
+				return err
+			}
 		}
 
 		// Something changed.
@@ -613,6 +707,46 @@ func (w *RemoteStateWatcher) retryHookTimerTriggered() error {
 	return nil
 }
 
+// deferredHookTimerTriggered is called when the deferred hook timer
+// expires.
+func (w *RemoteStateWatcher) deferredHookTimerTriggered() error {
+	w.mu.Lock()
+	w.current.DeferredHookVersion++
+	w.mu.Unlock()
+	return nil
+}
+
+// healthChanged is called when the results of the workload
+// health probes change.
+func (w *RemoteStateWatcher) healthChanged() error {
+	w.mu.Lock()
+	w.current.HealthVersion++
+	w.mu.Unlock()
+	return nil
+}
+
+// secretRotationsChanged responds to changes in the secrets owned
+// by the unit's application.
+func (w *RemoteStateWatcher) secretRotationsChanged() error {
+	rotations, err := w.unit.SecretRotations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.SecretRotations = rotations
+	w.mu.Unlock()
+	return nil
+}
+
+// secretRotationTimerTriggered is called when the secret rotation
+// timer expires.
+func (w *RemoteStateWatcher) secretRotationTimerTriggered() error {
+	w.mu.Lock()
+	w.current.SecretRotationVersion++
+	w.mu.Unlock()
+	return nil
+}
+
 // unitChanged responds to changes in the unit.
 func (w *RemoteStateWatcher) unitChanged() error {
 	if err := w.unit.Refresh(); err != nil {
@@ -737,9 +871,10 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 	rel Relation, relationTag names.RelationTag, ruw watcher.RelationUnitsWatcher,
 ) error {
 	relationSnapshot := RelationSnapshot{
-		Life:      rel.Life(),
-		Suspended: rel.Suspended(),
-		Members:   make(map[string]int64),
+		Life:               rel.Life(),
+		Suspended:          rel.Suspended(),
+		Members:            make(map[string]int64),
+		ApplicationMembers: make(map[string]int64),
 	}
 	select {
 	case <-w.catacomb.Dying():
@@ -751,6 +886,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 		for unit, settings := range change.Changed {
 			relationSnapshot.Members[unit] = settings.Version
 		}
+		for app, version := range change.AppChanged {
+			relationSnapshot.ApplicationMembers[app] = version
+		}
 	}
 	innerRUW, err := newRelationUnitsWatcher(rel.Id(), ruw, w.relationUnitsChanges)
 	if err != nil {
@@ -775,6 +913,9 @@ func (w *RemoteStateWatcher) relationUnitsChanged(change relationUnitsChange) er
 	for unit, settings := range change.Changed {
 		snapshot.Members[unit] = settings.Version
 	}
+	for app, version := range change.AppChanged {
+		snapshot.ApplicationMembers[app] = version
+	}
 	for _, unit := range change.Departed {
 		delete(snapshot.Members, unit)
 	}
@@ -28,7 +28,10 @@ type WatcherSuite struct {
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testclock.Clock
 
-	applicationWatcher *mockNotifyWatcher
+	applicationWatcher    *mockNotifyWatcher
+	deferredHookChannel   chan struct{}
+	healthChannel         chan struct{}
+	secretRotationChannel chan struct{}
 }
 
 type WatcherSuiteIAAS struct {
@@ -63,12 +66,13 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:                   newMockStringsWatcher(),
 			actionWatcher:                    newMockStringsWatcher(),
 			relationsWatcher:                 newMockStringsWatcher(),
+			updateStatusInterval:             5 * time.Minute,
+			secretRotationsWatcher:           newMockNotifyWatcher(),
 		},
 		relations:                   make(map[names.RelationTag]*mockRelation),
 		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:       make(map[names.RelationTag]*mockRelationUnitsWatcher),
 		storageAttachmentWatchers:   make(map[names.StorageTag]*mockNotifyWatcher),
-		updateStatusInterval:        5 * time.Minute,
 		updateStatusIntervalWatcher: newMockNotifyWatcher(),
 	}
 
@@ -79,6 +83,9 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testclock.NewClock(time.Now())
+	s.deferredHookChannel = make(chan struct{}, 1)
+	s.healthChannel = make(chan struct{}, 1)
+	s.secretRotationChannel = make(chan struct{}, 1)
 }
 
 func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
@@ -91,11 +98,14 @@ func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
 	s.applicationWatcher = s.st.unit.application.applicationWatcher
 	s.st.unit.upgradeSeriesWatcher = newMockNotifyWatcher()
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
-		State:               s.st,
-		ModelType:           s.modelType,
-		LeadershipTracker:   s.leadership,
-		UnitTag:             s.st.unit.tag,
-		UpdateStatusChannel: statusTicker,
+		State:                 s.st,
+		ModelType:             s.modelType,
+		LeadershipTracker:     s.leadership,
+		UnitTag:               s.st.unit.tag,
+		UpdateStatusChannel:   statusTicker,
+		DeferredHookChannel:   s.deferredHookChannel,
+		HealthChannel:         s.healthChannel,
+		SecretRotationChannel: s.secretRotationChannel,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -109,12 +119,15 @@ func (s *WatcherSuiteCAAS) SetUpTest(c *gc.C) {
 
 	s.applicationWatcher = newMockNotifyWatcher()
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
-		State:               s.st,
-		ModelType:           s.modelType,
-		LeadershipTracker:   s.leadership,
-		UnitTag:             s.st.unit.tag,
-		UpdateStatusChannel: statusTicker,
-		ApplicationChannel:  s.applicationWatcher.Changes(),
+		State:                 s.st,
+		ModelType:             s.modelType,
+		LeadershipTracker:     s.leadership,
+		UnitTag:               s.st.unit.tag,
+		UpdateStatusChannel:   statusTicker,
+		DeferredHookChannel:   s.deferredHookChannel,
+		HealthChannel:         s.healthChannel,
+		ApplicationChannel:    s.applicationWatcher.Changes(),
+		SecretRotationChannel: s.secretRotationChannel,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -537,9 +550,10 @@ func (s *WatcherSuite) TestRelationsChanged(c *gc.C) {
 		jc.DeepEquals,
 		map[int]remotestate.RelationSnapshot{
 			123: {
-				Life:      params.Alive,
-				Suspended: false,
-				Members:   map[string]int64{"mysql/1": 1, "mysql/2": 2},
+				Life:               params.Alive,
+				Suspended:          false,
+				Members:            map[string]int64{"mysql/1": 1, "mysql/2": 2},
+				ApplicationMembers: map[string]int64{},
 			},
 		},
 	)
@@ -618,6 +632,16 @@ func (s *WatcherSuite) TestRelationUnitsChanged(c *gc.C) {
 		jc.DeepEquals,
 		map[string]int64{"mysql/2": 1},
 	)
+
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		AppChanged: map[string]int64{"mysql": 3},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(
+		s.watcher.Snapshot().Relations[123].ApplicationMembers,
+		jc.DeepEquals,
+		map[string]int64{"mysql": 3},
+	)
 }
 
 func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
@@ -668,6 +692,49 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestDeferredHookTriggered(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.deferredHookChannel <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DeferredHookVersion, gc.Equals, initial.DeferredHookVersion+1)
+}
+
+func (s *WatcherSuite) TestHealthChanged(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.healthChannel <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().HealthVersion, gc.Equals, initial.HealthVersion+1)
+}
+
+func (s *WatcherSuite) TestSecretRotationsChanged(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	next := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.st.unit.secretRotations = map[string]time.Time{"mysql/root-password": next}
+	s.st.unit.secretRotationsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, map[string]time.Time{
+		"mysql/root-password": next,
+	})
+}
+
+func (s *WatcherSuite) TestSecretRotationTriggered(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.secretRotationChannel <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotationVersion, gc.Equals, initial.SecretRotationVersion+1)
+}
+
 func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 	s.signalAll()
 	initial := s.watcher.Snapshot()
@@ -680,7 +747,7 @@ func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+1)
 
 	// Change the update status interval to 10 seconds.
-	s.st.updateStatusInterval = 10 * time.Second
+	s.st.unit.updateStatusInterval = 10 * time.Second
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
 
 	// Advance 10 seconds; the timer should be triggered.
@@ -690,6 +757,43 @@ func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestUpdateStatusIntervalApplicationOverride(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// The application overrides the update status interval, which
+	// is delivered with a change to the application config.
+	s.st.unit.updateStatusInterval = 30 * time.Minute
+	s.st.unit.applicationConfigSettingsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// The original interval no longer triggers the timer.
+	s.waitAlarmsStable(c)
+	s.clock.Advance(5 * time.Minute)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion)
+
+	s.clock.Advance(25 * time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+1)
+}
+
+func (s *WatcherSuite) TestUpdateStatusIntervalDisabled(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.updateStatusInterval = 0
+	s.st.unit.applicationConfigSettingsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(time.Hour)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion)
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
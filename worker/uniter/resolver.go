@@ -4,6 +4,9 @@
 package uniter
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6/hooks"
 
@@ -18,23 +21,29 @@ import (
 
 // ResolverConfig defines configuration for the uniter resolver.
 type ResolverConfig struct {
-	ModelType           model.ModelType
-	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
-	ShouldRetryHooks    bool
-	StartRetryHookTimer func()
-	StopRetryHookTimer  func()
-	UpgradeSeries       resolver.Resolver
-	Leadership          resolver.Resolver
-	Actions             resolver.Resolver
-	Relations           resolver.Resolver
-	Storage             resolver.Resolver
-	Commands            resolver.Resolver
+	ModelType              model.ModelType
+	ClearResolved          func() error
+	ReportHookError        func(hook.Info) error
+	ShouldRetryHooks       bool
+	MaxHookRetries         int
+	IsRetriableHook        func(hooks.Kind) bool
+	StartRetryHookTimer    func()
+	StopRetryHookTimer     func()
+	Clock                  clock.Clock
+	ScheduleDeferredHook   func(time.Time)
+	ScheduleSecretRotation func(time.Time)
+	UpgradeSeries          resolver.Resolver
+	Leadership             resolver.Resolver
+	Actions                resolver.Resolver
+	Relations              resolver.Resolver
+	Storage                resolver.Resolver
+	Commands               resolver.Resolver
 }
 
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+	retryHookAttempts     int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -87,6 +96,11 @@ func (s *uniterResolver) NextOp(
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
 	}
+	if localState.Kind != operation.RunHook || localState.Step != operation.Pending {
+		// No hook is awaiting error resolution, so start
+		// counting retries afresh when one next fails.
+		s.retryHookAttempts = 0
+	}
 
 	op, err = s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
@@ -161,6 +175,20 @@ func (s *uniterResolver) nextOpConflicted(
 	return nil, resolver.ErrWaiting
 }
 
+// shouldRetryHook returns true if a failed hook of the given kind
+// should be retried automatically, given the retries made so far.
+// A MaxHookRetries of 0 means no limit, and a nil IsRetriableHook
+// allows every hook kind to be retried.
+func (s *uniterResolver) shouldRetryHook(kind hooks.Kind) bool {
+	if !s.config.ShouldRetryHooks {
+		return false
+	}
+	if s.config.IsRetriableHook != nil && !s.config.IsRetriableHook(kind) {
+		return false
+	}
+	return s.config.MaxHookRetries == 0 || s.retryHookAttempts < s.config.MaxHookRetries
+}
+
 func (s *uniterResolver) nextOpHookError(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
@@ -187,9 +215,10 @@ func (s *uniterResolver) nextOpHookError(
 			// timer. If the hook succeeds, we'll enter nextOp
 			// and stop the timer.
 			s.retryHookTimerStarted = false
+			s.retryHookAttempts++
 			return opFactory.NewRunHook(*localState.Hook)
 		}
-		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+		if !s.retryHookTimerStarted && s.shouldRetryHook(localState.Hook.Kind) {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
@@ -200,6 +229,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -207,6 +237,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -294,6 +325,30 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	if localState.HealthVersion != remoteState.HealthVersion {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.HealthChanged})
+	}
+
+	// Deferred hooks run in order once they are due, and a wakeup is
+	// scheduled for the next one otherwise.
+	if len(localState.Deferred) > 0 {
+		next := localState.Deferred[0]
+		if !next.Time.After(s.config.Clock.Now()) {
+			return opFactory.NewRunHook(hook.Info{Kind: hook.Deferred, EventName: next.Name})
+		}
+		s.config.ScheduleDeferredHook(next.Time)
+	}
+
+	// Only the leader rotates the application's secrets.
+	if remoteState.Leader {
+		if id, due, ok := nextSecretRotation(localState, remoteState); ok {
+			if !due.After(s.config.Clock.Now()) {
+				return opFactory.NewRunHook(hook.Info{Kind: hook.SecretRotate, SecretID: id})
+			}
+			s.config.ScheduleSecretRotation(due)
+		}
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -302,6 +357,24 @@ func (s *uniterResolver) nextOp(
 	return nil, resolver.ErrNoOperation
 }
 
+// nextSecretRotation returns the secret that is next due to be rotated,
+// ignoring those whose rotation has already been committed.
+func nextSecretRotation(localState resolver.LocalState, remoteState remotestate.Snapshot) (string, time.Time, bool) {
+	var (
+		nextID  string
+		nextDue time.Time
+	)
+	for id, due := range remoteState.SecretRotations {
+		if rotated, ok := localState.SecretRotations[id]; ok && rotated.Equal(due) {
+			continue
+		}
+		if nextID == "" || due.Before(nextDue) || (due.Equal(nextDue) && id < nextID) {
+			nextID, nextDue = id, due
+		}
+	}
+	return nextID, nextDue, nextID != ""
+}
+
 // NopResolver is a resolver that does nothing.
 type NopResolver struct{}
 
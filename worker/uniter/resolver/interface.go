@@ -4,6 +4,8 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 
@@ -91,6 +93,16 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// HealthVersion is the version of the workload health probe
+	// results from remotestate.Snapshot for which a health-changed
+	// hook has been committed.
+	HealthVersion int
+
+	// SecretRotations holds, for each secret owned by the unit's
+	// application, the rotation time from remotestate.Snapshot for
+	// which a secret-rotate hook has been committed.
+	SecretRotations map[string]time.Time
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -4,6 +4,8 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
@@ -160,6 +162,19 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.HealthChanged:
+		v := s.RemoteState.HealthVersion
+		op = onCommitWrapper{op, func() {
+			s.LocalState.HealthVersion = v
+		}}
+	case hook.SecretRotate:
+		id, due := info.SecretID, s.RemoteState.SecretRotations[info.SecretID]
+		op = onCommitWrapper{op, func() {
+			if s.LocalState.SecretRotations == nil {
+				s.LocalState.SecretRotations = make(map[string]time.Time)
+			}
+			s.LocalState.SecretRotations[id] = due
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
@@ -5,6 +5,7 @@ package resolver_test
 
 import (
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -149,6 +150,39 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestHealthChanged(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.HealthVersion = 1
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.HealthChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.HealthVersion = 2
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Local state's HealthVersion should be set to what RemoteState's
+	// HealthVersion was when the operation was constructed.
+	c.Assert(f.LocalState.HealthVersion, gc.Equals, 1)
+}
+
+func (s *ResolverOpFactorySuite) TestSecretRotate(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	due := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	f.RemoteState.SecretRotations = map[string]time.Time{"mysql/root-password": due}
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.SecretRotate, SecretID: "mysql/root-password"})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.SecretRotations = map[string]time.Time{"mysql/root-password": due.Add(time.Hour)}
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Local state should record the rotation time RemoteState had
+	// when the operation was constructed.
+	c.Assert(f.LocalState.SecretRotations, jc.DeepEquals, map[string]time.Time{"mysql/root-password": due})
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
@@ -4,6 +4,9 @@
 package uniter_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -305,6 +308,164 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookErrorRetryLimit(c *gc.C) {
+	s.resolverConfig.MaxHookRetries = 1
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+
+	s.remoteState.RetryHookVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+	localState.RetryHookVersion = 1
+
+	// The retry failed, and no more retries are allowed.
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+}
+
+func (s *resolverSuite) TestHookErrorNotRetriable(c *gc.C) {
+	s.resolverConfig.IsRetriableHook = func(kind hooks.Kind) bool {
+		return kind != hooks.UpgradeCharm
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.UpgradeCharm,
+			},
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckNoCalls(c)
+
+	localState.Hook = &hook.Info{Kind: hooks.Install}
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+}
+
+func (s *resolverSuite) TestDeferredHook(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(t0.Add(-time.Minute))
+	s.resolverConfig.Clock = clock
+	s.resolverConfig.ScheduleDeferredHook = func(at time.Time) {
+		s.stub.AddCall("ScheduleDeferredHook", at)
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+			Deferred: []hook.DeferredEvent{
+				{Name: "check-again", Time: t0},
+				{Name: "rotate-logs", Time: t0.Add(time.Hour)},
+			},
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCall(c, 0, "ScheduleDeferredHook", t0)
+
+	clock.Advance(time.Minute)
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run deferred (check-again) hook")
+}
+
+func (s *resolverSuite) TestHealthChanged(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.HealthVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run health-changed hook")
+
+	localState.HealthVersion = 1
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestSecretRotate(c *gc.C) {
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(t0.Add(-time.Minute))
+	s.resolverConfig.Clock = clock
+	s.resolverConfig.ScheduleSecretRotation = func(at time.Time) {
+		s.stub.AddCall("ScheduleSecretRotation", at)
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.SecretRotations = map[string]time.Time{
+		"mysql/root-password": t0,
+		"mysql/api-key":       t0.Add(time.Hour),
+	}
+
+	// Only the leader rotates secrets.
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckNoCalls(c)
+
+	s.remoteState.Leader = true
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCall(c, 0, "ScheduleSecretRotation", t0)
+
+	clock.Advance(time.Minute)
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run secret-rotate (mysql/root-password) hook")
+
+	// Once the rotation is committed, the next secret is scheduled.
+	localState.SecretRotations = map[string]time.Time{"mysql/root-password": t0}
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCall(c, 1, "ScheduleSecretRotation", t0.Add(time.Hour))
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
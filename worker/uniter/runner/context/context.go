@@ -25,6 +25,8 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/healthprobe"
+	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -164,6 +166,11 @@ type HookContext struct {
 	// or if it is running a relation-broken hook.
 	remoteUnitName string
 
+	// remoteApplicationName identifies the application of the changing
+	// unit, or the changing application itself, of the executing relation
+	// hook. It will be empty if the context is not running a relation hook.
+	remoteApplicationName string
+
 	// relations contains the context for every relation the unit is a member
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
@@ -229,6 +236,31 @@ type HookContext struct {
 	//  slaLevel contains the current SLA level.
 	slaLevel string
 
+	// hookLimits holds the resource limits to apply to hooks run
+	// in this context.
+	hookLimits application.HookLimits
+
+	// hookRecording holds which hooks run in this context should be
+	// recorded for offline replay.
+	hookRecording string
+
+	// deferredEvent holds the name of the deferred event delivered
+	// to the hook run in this context, if any.
+	deferredEvent string
+
+	// deferredEvents holds the events deferred by the hook run in
+	// this context, keyed by name. It is nil unless the context runs
+	// a hook, since only hooks may defer events.
+	deferredEvents map[string]time.Time
+
+	// health holds the results of the workload health probes
+	// delivered to a health-changed hook run in this context.
+	health *healthprobe.Health
+
+	// secretID holds the id of the secret to be rotated by a
+	// secret-rotate hook run in this context.
+	secretID string
+
 	// The cloud specification
 	cloudSpec *params.CloudSpec
 }
@@ -408,6 +440,60 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// HookLimits returns the resource limits to apply to hooks run in
+// this context.
+func (ctx *HookContext) HookLimits() application.HookLimits {
+	return ctx.hookLimits
+}
+
+// HookRecording returns which hooks run in this context should be
+// recorded for offline replay: "failed", "all", or "" for none.
+func (ctx *HookContext) HookRecording() string {
+	return ctx.hookRecording
+}
+
+// DeferHook is part of the jujuc.ContextDeferredHooks interface.
+func (ctx *HookContext) DeferHook(name string, at time.Time) error {
+	if ctx.deferredEvents == nil {
+		return errors.NotSupportedf("deferring events outside a hook")
+	}
+	if name == "" {
+		return errors.NotValidf("empty event name")
+	}
+	ctx.deferredEvents[name] = at
+	return nil
+}
+
+// DeferredEvents returns the events deferred by the hook run in this
+// context, ordered by time.
+func (ctx *HookContext) DeferredEvents() []hook.DeferredEvent {
+	events := make([]hook.DeferredEvent, 0, len(ctx.deferredEvents))
+	for name, at := range ctx.deferredEvents {
+		events = append(events, hook.DeferredEvent{Name: name, Time: at})
+	}
+	hook.SortDeferredEvents(events)
+	return events
+}
+
+// SaveSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) SaveSecret(name string, args jujuc.SecretArgs) (string, error) {
+	return ctx.unit.SaveSecret(name, args.Value, args.Description, args.RotateInterval)
+}
+
+// GetSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) GetSecret(id string) (string, error) {
+	return ctx.unit.SecretValue(id)
+}
+
+// GrantSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) GrantSecret(id string, relationId int) error {
+	relation, ok := ctx.relations[relationId]
+	if !ok {
+		return errors.NotFoundf("relation %d", relationId)
+	}
+	return ctx.unit.GrantSecret(id, relation.ru.Relation().Tag())
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -591,6 +677,15 @@ func (ctx *HookContext) RemoteUnitName() (string, error) {
 	return ctx.remoteUnitName, nil
 }
 
+// RemoteApplicationName returns the name of the remote application
+// for the executing relation hook.
+func (ctx *HookContext) RemoteApplicationName() (string, error) {
+	if ctx.remoteApplicationName == "" {
+		return "", errors.NotFoundf("remote application")
+	}
+	return ctx.remoteApplicationName, nil
+}
+
 func (ctx *HookContext) Relation(id int) (jujuc.ContextRelation, error) {
 	r, found := ctx.relations[id]
 	if !found {
@@ -667,10 +762,27 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 			"JUJU_RELATION="+r.Name(),
 			"JUJU_RELATION_ID="+r.FakeId(),
 			"JUJU_REMOTE_UNIT="+context.remoteUnitName,
+			"JUJU_REMOTE_APP="+context.remoteApplicationName,
 		)
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.deferredEvent != "" {
+		vars = append(vars, "JUJU_DEFERRED_EVENT="+context.deferredEvent)
+	}
+	if context.health != nil {
+		health := "healthy"
+		if !context.health.Healthy() {
+			health = "unhealthy"
+		}
+		vars = append(vars,
+			"JUJU_HEALTH="+health,
+			"JUJU_HEALTH_FAILED="+strings.Join(context.health.Failed(), " "),
+		)
+	}
+	if context.secretID != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretID)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -793,6 +905,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	// The secret has been rotated once the secret-rotate hook
+	// completes, whether or not it saved a new value.
+	if ctx.secretID != "" && writeChanges && ctxErr == nil {
+		if err := ctx.unit.SecretRotated(ctx.secretID); err != nil {
+			err = errors.Annotatef(err, "cannot record rotation of secret %q", ctx.secretID)
+			logger.Errorf("%v", err)
+			ctxErr = err
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
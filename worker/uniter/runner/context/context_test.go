@@ -76,6 +76,13 @@ func (s *InterfaceSuite) TestRelationContextWithRemoteUnitName(c *gc.C) {
 	c.Assert(name, gc.Equals, "u/123")
 }
 
+func (s *InterfaceSuite) TestRelationContextWithRemoteApplicationName(c *gc.C) {
+	ctx := s.GetContext(c, 1, "u/123")
+	name, err := ctx.RemoteApplicationName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "u")
+}
+
 func (s *InterfaceSuite) TestAddingMetricsInWrongContext(c *gc.C) {
 	ctx := s.GetContext(c, 1, "u/123")
 	err := ctx.AddMetric("key", "123", time.Now())
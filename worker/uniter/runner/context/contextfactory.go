@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/uniter/healthprobe"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -60,6 +61,10 @@ type StorageContextAccessor interface {
 // creation time.
 type RelationsFunc func() map[int]*RelationInfo
 
+// HealthFunc is used to get the results of the workload health probes
+// at context creation time.
+type HealthFunc func() healthprobe.Health
+
 type contextFactory struct {
 	// API connection fields; unit should be deprecated, but isn't yet.
 	unit    *uniter.Unit
@@ -81,6 +86,9 @@ type contextFactory struct {
 	getRelationInfos RelationsFunc
 	relationCaches   map[int]*RelationCache
 
+	// Callback to get the workload health.
+	getHealth HealthFunc
+
 	// For generating "unique" context ids.
 	rand *rand.Rand
 }
@@ -92,6 +100,7 @@ type FactoryConfig struct {
 	UnitTag          names.UnitTag
 	Tracker          leadership.Tracker
 	GetRelationInfos RelationsFunc
+	GetHealth        HealthFunc
 	Storage          StorageContextAccessor
 	Paths            Paths
 	Clock            Clock
@@ -140,6 +149,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		machineTag:       machineTag,
 		getRelationInfos: config.GetRelationInfos,
 		relationCaches:   map[int]*RelationCache{},
+		getHealth:        config.GetHealth,
 		storage:          config.Storage,
 		rand:             rand.New(rand.NewSource(time.Now().Unix())),
 		clock:            config.Clock,
@@ -211,6 +221,14 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 	if hookInfo.Kind.IsRelation() {
 		ctx.relationId = hookInfo.RelationId
 		ctx.remoteUnitName = hookInfo.RemoteUnit
+		ctx.remoteApplicationName = hookInfo.RemoteApplication
+		if ctx.remoteApplicationName == "" && hookInfo.RemoteUnit != "" {
+			appName, err := names.UnitApplication(hookInfo.RemoteUnit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ctx.remoteApplicationName = appName
+		}
 		relation, found := ctx.relations[hookInfo.RelationId]
 		if !found {
 			return nil, errors.Errorf("unknown relation id: %v", hookInfo.RelationId)
@@ -234,6 +252,17 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.Deferred {
+		ctx.deferredEvent = hookInfo.EventName
+	}
+	if hookInfo.Kind == hook.SecretRotate {
+		ctx.secretID = hookInfo.SecretID
+	}
+	if hookInfo.Kind == hook.HealthChanged && f.getHealth != nil {
+		health := f.getHealth()
+		ctx.health = &health
+	}
+	ctx.deferredEvents = make(map[string]time.Time)
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -250,6 +279,13 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 	}
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
+	if remoteUnitName != "" {
+		appName, err := names.UnitApplication(remoteUnitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.remoteApplicationName = appName
+	}
 	ctx.id = f.newId("run-commands")
 	return ctx, nil
 }
@@ -315,6 +351,16 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 		info: statusInfo,
 	}
 
+	ctx.hookLimits, err = f.unit.HookLimits()
+	if err != nil {
+		return errors.Annotate(err, "could not retrieve hook limits for unit")
+	}
+
+	ctx.hookRecording, err = f.unit.HookRecording()
+	if err != nil {
+		return errors.Annotate(err, "could not retrieve hook recording mode for unit")
+	}
+
 	if f.modelType == model.IAAS {
 		ctx.machinePorts, err = f.state.AllMachinePorts(f.machineTag)
 		if err != nil {
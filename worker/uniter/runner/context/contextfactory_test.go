@@ -5,6 +5,7 @@ package context_test
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -21,12 +22,14 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/status"
 	environscontext "github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/uniter/healthprobe"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
@@ -37,6 +40,7 @@ type ContextFactorySuite struct {
 	paths      runnertesting.RealPaths
 	factory    context.ContextFactory
 	membership map[int][]string
+	health     healthprobe.Health
 }
 
 var _ = gc.Suite(&ContextFactorySuite{})
@@ -51,6 +55,7 @@ func (s *ContextFactorySuite) SetUpTest(c *gc.C) {
 		UnitTag:          s.unit.Tag().(names.UnitTag),
 		Tracker:          runnertesting.FakeTracker{},
 		GetRelationInfos: s.getRelationInfos,
+		GetHealth:        func() healthprobe.Health { return s.health },
 		Storage:          s.storage,
 		Paths:            s.paths,
 		Clock:            testclock.NewClock(time.Time{}),
@@ -134,6 +139,65 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestNewHookContextDeferHook(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hook.Deferred, EventName: "check-again"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.DeferredEvents(), gc.HasLen, 0)
+
+	t0 := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	c.Assert(ctx.DeferHook("check-again", t0.Add(time.Hour)), jc.ErrorIsNil)
+	c.Assert(ctx.DeferHook("rotate-logs", t0), jc.ErrorIsNil)
+	c.Assert(ctx.DeferHook("check-again", t0.Add(time.Minute)), jc.ErrorIsNil)
+	c.Assert(ctx.DeferredEvents(), jc.DeepEquals, []hook.DeferredEvent{
+		{Name: "rotate-logs", Time: t0},
+		{Name: "check-again", Time: t0.Add(time.Minute)},
+	})
+}
+
+func (s *ContextFactorySuite) TestNewCommandContextDeferHook(c *gc.C) {
+	ctx, err := s.factory.CommandContext(context.CommandInfo{RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeferHook("check-again", time.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ContextFactorySuite) TestNewHookContextHealthChanged(c *gc.C) {
+	s.health = healthprobe.Health{Failures: map[string]healthprobe.Failure{
+		"web": {Output: "connection refused", Status: status.Error},
+		"db":  {Output: "timed out", Status: status.Blocked},
+	}}
+	vars := s.healthChangedVars(c)
+	c.Assert(vars["JUJU_HEALTH"], gc.Equals, "unhealthy")
+	c.Assert(vars["JUJU_HEALTH_FAILED"], gc.Equals, "db web")
+
+	s.health = healthprobe.Health{}
+	vars = s.healthChangedVars(c)
+	c.Assert(vars["JUJU_HEALTH"], gc.Equals, "healthy")
+	c.Assert(vars["JUJU_HEALTH_FAILED"], gc.Equals, "")
+}
+
+func (s *ContextFactorySuite) TestNewHookContextSecretRotate(c *gc.C) {
+	vars := s.hookVars(c, hook.Info{Kind: hook.SecretRotate, SecretID: "u/root-password"})
+	c.Assert(vars["JUJU_SECRET_ID"], gc.Equals, "u/root-password")
+}
+
+func (s *ContextFactorySuite) healthChangedVars(c *gc.C) map[string]string {
+	return s.hookVars(c, hook.Info{Kind: hook.HealthChanged})
+}
+
+func (s *ContextFactorySuite) hookVars(c *gc.C, info hook.Info) map[string]string {
+	ctx, err := s.factory.HookContext(info)
+	c.Assert(err, jc.ErrorIsNil)
+	vars, err := ctx.HookVars(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string]string)
+	for _, v := range vars {
+		parts := strings.SplitN(v, "=", 2)
+		result[parts[0]] = parts[1]
+	}
+	return result
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
@@ -58,6 +58,9 @@ func NewHookContext(
 		assignedMachineTag:  assignedMachineTag,
 		clock:               clock,
 	}
+	if remoteUnitName != "" {
+		ctx.remoteApplicationName, _ = names.UnitApplication(remoteUnitName)
+	}
 	// Get and cache the addresses.
 	var err error
 	ctx.publicAddress, err = unit.PublicAddress()
@@ -96,6 +99,9 @@ func SetEnvironmentHookContextRelation(
 ) {
 	context.relationId = relationId
 	context.remoteUnitName = remoteUnitName
+	if remoteUnitName != "" {
+		context.remoteApplicationName, _ = names.UnitApplication(remoteUnitName)
+	}
 	context.relations = map[int]*ContextRelation{
 		relationId: {
 			endpointName: endpointName,
@@ -26,6 +26,10 @@ type ContextRelation struct {
 	// settings allows read and write access to the relation unit settings.
 	settings *uniter.Settings
 
+	// appSettings allows read and write access to the application's
+	// relation settings; only the leader may write them.
+	appSettings *uniter.Settings
+
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
 }
@@ -72,10 +76,35 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	return ctx.settings, nil
 }
 
-// WriteSettings persists all changes made to the unit's relation settings.
+// ApplicationSettings returns the local application's settings for
+// the relation.
+func (ctx *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	if ctx.appSettings == nil {
+		node, err := ctx.ru.ApplicationSettings()
+		if err != nil {
+			return nil, err
+		}
+		ctx.appSettings = node
+	}
+	return ctx.appSettings, nil
+}
+
+// ReadApplicationSettings returns the relation settings of the
+// named remote application.
+func (ctx *ContextRelation) ReadApplicationSettings(app string) (params.Settings, error) {
+	return ctx.ru.ReadApplicationSettings(app)
+}
+
+// WriteSettings persists all changes made to the unit's and
+// application's relation settings.
 func (ctx *ContextRelation) WriteSettings() (err error) {
 	if ctx.settings != nil {
-		err = ctx.settings.Write()
+		if err = ctx.settings.Write(); err != nil {
+			return err
+		}
+	}
+	if ctx.appSettings != nil {
+		err = ctx.appSettings.Write()
 	}
 	return
 }
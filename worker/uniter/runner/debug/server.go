@@ -21,7 +21,8 @@ import (
 // ServerSession represents a "juju debug-hooks" session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks   set.Strings
+	debugAt string
 
 	output io.Writer
 }
@@ -32,6 +33,13 @@ func (s *ServerSession) MatchHook(hookName string) bool {
 	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
 }
 
+// DebugAt returns the value the debug-code client asked to be passed
+// to hooks in JUJU_DEBUG_AT. It is empty for debug-hooks sessions,
+// which leave it to the user to run hooks.
+func (s *ServerSession) DebugAt() string {
+	return s.debugAt
+}
+
 // waitClientExit executes flock, waiting for the SSH client to exit.
 // This is a var so it can be replaced for testing.
 var waitClientExit = func(s *ServerSession) {
@@ -52,6 +60,9 @@ func (s *ServerSession) RunHook(hookName, charmDir string, env []string) error {
 
 	env = utils.Setenv(env, "JUJU_HOOK_NAME="+hookName)
 	env = utils.Setenv(env, "JUJU_DEBUG="+debugDir)
+	if s.debugAt != "" {
+		env = utils.Setenv(env, "JUJU_DEBUG_AT="+s.debugAt)
+	}
 
 	cmd := exec.Command("/bin/bash", "-s")
 	cmd.Env = env
@@ -78,10 +89,11 @@ func (s *ServerSession) writeDebugFiles(debugDir string) error {
 	// hook.sh does not inherit environment variables,
 	// so we must insert the path to the directory
 	// containing env.sh for it to source.
-	debugHooksHookScript := strings.Replace(
-		debugHooksHookScript,
-		"__JUJU_DEBUG__", debugDir, -1,
-	)
+	hookScript := debugHooksHookScript
+	if s.debugAt != "" {
+		hookScript = debugCodeHookScript
+	}
+	hookScript = strings.Replace(hookScript, "__JUJU_DEBUG__", debugDir, -1)
 
 	type file struct {
 		filename string
@@ -91,7 +103,7 @@ func (s *ServerSession) writeDebugFiles(debugDir string) error {
 	files := []file{
 		{"welcome.msg", debugHooksWelcomeMessage, 0644},
 		{"init.sh", debugHooksInitScript, 0755},
-		{"hook.sh", debugHooksHookScript, 0755},
+		{"hook.sh", hookScript, 0755},
 	}
 	for _, file := range files {
 		if err := ioutil.WriteFile(
@@ -128,7 +140,7 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 		return nil, err
 	}
 	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{HooksContext: c, hooks: hooks}
+	session := &ServerSession{HooksContext: c, hooks: hooks, debugAt: args.DebugAt}
 	return session, nil
 }
 
@@ -173,7 +185,9 @@ new events for this unit without exiting a current debug-session.
 ./hooks/$JUJU_HOOK_NAME # or, equivalently, ./actions/$JUJU_HOOK_NAME
 tmux kill-session -t $JUJU_UNIT_NAME # or, equivalently, CTRL+a d
 
-4. CTRL+a is tmux prefix.
+4. If the charm has a dispatch script, run ./dispatch instead; it is given
+the hook or action to handle in JUJU_DISPATCH_PATH ($JUJU_DISPATCH_PATH).
+5. CTRL+a is tmux prefix.
 
 More help and info is available in the online documentation:
 https://jujucharms.com/docs/authors-hook-debug.html
@@ -190,3 +204,18 @@ const debugHooksHookScript = `#!/bin/bash
 echo $$ > $JUJU_DEBUG/hook.pid
 exec /bin/bash --noprofile --init-file $JUJU_DEBUG/init.sh
 `
+
+// debugCodeHookScript runs the hook or action straight away, rather
+// than leaving the user at a shell, so that a charm seeing JUJU_DEBUG_AT
+// can break into its language debugger in the tmux window.
+const debugCodeHookScript = `#!/bin/bash
+. __JUJU_DEBUG__/env.sh
+echo $$ > $JUJU_DEBUG/hook.pid
+trap 'echo $? > $JUJU_DEBUG/hook_exit_status' EXIT
+cd "$CHARM_DIR"
+if [ -x ./dispatch ]; then
+    ./dispatch
+else
+    ./$JUJU_DISPATCH_PATH
+fi
+`
@@ -103,6 +103,25 @@ func (s *DebugHooksServerSuite) TestFindSession(c *gc.C) {
 	c.Assert(session.MatchHook("foo bar baz"), jc.IsFalse)
 }
 
+func (s *DebugHooksServerSuite) TestFindSessionDebugAt(c *gc.C) {
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte(`{hooks: [install], debug-at: all}`), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.DebugAt(), gc.Equals, "all")
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+	c.Assert(session.MatchHook("start"), jc.IsFalse)
+
+	// The hook script runs the hook rather than starting a shell.
+	debugDir := c.MkDir()
+	err = session.writeDebugFiles(debugDir)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(debugDir, "hook.sh"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.Contains, "./$JUJU_DISPATCH_PATH")
+	c.Assert(string(data), gc.Not(jc.Contains), "--init-file")
+}
+
 func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte{}, 0777)
 	c.Assert(err, jc.ErrorIsNil)
@@ -41,6 +41,8 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextDeferredHooks
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -63,6 +65,11 @@ type relationHookContext interface {
 	// is associated with if it was found, and an error if it was not found or is not
 	// available.
 	RemoteUnitName() (string, error)
+
+	// RemoteApplicationName returns the name of the remote application the
+	// hook execution is associated with if it was found, and an error if it
+	// was not found or is not available.
+	RemoteApplicationName() (string, error)
 }
 
 // ActionHookContext is the context for an action hook.
@@ -268,6 +275,15 @@ type ContextRelation interface {
 	// ReadSettings returns the settings of any remote unit in the relation.
 	ReadSettings(unit string) (params.Settings, error)
 
+	// ApplicationSettings allows read/write access to the application-level
+	// settings of the local unit's application in this relation. Only the
+	// application's leader may access them.
+	ApplicationSettings() (Settings, error)
+
+	// ReadApplicationSettings returns the application-level settings of
+	// a remote application in the relation.
+	ReadApplicationSettings(app string) (params.Settings, error)
+
 	// Suspended returns true if the relation is suspended.
 	Suspended() bool
 
@@ -303,6 +319,44 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextDeferredHooks is the part of a hook context related to
+// custom events delivered to later hooks.
+type ContextDeferredHooks interface {
+
+	// DeferHook asks for the deferred hook to be run at the given
+	// time, delivering the named event. Deferring an event that is
+	// already pending replaces its time.
+	DeferHook(name string, at time.Time) error
+}
+
+// SecretArgs holds the details of a secret to save.
+type SecretArgs struct {
+	// Value is the value of the secret.
+	Value string
+
+	// Description describes the secret.
+	Description string
+
+	// RotateInterval is how often the secret should be rotated,
+	// or zero if it is not rotated.
+	RotateInterval time.Duration
+}
+
+// ContextSecrets is the part of a hook context related to secrets.
+type ContextSecrets interface {
+
+	// SaveSecret creates or updates the named secret owned by the
+	// unit's application, returning the secret's id.
+	SaveSecret(name string, args SecretArgs) (string, error)
+
+	// GetSecret returns the value of the secret with the given id.
+	GetSecret(id string) (string, error)
+
+	// GrantSecret gives the remote application on the given relation
+	// access to the secret with the given id.
+	GrantSecret(id string, relationId int) error
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
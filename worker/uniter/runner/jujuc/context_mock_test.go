@@ -83,6 +83,32 @@ func (mr *MockContextRelationMockRecorder) ReadSettings(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSettings", reflect.TypeOf((*MockContextRelation)(nil).ReadSettings), arg0)
 }
 
+// ApplicationSettings mocks base method
+func (m *MockContextRelation) ApplicationSettings() (Settings, error) {
+	ret := m.ctrl.Call(m, "ApplicationSettings")
+	ret0, _ := ret[0].(Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationSettings indicates an expected call of ApplicationSettings
+func (mr *MockContextRelationMockRecorder) ApplicationSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSettings", reflect.TypeOf((*MockContextRelation)(nil).ApplicationSettings))
+}
+
+// ReadApplicationSettings mocks base method
+func (m *MockContextRelation) ReadApplicationSettings(arg0 string) (params.Settings, error) {
+	ret := m.ctrl.Call(m, "ReadApplicationSettings", arg0)
+	ret0, _ := ret[0].(params.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadApplicationSettings indicates an expected call of ReadApplicationSettings
+func (mr *MockContextRelationMockRecorder) ReadApplicationSettings(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationSettings", reflect.TypeOf((*MockContextRelation)(nil).ReadApplicationSettings), arg0)
+}
+
 // SetStatus mocks base method
 func (m *MockContextRelation) SetStatus(arg0 relation.Status) error {
 	ret := m.ctrl.Call(m, "SetStatus", arg0)
@@ -0,0 +1,93 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+var validEventName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// DeferHookCommand implements the defer-hook command.
+type DeferHookCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name string
+	in   time.Duration
+	at   string
+}
+
+// NewDeferHookCommand creates a defer-hook command.
+func NewDeferHookCommand(ctx Context) (cmd.Command, error) {
+	return &DeferHookCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *DeferHookCommand) Info() *cmd.Info {
+	doc := `
+defer-hook asks for the deferred hook to be run later, delivering the
+named event in the JUJU_DEFERRED_EVENT environment variable. The event
+is delivered after the duration given with --in, or at the time given
+in RFC3339 format with --at. Pending events survive agent restarts,
+and are shown by "juju show-unit".
+
+Deferring an event that is already pending replaces its time. Events
+are only recorded if the hook that defers them succeeds.
+
+Examples:
+
+    defer-hook --in 5m check-again
+    defer-hook --at 2019-06-01T10:00:00Z rotate-logs
+`
+	return &cmd.Info{
+		Name:    "defer-hook",
+		Args:    "(--in <duration> | --at <time>) <event name>",
+		Purpose: "deliver a custom event to a later hook",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *DeferHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.in, "in", 0, "how long to wait before delivering the event")
+	f.StringVar(&c.at, "at", "", "when to deliver the event, in RFC3339 format")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *DeferHookCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no event name specified")
+	}
+	c.name = args[0]
+	if !validEventName.MatchString(c.name) {
+		return errors.NotValidf("event name %q", c.name)
+	}
+	switch {
+	case c.in != 0 && c.at != "":
+		return errors.New("only one of --in and --at may be specified")
+	case c.in < 0:
+		return errors.NotValidf("negative --in %v", c.in)
+	case c.in == 0 && c.at == "":
+		return errors.New("one of --in or --at must be specified")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *DeferHookCommand) Run(ctx *cmd.Context) error {
+	at := time.Now().Add(c.in)
+	if c.at != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, c.at); err != nil {
+			return errors.NotValidf("--at %q", c.at)
+		}
+	}
+	return c.ctx.DeferHook(c.name, at)
+}
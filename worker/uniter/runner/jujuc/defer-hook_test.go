@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type DeferHookSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&DeferHookSuite{})
+
+func (s *DeferHookSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("defer-hook"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *DeferHookSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no event name specified",
+	}, {
+		args: []string{"check"},
+		err:  "one of --in or --at must be specified",
+	}, {
+		args: []string{"--in", "5m", "--at", "2019-06-01T10:00:00Z", "check"},
+		err:  "only one of --in and --at may be specified",
+	}, {
+		args: []string{"--in", "-5m", "check"},
+		err:  "negative --in -5m0s not valid",
+	}, {
+		args: []string{"--in", "5m", "check again"},
+		err:  `event name "check again" not valid`,
+	}, {
+		args: []string{"--in", "5m", "check", "again"},
+		err:  `unrecognized args: \["again"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		err := cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *DeferHookSuite) TestDeferIn(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	before := time.Now()
+	code := cmd.Main(com, ctx, []string{"--in", "5m", "check-again"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	at := hctx.info.DeferredHooks.Deferred["check-again"]
+	c.Check(at.Before(before.Add(5*time.Minute)), jc.IsFalse)
+	c.Check(at.After(time.Now().Add(5*time.Minute)), jc.IsFalse)
+}
+
+func (s *DeferHookSuite) TestDeferAt(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--at", "2019-06-01T10:00:00Z", "rotate-logs"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	expected := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	c.Check(hctx.info.DeferredHooks.Deferred["rotate-logs"].Equal(expected), jc.IsTrue)
+}
+
+func (s *DeferHookSuite) TestDeferAtInvalid(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--at", "tomorrow", "rotate-logs"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR --at \"tomorrow\" not valid\n")
+}
+
+func (s *DeferHookSuite) TestDeferError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("uh oh spaghettio"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--in", "1h", "check-again"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR uh oh spaghettio\n")
+}
@@ -5,6 +5,7 @@ package jujuctesting
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/testing"
 )
@@ -23,6 +24,8 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	DeferredHooks
+	Secrets
 }
 
 // Context returns a Context that wraps the info.
@@ -38,6 +41,10 @@ func (info *ContextInfo) SetAsRelationHook(id int, remote string) {
 	}
 	info.HookRelation = relation
 	info.RemoteUnitName = remote
+	info.RemoteApplicationName = ""
+	if remote != "" {
+		info.RemoteApplicationName = strings.Split(remote, "/")[0]
+	}
 }
 
 // SetAsActionHook updates the context to work as an action hook context.
@@ -63,6 +70,8 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextDeferredHooks
+	ContextSecrets
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +101,9 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextDeferredHooks.stub = stub
+	ctx.ContextDeferredHooks.info = &info.DeferredHooks
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
 	return &ctx
 }
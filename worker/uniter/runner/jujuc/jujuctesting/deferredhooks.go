@@ -0,0 +1,34 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DeferredHooks holds values for the hook context.
+type DeferredHooks struct {
+	Deferred map[string]time.Time
+}
+
+// ContextDeferredHooks is a test double for jujuc.ContextDeferredHooks.
+type ContextDeferredHooks struct {
+	contextBase
+	info *DeferredHooks
+}
+
+// DeferHook implements jujuc.ContextDeferredHooks.
+func (c *ContextDeferredHooks) DeferHook(name string, at time.Time) error {
+	c.stub.AddCall("DeferHook", name, at)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Deferred == nil {
+		c.info.Deferred = make(map[string]time.Time)
+	}
+	c.info.Deferred[name] = at
+	return nil
+}
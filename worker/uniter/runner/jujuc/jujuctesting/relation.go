@@ -6,6 +6,7 @@ package jujuctesting
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 
@@ -24,11 +25,23 @@ type Relation struct {
 	Units map[string]Settings
 	// UnitName is data for jujuc.ContextRelation.
 	UnitName string
+	// Applications is data for jujuc.ContextRelation.
+	Applications map[string]Settings
 }
 
 // Reset clears the Relation's settings.
 func (r *Relation) Reset() {
 	r.Units = nil
+	r.Applications = nil
+}
+
+// SetApplication sets the application-level relation settings for
+// the application.
+func (r *Relation) SetApplication(name string, settings Settings) {
+	if r.Applications == nil {
+		r.Applications = make(map[string]Settings)
+	}
+	r.Applications[name] = settings
 }
 
 // SetRelated adds the relation settings for the unit.
@@ -110,6 +123,35 @@ func (r *ContextRelation) ReadSettings(name string) (params.Settings, error) {
 	return s.Map(), nil
 }
 
+// ApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	r.stub.AddCall("ApplicationSettings")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	appName := strings.Split(r.info.UnitName, "/")[0]
+	settings, ok := r.info.Applications[appName]
+	if !ok {
+		return nil, errors.Errorf("no settings for %q", appName)
+	}
+	return settings, nil
+}
+
+// ReadApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ReadApplicationSettings(name string) (params.Settings, error) {
+	r.stub.AddCall("ReadApplicationSettings", name)
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s, found := r.info.Applications[name]
+	if !found {
+		return nil, fmt.Errorf("unknown application %s", name)
+	}
+	return s.Map(), nil
+}
+
 // Suspended implements jujuc.ContextRelation.
 func (r *ContextRelation) Suspended() bool {
 	return true
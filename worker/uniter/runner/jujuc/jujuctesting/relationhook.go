@@ -11,14 +11,16 @@ import (
 
 // RelationHook holds the values for the hook context.
 type RelationHook struct {
-	HookRelation   jujuc.ContextRelation
-	RemoteUnitName string
+	HookRelation          jujuc.ContextRelation
+	RemoteUnitName        string
+	RemoteApplicationName string
 }
 
 // Reset clears the RelationHook's data.
 func (rh *RelationHook) Reset() {
 	rh.HookRelation = nil
 	rh.RemoteUnitName = ""
+	rh.RemoteApplicationName = ""
 }
 
 // ContextRelationHook is a test double for jujuc.RelationHookContext.
@@ -49,3 +51,15 @@ func (c *ContextRelationHook) RemoteUnitName() (string, error) {
 
 	return c.info.RemoteUnitName, err
 }
+
+// RemoteApplicationName implements jujuc.RelationHookContext.
+func (c *ContextRelationHook) RemoteApplicationName() (string, error) {
+	c.stub.AddCall("RemoteApplicationName")
+	c.stub.NextErr()
+	var err error
+	if c.info.RemoteApplicationName == "" {
+		err = errors.NotFoundf("remote application")
+	}
+
+	return c.info.RemoteApplicationName, err
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds values for the hook context.
+type Secrets struct {
+	// Owner is the name of the unit's application.
+	Owner string
+
+	// Secrets holds the secrets the unit can read, keyed by id.
+	Secrets map[string]jujuc.SecretArgs
+
+	// Grants holds the relation ids granted access to each
+	// secret, keyed by secret id.
+	Grants map[string][]int
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// SaveSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) SaveSecret(name string, args jujuc.SecretArgs) (string, error) {
+	c.stub.AddCall("SaveSecret", name, args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]jujuc.SecretArgs)
+	}
+	id := c.info.Owner + "/" + name
+	c.info.Secrets[id] = args
+	return id, nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string) (string, error) {
+	c.stub.AddCall("GetSecret", id)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	secret, ok := c.info.Secrets[id]
+	if !ok {
+		return "", errors.NotFoundf("secret %q", id)
+	}
+	return secret.Value, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, relationId int) error {
+	c.stub.AddCall("GrantSecret", id, relationId)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Grants == nil {
+		c.info.Grants = make(map[string][]int)
+	}
+	c.info.Grants[id] = append(c.info.Grants[id], relationId)
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -24,6 +25,11 @@ type RelationGetCommand struct {
 	Key      string
 	UnitName string
 	out      cmd.Output
+
+	// Application is true if the application-level settings of
+	// ApplicationName are to be read instead of a unit's settings.
+	Application     bool
+	ApplicationName string
 }
 
 func NewRelationGetCommand(ctx Context) (cmd.Command, error) {
@@ -43,6 +49,9 @@ func (c *RelationGetCommand) Info() *cmd.Info {
 	doc := `
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+With --app, the application-level settings of the given application (or of
+the given unit's application) are printed instead. The local application's
+settings can only be read by its leader.
 `
 	// There's nothing we can really do about the error here.
 	if name, err := c.ctx.RemoteUnitName(); err == nil {
@@ -64,6 +73,7 @@ func (c *RelationGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
+	f.BoolVar(&c.Application, "app", false, "get the application-level settings instead of a unit's")
 }
 
 // Init is part of the cmd.Command interface.
@@ -78,6 +88,9 @@ func (c *RelationGetCommand) Init(args []string) error {
 		}
 		args = args[1:]
 	}
+	if c.Application {
+		return c.initApplication(args)
+	}
 	name, err := c.ctx.RemoteUnitName()
 	if err == nil {
 		c.UnitName = name
@@ -94,13 +107,55 @@ func (c *RelationGetCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// initApplication determines the application whose settings are to be
+// read, which defaults to the remote application of the hook.
+func (c *RelationGetCommand) initApplication(args []string) error {
+	name, err := c.ctx.RemoteApplicationName()
+	if err == nil {
+		c.ApplicationName = name
+	} else if cause := errors.Cause(err); !errors.IsNotFound(cause) {
+		return errors.Trace(err)
+	}
+	if len(args) > 0 {
+		name := args[0]
+		if names.IsValidUnit(name) {
+			name, _ = names.UnitApplication(name)
+		} else if !names.IsValidApplication(name) {
+			return errors.Errorf("invalid application or unit name %q", name)
+		}
+		c.ApplicationName = name
+		args = args[1:]
+	}
+	if c.ApplicationName == "" {
+		return fmt.Errorf("no application specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
 func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	r, err := c.ctx.Relation(c.RelationId)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	var settings params.Settings
-	if c.UnitName == c.ctx.UnitName() {
+	if c.Application {
+		localApp, err := names.UnitApplication(c.ctx.UnitName())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.ApplicationName == localApp {
+			node, err := r.ApplicationSettings()
+			if err != nil {
+				return err
+			}
+			settings = node.Map()
+		} else {
+			settings, err = r.ReadApplicationSettings(c.ApplicationName)
+			if err != nil {
+				return err
+			}
+		}
+	} else if c.UnitName == c.ctx.UnitName() {
 		node, err := r.Settings()
 		if err != nil {
 			return err
@@ -222,6 +222,8 @@ Summary:
 get relation settings
 
 Options:
+--app  (= false)
+    get the application-level settings instead of a unit's
 --format  (= smart)
     Specify output format (json|smart|yaml)
 -o, --output (= "")
@@ -232,6 +234,9 @@ Options:
 Details:
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+With --app, the application-level settings of the given application (or of
+the given unit's application) are printed instead. The local application's
+settings can only be read by its leader.
 %s`[1:]
 
 var relationGetHelpTests = []struct {
@@ -291,3 +296,50 @@ func (s *RelationGetSuite) TestOutputPath(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(content), gc.Equals, "pew\npew\n\n")
 }
+
+func (s *RelationGetSuite) TestRelationGetApplication(c *gc.C) {
+	for i, t := range []struct {
+		summary string
+		unit    string
+		args    []string
+		code    int
+		out     string
+	}{{
+		summary: "implicit remote application",
+		unit:    "m/0",
+		args:    []string{"--app", "shared"},
+		out:     "value",
+	}, {
+		summary: "explicit application",
+		args:    []string{"--app", "-", "m"},
+		out:     "shared: value",
+	}, {
+		summary: "application of explicit unit",
+		args:    []string{"--app", "shared", "m/1"},
+		out:     "value",
+	}, {
+		summary: "local application",
+		args:    []string{"--app", "-", "u"},
+		out:     "local: value",
+	}, {
+		summary: "no application",
+		args:    []string{"--app"},
+		code:    2,
+		out:     "no application specified",
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.newHookContext(1, t.unit)
+		info.rels[1].SetApplication("m", jujuctesting.Settings{"shared": "value"})
+		info.rels[1].SetApplication("u", jujuctesting.Settings{"local": "value"})
+		com, err := jujuc.NewCommand(hctx, cmdString("relation-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		if code == 0 {
+			c.Check(bufferString(ctx.Stdout), gc.Equals, t.out+"\n")
+		} else {
+			c.Check(bufferString(ctx.Stderr), gc.Matches, fmt.Sprintf(`(.|\n)*ERROR %s\n`, t.out))
+		}
+	}
+}
@@ -46,6 +46,8 @@ Summary:
 set relation settings
 
 Options:
+--app  (= false)
+    set the application-level settings instead of the unit's
 --file  (= )
     file containing key-value pairs
 --format (= "")
@@ -65,6 +67,11 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --app option writes the application-level settings of the local
+unit's application instead, which are shared by all of its units and
+can be read by the units of the related applications. Only the leader
+may write them.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -384,3 +391,31 @@ func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
 	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "--format flag deprecated for command \"relation-set\"")
 }
+
+func (s *RelationSetSuite) TestRunApplication(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.IsLeader = true
+	info.rels[1].SetApplication("u", jujuctesting.Settings{"base": "value"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := cmdtesting.RunCommand(c, com, "--app", "foo=bar", "base=")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"foo": "bar"})
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{
+		"private-address": "u-0.testing.invalid",
+	})
+}
+
+func (s *RelationSetSuite) TestRunApplicationNotLeader(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.rels[1].SetApplication("u", jujuctesting.Settings{"base": "value"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, com, "--app", "foo=bar")
+	c.Assert(err, gc.ErrorMatches, "cannot write application relation settings: unit is not the leader")
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"base": "value"})
+}
@@ -143,6 +143,9 @@ func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 // RemoteUnitName implements hooks.Context.
 func (*RestrictedContext) RemoteUnitName() (string, error) { return "", ErrRestrictedContext }
 
+// RemoteApplicationName implements hooks.Context.
+func (*RestrictedContext) RemoteApplicationName() (string, error) { return "", ErrRestrictedContext }
+
 // ActionParams implements hooks.Context.
 func (*RestrictedContext) ActionParams() (map[string]interface{}, error) {
 	return nil, ErrRestrictedContext
@@ -173,3 +176,23 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// DeferHook implements hooks.Context.
+func (*RestrictedContext) DeferHook(string, time.Time) error {
+	return ErrRestrictedContext
+}
+
+// SaveSecret implements hooks.Context.
+func (*RestrictedContext) SaveSecret(string, SecretArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GrantSecret implements hooks.Context.
+func (*RestrictedContext) GrantSecret(string, int) error {
+	return ErrRestrictedContext
+}
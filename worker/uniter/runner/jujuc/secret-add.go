@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// SecretAddCommand implements the secret-add command.
+type SecretAddCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name        string
+	file        cmd.FileVar
+	description string
+	rotate      time.Duration
+}
+
+// NewSecretAddCommand creates a secret-add command.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &SecretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *SecretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add saves a secret owned by the unit's application, and prints
+the secret's id. Other applications may read the secret with secret-get
+once they have been granted access with secret-grant. Saving a secret
+that already exists updates its value, and increments its revision if
+the value changed. Only the leader may save secrets.
+
+The secret value is read from the file given with --file, or from
+stdin, so that it is never visible in the command line of the process.
+
+If --rotate is specified, the secret-rotate hook is run on the leader
+each time the interval passes, with the secret's id in JUJU_SECRET_ID,
+so that the charm can save a new value.
+
+Examples:
+
+    echo -n s3cret | secret-add db-password
+    secret-add --rotate 720h --file tls.key tls-key
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<name> [--file <path>]",
+		Purpose: "save a secret owned by the unit's application",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *SecretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(&c.file, "file", "file containing the secret value")
+	f.StringVar(&c.description, "description", "", "describe the secret")
+	f.DurationVar(&c.rotate, "rotate", 0, "how often the secret should be rotated")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *SecretAddCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if c.rotate < 0 {
+		return errors.NotValidf("negative --rotate %v", c.rotate)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *SecretAddCommand) Run(ctx *cmd.Context) error {
+	var data []byte
+	var err error
+	if c.file.Path != "" {
+		data, err = c.file.Read(ctx)
+	} else {
+		data, err = ioutil.ReadAll(ctx.Stdin)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(data) == 0 {
+		return errors.New("no secret value specified")
+	}
+	id, err := c.ctx.SaveSecret(c.name, SecretArgs{
+		Value:          string(data),
+		Description:    c.description,
+		RotateInterval: c.rotate,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
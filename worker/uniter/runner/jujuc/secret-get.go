@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// SecretGetCommand implements the secret-get command.
+type SecretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id string
+}
+
+// NewSecretGetCommand creates a secret-get command.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &SecretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *SecretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of a secret. The secret must be owned by
+the unit's application, or access to it must have been granted on a
+relation with the owner.
+
+Examples:
+
+    secret-get mysql/root-password
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<secret id>",
+		Purpose: "print the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *SecretGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *SecretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintln(ctx.Stdout, value)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// SecretGrantCommand implements the secret-grant command.
+type SecretGrantCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id              string
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand creates a secret-grant command.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	c := &SecretGrantCommand{ctx: ctx}
+	rV, err := NewRelationIdValue(c.ctx, &c.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *SecretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant gives the remote application on a relation access to a
+secret owned by the unit's application. The grant lasts as long as the
+relation. Only the leader may grant access to secrets.
+
+-r must be specified when not in a relation hook.
+
+Examples:
+
+    secret-grant -r db:2 mysql/root-password
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<secret id>",
+		Purpose: "grant access to a secret on a relation",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *SecretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *SecretGrantCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret id specified")
+	}
+	c.id = args[0]
+	if c.relationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *SecretGrantCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.GrantSecret(c.id, c.relationId)
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		err   string
+	}{{
+		relid: 1,
+		args:  nil,
+		err:   "no secret id specified",
+	}, {
+		relid: -1,
+		args:  []string{"mysql/root-password"},
+		err:   "no relation id specified",
+	}, {
+		relid: -1,
+		args:  []string{"-r", "unknown:123", "mysql/root-password"},
+		err:   `invalid value "unknown:123" for flag -r: relation not found`,
+	}, {
+		relid: 1,
+		args:  []string{"mysql/root-password", "extra"},
+		err:   `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, _ := s.newHookContext(t.relid, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGrantSuite) TestSecretGrant(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		grant int
+	}{{
+		relid: 1,
+		args:  []string{"mysql/root-password"},
+		grant: 1,
+	}, {
+		relid: -1,
+		args:  []string{"-r", "peer0:0", "mysql/root-password"},
+		grant: 0,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, info := s.newHookContext(t.relid, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Assert(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(info.Secrets.Grants, jc.DeepEquals, map[string][]int{
+			"mysql/root-password": {t.grant},
+		})
+	}
+}
@@ -66,6 +66,10 @@ var baseCommands = map[string]creator{
 	"pod-spec-set" + cmdSuffix:            NewPodSpecSetCommand,
 	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"defer-hook" + cmdSuffix:              NewDeferHookCommand,
+	"secret-add" + cmdSuffix:              NewSecretAddCommand,
+	"secret-get" + cmdSuffix:              NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:            NewSecretGrantCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -201,6 +205,25 @@ type Server struct {
 	wg         sync.WaitGroup
 }
 
+// MainFunc runs the hook tool invocation specified by req, and fills
+// in resp, in the manner of Jujuc.Main.
+type MainFunc func(req Request, resp *exec.ExecResponse) error
+
+// NewMainFunc returns a MainFunc that runs Commands obtained from getCmd.
+func NewMainFunc(getCmd CmdGetter) MainFunc {
+	return (&Jujuc{getCmd: getCmd}).Main
+}
+
+// funcJujuc exposes a MainFunc to net/rpc as Jujuc.Main.
+type funcJujuc struct {
+	main MainFunc
+}
+
+// Main is called via RPC to run a hook tool.
+func (j *funcJujuc) Main(req Request, resp *exec.ExecResponse) error {
+	return j.main(req, resp)
+}
+
 // NewServer creates an RPC server bound to socketPath, which can execute
 // remote command invocations against an appropriate Context. It will not
 // actually do so until Run is called.
@@ -209,6 +232,21 @@ func NewServer(getCmd CmdGetter, socketPath string) (*Server, error) {
 	if err := server.Register(&Jujuc{getCmd: getCmd}); err != nil {
 		return nil, err
 	}
+	return newServer(server, socketPath)
+}
+
+// NewServerFunc creates an RPC server bound to socketPath, which handles
+// remote command invocations with the supplied MainFunc. This allows
+// invocations to be observed, or answered without a Context at all.
+func NewServerFunc(main MainFunc, socketPath string) (*Server, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("Jujuc", &funcJujuc{main}); err != nil {
+		return nil, err
+	}
+	return newServer(server, socketPath)
+}
+
+func newServer(server *rpc.Server, socketPath string) (*Server, error) {
 	listener, err := sockets.Listen(socketPath)
 	if err != nil {
 		return nil, errors.Annotate(err, "listening to jujuc socket")
@@ -284,3 +284,36 @@ func (s *NewCommandSuite) TestNewCommand(c *gc.C) {
 		}
 	}
 }
+
+func (s *ServerSuite) TestServerFunc(c *gc.C) {
+	var calls []jujuc.Request
+	main := jujuc.NewMainFunc(factory)
+	observe := func(req jujuc.Request, resp *exec.ExecResponse) error {
+		calls = append(calls, req)
+		return main(req, resp)
+	}
+	sockPath := s.osDependentSockPath(c)
+	srv, err := jujuc.NewServerFunc(observe, sockPath)
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error)
+	go func() { done <- srv.Run() }()
+	defer func() {
+		srv.Close()
+		c.Assert(<-done, gc.IsNil)
+	}()
+
+	client, err := sockets.Dial(sockPath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	req := jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         c.MkDir(),
+		CommandName: "remote",
+	}
+	var resp exec.ExecResponse
+	err = client.Call("Jujuc.Main", req, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "eye of newt\n")
+	c.Assert(calls, jc.DeepEquals, []jujuc.Request{req})
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/application"
+)
+
+// hookLimitError is returned when a hook is killed for exceeding
+// one of its application's hook limits.
+type hookLimitError struct {
+	message string
+}
+
+// Error is part of the error interface.
+func (e *hookLimitError) Error() string {
+	return e.message
+}
+
+// NewHookLimitError returns an error satisfying IsHookLimitError.
+func NewHookLimitError(format string, args ...interface{}) error {
+	return &hookLimitError{message: fmt.Sprintf(format, args...)}
+}
+
+// IsHookLimitError returns true if the error's cause is a hook
+// being killed for exceeding one of its hook limits.
+func IsHookLimitError(err error) bool {
+	_, ok := errors.Cause(err).(*hookLimitError)
+	return ok
+}
+
+// waitForHook blocks until the started hook process, running in the
+// given scope, finishes. If the hook runs for longer than the time
+// limit it is killed along with the processes it started, and an error
+// satisfying IsHookLimitError is returned; likewise if the hook was
+// killed for exceeding its memory limit.
+//
+// The limits only bound the resources a hook uses: hooks still run as
+// root, without any further sandboxing.
+func waitForHook(ps *exec.Cmd, hookName, scope string, limits application.HookLimits, clock clock.Clock) error {
+	defer releaseHookScope(scope)
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if limits.Timeout > 0 {
+		timer := clock.NewTimer(limits.Timeout)
+		defer timer.Stop()
+		timeout = timer.Chan()
+	}
+
+	select {
+	case err := <-done:
+		if err != nil && killedByMemoryLimit(err, scope) {
+			return NewHookLimitError(
+				"hook %q exceeded its memory limit of %dM", hookName, limits.MemoryMB,
+			)
+		}
+		return err
+	case <-timeout:
+		if err := killHookProcessGroup(ps); err != nil {
+			logger.Warningf("cannot kill hook %q: %v", hookName, err)
+		}
+		<-done
+		return NewHookLimitError(
+			"hook %q exceeded its time limit of %v", hookName, limits.Timeout,
+		)
+	}
+}
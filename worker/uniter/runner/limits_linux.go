@@ -0,0 +1,106 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/core/application"
+)
+
+var (
+	// systemdRun is used to run hooks in a transient systemd scope, so
+	// that the kernel enforces their memory and CPU limits.
+	systemdRun = "systemd-run"
+
+	// systemctl is used to query and clean up the hook scopes.
+	systemctl = "systemctl"
+)
+
+// limitHookCommand returns the command used to run the hook with the
+// supplied memory and CPU limits applied, along with the name of the
+// systemd scope unit the hook runs in. If neither limit is set, or
+// systemd-run is not available, the hook command is returned unchanged
+// and the scope is empty.
+func limitHookCommand(hookCmd []string, limits application.HookLimits) ([]string, string) {
+	if limits.MemoryMB == 0 && limits.CPUPercent == 0 {
+		return hookCmd, ""
+	}
+	path, err := exec.LookPath(systemdRun)
+	if err != nil {
+		logger.Warningf("hook memory and CPU limits not enforced: %v", err)
+		return hookCmd, ""
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		logger.Warningf("hook memory and CPU limits not enforced: %v", err)
+		return hookCmd, ""
+	}
+	scope := fmt.Sprintf("juju-hook-%s.scope", uuid)
+	cmd := []string{path, "--scope", "--quiet", "--unit", scope}
+	if limits.MemoryMB > 0 {
+		cmd = append(cmd, "-p", fmt.Sprintf("MemoryMax=%dM", limits.MemoryMB))
+	}
+	if limits.CPUPercent > 0 {
+		cmd = append(cmd, "-p", fmt.Sprintf("CPUQuota=%d%%", limits.CPUPercent))
+	}
+	cmd = append(cmd, "--")
+	return append(cmd, hookCmd...), scope
+}
+
+// setHookProcessGroup makes the hook process the leader of a new
+// process group, so that any processes it starts can be killed with it.
+func setHookProcessGroup(ps *exec.Cmd) {
+	ps.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killHookProcessGroup kills the hook process and every process
+// in its process group.
+func killHookProcessGroup(ps *exec.Cmd) error {
+	return syscall.Kill(-ps.Process.Pid, syscall.SIGKILL)
+}
+
+// killedByMemoryLimit returns true if the hook process, run in the
+// given scope, was killed by the kernel's out of memory killer. Other
+// processes may also kill the hook with SIGKILL, so the scope's result
+// is checked; systemd records it as "oom-kill" when the oom_kill count
+// in the memory.events of the scope's cgroup went up.
+func killedByMemoryLimit(err error, scope string) bool {
+	if scope == "" {
+		return false
+	}
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() || status.Signal() != syscall.SIGKILL {
+		return false
+	}
+	out, err := exec.Command(systemctl, "show", "--property=Result", scope).Output()
+	if err != nil {
+		logger.Warningf("cannot get result of hook scope %q: %v", scope, err)
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "Result=oom-kill"
+}
+
+// releaseHookScope unloads the hook's scope, which systemd keeps
+// loaded if the hook was killed.
+func releaseHookScope(scope string) {
+	if scope == "" {
+		return
+	}
+	// The scope is only kept if it failed,
+	// so an error here is expected.
+	_ = exec.Command(systemctl, "reset-failed", scope).Run()
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+func (s *RunMockContextSuite) TestRunHookTimeLimitKillsChildren(c *gc.C) {
+	ctx := &MockContext{
+		hookLimits: application.HookLimits{Timeout: 100 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "sleeping; sleep 10 & echo $! > child-pid; wait",
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.IsHookLimitError(ctx.flushFailure), jc.IsTrue)
+
+	content, err := ioutil.ReadFile(filepath.Join(s.paths.GetCharmDir(), "child-pid"))
+	c.Assert(err, jc.ErrorIsNil)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	// The child is killed with the hook, but may
+	// take a moment to be reaped once orphaned.
+	deadline := time.Now().Add(5 * time.Second)
+	for syscall.Kill(pid, 0) == nil {
+		if time.Now().After(deadline) {
+			c.Fatalf("process %d started by the hook was not killed", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package runner
+
+import (
+	"os/exec"
+
+	"github.com/juju/juju/core/application"
+)
+
+// limitHookCommand returns the hook command unchanged; memory and CPU
+// limits are only enforced on Linux.
+func limitHookCommand(hookCmd []string, limits application.HookLimits) ([]string, string) {
+	if limits.MemoryMB > 0 || limits.CPUPercent > 0 {
+		logger.Warningf("hook memory and CPU limits are only enforced on Linux")
+	}
+	return hookCmd, ""
+}
+
+// setHookProcessGroup does nothing; hooks are only run in their own
+// process group on Linux.
+func setHookProcessGroup(ps *exec.Cmd) {}
+
+// killHookProcessGroup kills the hook process. Processes started by
+// the hook are only killed with it on Linux.
+func killHookProcessGroup(ps *exec.Cmd) error {
+	return ps.Process.Kill()
+}
+
+// killedByMemoryLimit always returns false; memory limits are only
+// enforced on Linux.
+func killedByMemoryLimit(err error, scope string) bool {
+	return false
+}
+
+// releaseHookScope does nothing; hooks are only run in a scope on Linux.
+func releaseHookScope(scope string) {}
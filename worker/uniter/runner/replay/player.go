@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Player answers hook tool calls with the responses from a recording.
+// Calls must be made in the order they were recorded.
+type Player struct {
+	mu    sync.Mutex
+	calls []Call
+	next  int
+}
+
+// NewPlayer returns a Player that replays the calls in rec.
+func NewPlayer(rec *Recording) *Player {
+	return &Player{calls: rec.Calls}
+}
+
+// Main is a jujuc.MainFunc that responds to each hook tool call with
+// the next recorded response. A call that does not match the next
+// recorded call fails, and does not consume the recorded call.
+func (p *Player) Main(req jujuc.Request, resp *exec.ExecResponse) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	got := Call{Command: req.CommandName, Args: req.Args}
+	if p.next >= len(p.calls) {
+		resp.Code = 1
+		resp.Stderr = []byte(fmt.Sprintf("debug-replay: unexpected call %q; all recorded calls have been made\n", got))
+		return nil
+	}
+	call := p.calls[p.next]
+	if call.Command != got.Command || !argsEqual(call.Args, got.Args) {
+		resp.Code = 1
+		resp.Stderr = []byte(fmt.Sprintf("debug-replay: expected call %q, got %q\n", call, got))
+		return nil
+	}
+	if call.StdinSet && !req.StdinSet {
+		return jujuc.ErrNoStdin
+	}
+	p.next++
+	resp.Code = call.Code
+	resp.Stdout = []byte(call.Stdout)
+	resp.Stderr = []byte(call.Stderr)
+	return nil
+}
+
+// Remaining returns the recorded calls that have not been made.
+func (p *Player) Remaining() []Call {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Call(nil), p.calls[p.next:]...)
+}
+
+func argsEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type PlayerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&PlayerSuite{})
+
+func (s *PlayerSuite) record(c *gc.C) *replay.Recording {
+	recorder := replay.NewRecorder()
+	main := recorder.Wrap(func(req jujuc.Request, resp *exec.ExecResponse) error {
+		switch req.CommandName {
+		case "config-get":
+			resp.Stdout = []byte("3306\n")
+		case "relation-set":
+			if !req.StdinSet {
+				return jujuc.ErrNoStdin
+			}
+		default:
+			return errors.New("bad request: unknown command")
+		}
+		return nil
+	})
+	var resp exec.ExecResponse
+	err := main(jujuc.Request{CommandName: "config-get", Args: []string{"port"}}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	err = main(jujuc.Request{CommandName: "relation-set", Args: []string{"--file", "-"}}, &resp)
+	c.Assert(err, gc.Equals, jujuc.ErrNoStdin)
+	err = main(jujuc.Request{
+		CommandName: "relation-set",
+		Args:        []string{"--file", "-"},
+		StdinSet:    true,
+		Stdin:       []byte("a: b\n"),
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	err = main(jujuc.Request{CommandName: "bogus"}, &resp)
+	c.Assert(err, gc.ErrorMatches, "bad request: unknown command")
+	return recorder.Recording("mysql/0", "hooks/install", []string{"A=B"}, errors.New("exit status 1"))
+}
+
+func (s *PlayerSuite) TestRecorder(c *gc.C) {
+	rec := s.record(c)
+	c.Assert(rec.Unit, gc.Equals, "mysql/0")
+	c.Assert(rec.Hook, gc.Equals, "hooks/install")
+	c.Assert(rec.Env, jc.DeepEquals, []string{"A=B"})
+	c.Assert(rec.Error, gc.Equals, "exit status 1")
+	c.Assert(rec.Calls, jc.DeepEquals, []replay.Call{{
+		Command: "config-get",
+		Args:    []string{"port"},
+		Stdout:  "3306\n",
+	}, {
+		Command:  "relation-set",
+		Args:     []string{"--file", "-"},
+		StdinSet: true,
+		Stdin:    "a: b\n",
+	}, {
+		Command: "bogus",
+		Code:    1,
+		Stderr:  "bad request: unknown command",
+	}})
+}
+
+func (s *PlayerSuite) TestRecorderRedactsSecrets(c *gc.C) {
+	recorder := replay.NewRecorder()
+	main := recorder.Wrap(func(req jujuc.Request, resp *exec.ExecResponse) error {
+		switch req.CommandName {
+		case "secret-add":
+			resp.Stdout = []byte("secret://app/mysql/password\n")
+		case "secret-get":
+			resp.Stdout = []byte("sekrit\n")
+		}
+		return nil
+	})
+	var resp exec.ExecResponse
+	err := main(jujuc.Request{
+		CommandName: "secret-add",
+		Args:        []string{"password"},
+		StdinSet:    true,
+		Stdin:       []byte("sekrit"),
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	resp = exec.ExecResponse{}
+	err = main(jujuc.Request{CommandName: "secret-get", Args: []string{"secret://app/mysql/password"}}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec := recorder.Recording("mysql/0", "hooks/install", nil, nil)
+	c.Assert(rec.Calls, jc.DeepEquals, []replay.Call{{
+		Command:  "secret-add",
+		Args:     []string{"password"},
+		StdinSet: true,
+		Stdin:    "<redacted>",
+		Stdout:   "secret://app/mysql/password\n",
+	}, {
+		Command: "secret-get",
+		Args:    []string{"secret://app/mysql/password"},
+		Stdout:  "<redacted>",
+	}})
+}
+
+func (s *PlayerSuite) TestPlayer(c *gc.C) {
+	player := replay.NewPlayer(s.record(c))
+
+	var resp exec.ExecResponse
+	err := player.Main(jujuc.Request{CommandName: "config-get", Args: []string{"port"}}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "3306\n")
+
+	resp = exec.ExecResponse{}
+	err = player.Main(jujuc.Request{CommandName: "relation-set", Args: []string{"--file", "-"}}, &resp)
+	c.Assert(err, gc.Equals, jujuc.ErrNoStdin)
+	err = player.Main(jujuc.Request{
+		CommandName: "relation-set",
+		Args:        []string{"--file", "-"},
+		StdinSet:    true,
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(player.Remaining(), gc.HasLen, 1)
+}
+
+func (s *PlayerSuite) TestPlayerMismatch(c *gc.C) {
+	player := replay.NewPlayer(s.record(c))
+
+	var resp exec.ExecResponse
+	err := player.Main(jujuc.Request{CommandName: "config-get", Args: []string{"host"}}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 1)
+	c.Assert(string(resp.Stderr), gc.Equals, `debug-replay: expected call "config-get port", got "config-get host"`+"\n")
+	c.Assert(player.Remaining(), gc.HasLen, 3)
+}
+
+func (s *PlayerSuite) TestPlayerExhausted(c *gc.C) {
+	player := replay.NewPlayer(&replay.Recording{Hook: "hooks/install"})
+
+	var resp exec.ExecResponse
+	err := player.Main(jujuc.Request{CommandName: "is-leader"}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 1)
+	c.Assert(string(resp.Stderr), gc.Equals, `debug-replay: unexpected call "is-leader"; all recorded calls have been made`+"\n")
+}
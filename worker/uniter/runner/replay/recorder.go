@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// redacted replaces secret values in recorded calls.
+const redacted = "<redacted>"
+
+// secretOutputTools are the hook tools which print secret values.
+var secretOutputTools = set.NewStrings("secret-get")
+
+// Recorder records the hook tool calls handled by a jujuc server.
+// Secret values passed to or printed by the secret hook tools are
+// redacted, so that they are never written to disk.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Wrap returns a MainFunc that handles hook tool calls with main,
+// recording each call and its response.
+func (r *Recorder) Wrap(main jujuc.MainFunc) jujuc.MainFunc {
+	return func(req jujuc.Request, resp *exec.ExecResponse) error {
+		err := main(req, resp)
+		if errors.Cause(err) == jujuc.ErrNoStdin {
+			// The client will retry the call with stdin.
+			return err
+		}
+		call := Call{
+			Command:  req.CommandName,
+			Args:     req.Args,
+			StdinSet: req.StdinSet,
+			Stdin:    string(req.Stdin),
+			Code:     resp.Code,
+			Stdout:   string(resp.Stdout),
+			Stderr:   string(resp.Stderr),
+		}
+		if err != nil {
+			call.Code = 1
+			call.Stderr = err.Error()
+		}
+		if strings.HasPrefix(call.Command, "secret-") && call.Stdin != "" {
+			call.Stdin = redacted
+		}
+		if secretOutputTools.Contains(call.Command) && call.Stdout != "" {
+			call.Stdout = redacted
+		}
+		r.mu.Lock()
+		r.calls = append(r.calls, call)
+		r.mu.Unlock()
+		return err
+	}
+}
+
+// Recording returns a Recording of the named unit running the given
+// hook with the supplied environment, holding the calls recorded so
+// far and the hook's error, if any.
+func (r *Recorder) Recording(unitName, hook string, env []string, hookErr error) *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := &Recording{
+		Unit:     unitName,
+		Hook:     hook,
+		Recorded: time.Now(),
+		Env:      append([]string(nil), env...),
+		Calls:    append([]Call(nil), r.calls...),
+	}
+	if hookErr != nil {
+		rec.Error = hookErr.Error()
+	}
+	return rec
+}
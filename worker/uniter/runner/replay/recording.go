@@ -0,0 +1,130 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replay records the hook tool calls a hook makes, along with
+// the environment it was run in, so that the hook can later be replayed
+// against a fake jujuc server without a live model.
+package replay
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// recordingSuffix is the file name suffix of recordings.
+const recordingSuffix = ".yaml"
+
+// Recording holds everything needed to replay a hook offline.
+type Recording struct {
+	// Unit is the name of the unit that ran the hook.
+	Unit string `yaml:"unit"`
+
+	// Hook is the charm-relative path of the hook or action that was
+	// run, as exposed to the charm in JUJU_DISPATCH_PATH.
+	Hook string `yaml:"hook"`
+
+	// Recorded is when the hook finished running.
+	Recorded time.Time `yaml:"recorded"`
+
+	// Env holds the environment variables the hook was run with.
+	Env []string `yaml:"env"`
+
+	// Calls holds the hook tool calls the hook made, in order.
+	Calls []Call `yaml:"calls"`
+
+	// Error holds the error the hook failed with, if any.
+	Error string `yaml:"error,omitempty"`
+}
+
+// Call records a single hook tool invocation and its response.
+type Call struct {
+	Command  string   `yaml:"command"`
+	Args     []string `yaml:"args,omitempty"`
+	StdinSet bool     `yaml:"stdin-set,omitempty"`
+	Stdin    string   `yaml:"stdin,omitempty"`
+	Code     int      `yaml:"code"`
+	Stdout   string   `yaml:"stdout,omitempty"`
+	Stderr   string   `yaml:"stderr,omitempty"`
+}
+
+// String returns the call as it would appear on a command line.
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Command}, c.Args...), " ")
+}
+
+// ReadFile reads a recording from the named file.
+func ReadFile(path string) (*Recording, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rec Recording
+	if err := yaml.Unmarshal(data, &rec); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse recording %q", path)
+	}
+	if rec.Hook == "" {
+		return nil, errors.NotValidf("recording %q with no hook", path)
+	}
+	return &rec, nil
+}
+
+// WriteFile writes the recording to a new file in dir, creating dir if
+// necessary, and returns the file's path. Recordings may hold secrets
+// passed to or returned by hook tools, so they are only readable by
+// the agent's user.
+func (r *Recording) WriteFile(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name := fmt.Sprintf("%s-%s-%s%s",
+		strings.Replace(r.Unit, "/", "-", -1),
+		strings.Replace(r.Hook, "/", "-", -1),
+		r.Recorded.UTC().Format("20060102T150405.000000000"),
+		recordingSuffix,
+	)
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return "", errors.Trace(err)
+	}
+	return path, nil
+}
+
+// Prune removes all but the newest keep recordings from dir.
+func Prune(dir string, keep int) error {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var recordings []os.FileInfo
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), recordingSuffix) {
+			recordings = append(recordings, info)
+		}
+	}
+	if len(recordings) <= keep {
+		return nil
+	}
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].ModTime().Before(recordings[j].ModTime())
+	})
+	for _, info := range recordings[:len(recordings)-keep] {
+		if err := os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type RecordingSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RecordingSuite{})
+
+func (s *RecordingSuite) TestWriteAndReadFile(c *gc.C) {
+	rec := &replay.Recording{
+		Unit:     "mysql/0",
+		Hook:     "hooks/config-changed",
+		Recorded: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+		Env:      []string{"JUJU_UNIT_NAME=mysql/0"},
+		Calls: []replay.Call{{
+			Command: "config-get",
+			Args:    []string{"--format=json"},
+			Stdout:  `{"port":3306}`,
+		}},
+		Error: "exit status 1",
+	}
+	dir := filepath.Join(c.MkDir(), "recordings")
+	path, err := rec.WriteFile(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filepath.Base(path), gc.Equals, "mysql-0-hooks-config-changed-20190601T120000.000000000.yaml")
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	read, err := replay.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, rec)
+}
+
+func (s *RecordingSuite) TestReadFileNoHook(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "bad.yaml")
+	err := ioutil.WriteFile(path, []byte("unit: mysql/0\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = replay.ReadFile(path)
+	c.Assert(err, gc.ErrorMatches, `recording ".*bad.yaml" with no hook not valid`)
+}
+
+func (s *RecordingSuite) TestPrune(c *gc.C) {
+	dir := c.MkDir()
+	now := time.Now()
+	for i, name := range []string{"a.yaml", "b.yaml", "c.yaml", "other"} {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, nil, 0600)
+		c.Assert(err, jc.ErrorIsNil)
+		mtime := now.Add(time.Duration(i) * time.Minute)
+		err = os.Chtimes(path, mtime, mtime)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := replay.Prune(dir, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	c.Assert(names, jc.DeepEquals, []string{"b.yaml", "c.yaml", "other"})
+}
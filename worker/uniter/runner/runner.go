@@ -20,14 +20,35 @@ import (
 	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 )
 
 var logger = loggo.GetLogger("juju.worker.uniter.runner")
 
+const (
+	// dispatchScript is the name of the optional executable in the charm
+	// root which, if present, is run for every hook and action in place
+	// of the individual hook and action files.
+	dispatchScript = "dispatch"
+
+	// dispatchPathEnvVar holds the charm-relative path of the hook or
+	// action being run, so that dispatch can tell which event to handle.
+	dispatchPathEnvVar = "JUJU_DISPATCH_PATH"
+
+	// recordingsDir is the name of the component directory holding
+	// hook recordings.
+	recordingsDir = "hook-recordings"
+
+	// maxRecordings is the number of hook recordings kept per unit.
+	maxRecordings = 50
+)
+
 // Runner is responsible for invoking commands in a context.
 type Runner interface {
 
@@ -53,6 +74,9 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookLimits() application.HookLimits
+	HookRecording() string
+	DeferredEvents() []hook.DeferredEvent
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -82,7 +106,7 @@ func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, erro
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
 // juju-run as an action
 func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
-	srv, err := runner.startJujucServer()
+	srv, err := runner.startJujucServer(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +229,12 @@ func (runner *runner) RunHook(hookName string) error {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
-	srv, err := runner.startJujucServer()
+	var recorder *replay.Recorder
+	recording := runner.context.HookRecording()
+	if recording != "" {
+		recorder = replay.NewRecorder()
+	}
+	srv, err := runner.startJujucServer(recorder)
 	if err != nil {
 		return err
 	}
@@ -221,6 +250,10 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		// because that already has handling for windows environment requirements.
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
+	// The dispatch path is set whether or not the charm has a dispatch
+	// script, so that it is also available in debug-hooks sessions.
+	dispatchPath := filepath.ToSlash(filepath.Join(charmLocation, hookName))
+	env = append(env, dispatchPathEnvVar+"="+dispatchPath)
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
@@ -229,19 +262,47 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
+	// Missing hooks are not recorded, as there is nothing to replay.
+	if recorder != nil && !charmrunner.IsMissingHookError(errors.Cause(err)) &&
+		(err != nil || recording == application.HookRecordingAll) {
+		runner.saveRecording(recorder.Recording(runner.context.UnitName(), dispatchPath, env, err))
+	}
 	return runner.context.Flush(hookName, err)
 }
 
+// saveRecording writes the recording of a hook so that it can be
+// replayed with juju debug-replay. Failure to save the recording is
+// logged, but does not fail the hook.
+func (runner *runner) saveRecording(rec *replay.Recording) {
+	dir := runner.paths.ComponentDir(recordingsDir)
+	path, err := rec.WriteFile(dir)
+	if err != nil {
+		logger.Warningf("cannot record hook %q: %v", rec.Hook, err)
+		return
+	}
+	logger.Infof("recorded hook %q to %s", rec.Hook, path)
+	if err := replay.Prune(dir, maxRecordings); err != nil {
+		logger.Warningf("cannot prune hook recordings: %v", err)
+	}
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
-	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
+	hook, err := searchHook(charmDir, dispatchScript)
+	if charmrunner.IsMissingHookError(err) {
+		// The charm has no dispatch script, so fall back to
+		// the per-hook or per-action file.
+		hook, err = searchHook(charmDir, filepath.Join(charmLocation, hookName))
+	}
 	if err != nil {
 		return err
 	}
-	hookCmd := hookCommand(hook)
+	limits := runner.context.HookLimits()
+	hookCmd, scope := limitHookCommand(hookCommand(hook), limits)
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setHookProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -255,14 +316,16 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, or the hook exceeds its limits.
+		err = waitForHook(ps, hookName, scope, limits, clock.WallClock)
 	}
 	hookLogger.Stop()
 	return errors.Trace(err)
 }
 
-func (runner *runner) startJujucServer() (*jujuc.Server, error) {
+// startJujucServer starts a server for hook tools run in the runner's
+// context. If recorder is not nil, every hook tool call is recorded.
+func (runner *runner) startJujucServer(recorder *replay.Recorder) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
@@ -270,7 +333,11 @@ func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 		}
 		return jujuc.NewCommand(runner.context, cmdName)
 	}
-	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
+	main := jujuc.NewMainFunc(getCmd)
+	if recorder != nil {
+		main = recorder.Wrap(main)
+	}
+	srv, err := jujuc.NewServerFunc(main, runner.paths.GetJujucSocket())
 	if err != nil {
 		return nil, errors.Annotate(err, "starting jujuc server")
 	}
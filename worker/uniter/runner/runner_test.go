@@ -6,6 +6,7 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -19,10 +20,12 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -156,6 +159,8 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookLimits      application.HookLimits
+	hookRecording   string
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -177,6 +182,18 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) HookLimits() application.HookLimits {
+	return ctx.hookLimits
+}
+
+func (ctx *MockContext) HookRecording() string {
+	return ctx.hookRecording
+}
+
+func (ctx *MockContext) DeferredEvents() []hook.DeferredEvent {
+	return nil
+}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
@@ -290,6 +307,155 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) assertDispatchPath(c *gc.C, expectPath string) {
+	path := filepath.Join(s.paths.GetCharmDir(), "dispatch-path")
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimRight(string(content), "\r\n"), gc.Equals, expectPath)
+}
+
+func (s *RunMockContextSuite) TestRunHookDispatch(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("dispatch script uses bash syntax")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		name:   "dispatch",
+		perm:   0700,
+		stdout: "$JUJU_DISPATCH_PATH > dispatch-path",
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertDispatchPath(c, "hooks/something-happened")
+}
+
+func (s *RunMockContextSuite) TestRunActionDispatch(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("dispatch script uses bash syntax")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+	}
+	makeCharm(c, hookSpec{
+		name:   "dispatch",
+		perm:   0700,
+		stdout: "$JUJU_DISPATCH_PATH > dispatch-path",
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertDispatchPath(c, "actions/something-happened")
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeLimit(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook script uses bash syntax")
+	}
+	ctx := &MockContext{
+		hookLimits: application.HookLimits{Timeout: 100 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "sleeping; sleep 10",
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	if time.Now().Sub(t0) > 5*time.Second {
+		c.Errorf("hook was not killed when its time limit was reached")
+	}
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" exceeded its time limit of 100ms`)
+	c.Assert(runner.IsHookLimitError(ctx.flushFailure), jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeLimit(c *gc.C) {
+	ctx := &MockContext{
+		hookLimits: application.HookLimits{Timeout: time.Minute},
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 1,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 1")
+	c.Assert(runner.IsHookLimitError(ctx.flushFailure), jc.IsFalse)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) recordings(c *gc.C) []string {
+	dir := s.paths.ComponentDir("hook-recordings")
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	var paths []string
+	for _, info := range infos {
+		paths = append(paths, filepath.Join(dir, info.Name()))
+	}
+	return paths
+}
+
+func (s *RunMockContextSuite) TestRunHookRecordsFailure(c *gc.C) {
+	ctx := &MockContext{hookRecording: "failed"}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 1,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 1")
+
+	paths := s.recordings(c)
+	c.Assert(paths, gc.HasLen, 1)
+	rec, err := replay.ReadFile(paths[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rec.Unit, gc.Equals, "some-unit/999")
+	c.Assert(rec.Hook, gc.Equals, "hooks/something-happened")
+	c.Assert(rec.Error, gc.Equals, "exit status 1")
+	c.Assert(rec.Env, gc.Not(gc.HasLen), 0)
+	c.Assert(rec.Env[len(rec.Env)-1], gc.Equals, "JUJU_DISPATCH_PATH=hooks/something-happened")
+}
+
+func (s *RunMockContextSuite) TestRunHookRecordsOnlyFailures(c *gc.C) {
+	ctx := &MockContext{hookRecording: "failed"}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(s.recordings(c), gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookRecordsAll(c *gc.C) {
+	ctx := &MockContext{hookRecording: "all"}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(s.recordings(c), gc.HasLen, 1)
+}
+
 func (s *RunMockContextSuite) TestRunActionParamsFailure(c *gc.C) {
 	expectErr := errors.New("stork")
 	ctx := &MockContext{
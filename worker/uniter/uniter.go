@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
@@ -31,6 +33,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/healthprobe"
 	"github.com/juju/juju/worker/uniter/hook"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -107,6 +110,20 @@ type Uniter struct {
 	// hookRetryStrategy represents configuration for hook retries
 	hookRetryStrategy params.RetryStrategy
 
+	// healthMu guards the workload health and the workload status
+	// saved while the health probes are failing.
+	healthMu      sync.Mutex
+	health        healthprobe.Health
+	healthyStatus *params.StatusResult
+
+	// healthChannel is signalled when the workload health changes.
+	// It is passed to the remote state watcher.
+	healthChannel chan struct{}
+
+	// healthProbesStarted records whether the worker running the
+	// workload health probes has been started.
+	healthProbesStarted bool
+
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
@@ -235,6 +252,13 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	)
 
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
+	hookRetryPolicy := application.HookRetryPolicy{
+		Hooks:        u.hookRetryStrategy.RetryHooks,
+		ExcludeHooks: u.hookRetryStrategy.ExcludeHooks,
+	}
+	isRetriableHook := func(kind hooks.Kind) bool {
+		return hookRetryPolicy.Retriable(string(kind))
+	}
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -259,6 +283,44 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		retryHookTimer.Reset()
 	}()
 
+	deferredHookChan := make(chan struct{}, 1)
+	var deferredHookTimer clock.Timer
+	scheduleDeferredHook := func(at time.Time) {
+		if deferredHookTimer != nil {
+			deferredHookTimer.Stop()
+		}
+		deferredHookTimer = u.clock.AfterFunc(at.Sub(u.clock.Now()), func() {
+			select {
+			case deferredHookChan <- struct{}{}:
+			default:
+			}
+		})
+	}
+	defer func() {
+		if deferredHookTimer != nil {
+			deferredHookTimer.Stop()
+		}
+	}()
+
+	secretRotationChan := make(chan struct{}, 1)
+	var secretRotationTimer clock.Timer
+	scheduleSecretRotation := func(at time.Time) {
+		if secretRotationTimer != nil {
+			secretRotationTimer.Stop()
+		}
+		secretRotationTimer = u.clock.AfterFunc(at.Sub(u.clock.Now()), func() {
+			select {
+			case secretRotationChan <- struct{}{}:
+			default:
+			}
+		})
+	}
+	defer func() {
+		if secretRotationTimer != nil {
+			secretRotationTimer.Stop()
+		}
+	}()
+
 	restartWatcher := func() error {
 		watcherMu.Lock()
 		defer watcherMu.Unlock()
@@ -270,14 +332,17 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		var err error
 		watcher, err = remotestate.NewWatcher(
 			remotestate.WatcherConfig{
-				State:               remotestate.NewAPIState(u.st),
-				LeadershipTracker:   u.leadershipTracker,
-				UnitTag:             unitTag,
-				UpdateStatusChannel: u.updateStatusAt,
-				CommandChannel:      u.commandChannel,
-				RetryHookChannel:    retryHookChan,
-				ApplicationChannel:  u.applicationChannel,
-				ModelType:           u.modelType,
+				State:                 remotestate.NewAPIState(u.st),
+				LeadershipTracker:     u.leadershipTracker,
+				UnitTag:               unitTag,
+				UpdateStatusChannel:   u.updateStatusAt,
+				CommandChannel:        u.commandChannel,
+				RetryHookChannel:      retryHookChan,
+				DeferredHookChannel:   deferredHookChan,
+				HealthChannel:         u.healthChannel,
+				SecretRotationChannel: secretRotationChan,
+				ApplicationChannel:    u.applicationChannel,
+				ModelType:             u.modelType,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -289,6 +354,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	}
 
 	onIdle := func() error {
+		if err := u.startHealthProbes(); err != nil {
+			return errors.Trace(err)
+		}
 		opState := u.operationExecutor.State()
 		if opState.Kind != operation.Continue {
 			// We should only set idle status if we're in
@@ -315,17 +383,22 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 
 		cfg := ResolverConfig{
-			ModelType:           u.modelType,
-			ClearResolved:       clearResolved,
-			ReportHookError:     u.reportHookError,
-			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
-			StartRetryHookTimer: retryHookTimer.Start,
-			StopRetryHookTimer:  retryHookTimer.Reset,
-			Actions:             actions.NewResolver(),
-			UpgradeSeries:       upgradeseries.NewResolver(),
-			Leadership:          uniterleadership.NewResolver(),
-			Relations:           relation.NewRelationsResolver(u.relations),
-			Storage:             storage.NewResolver(u.storage, u.modelType),
+			ModelType:              u.modelType,
+			ClearResolved:          clearResolved,
+			ReportHookError:        u.reportHookError,
+			ShouldRetryHooks:       u.hookRetryStrategy.ShouldRetry,
+			MaxHookRetries:         u.hookRetryStrategy.MaxAttempts,
+			IsRetriableHook:        isRetriableHook,
+			StartRetryHookTimer:    retryHookTimer.Start,
+			StopRetryHookTimer:     retryHookTimer.Reset,
+			Clock:                  u.clock,
+			ScheduleDeferredHook:   scheduleDeferredHook,
+			ScheduleSecretRotation: scheduleSecretRotation,
+			Actions:                actions.NewResolver(),
+			UpgradeSeries:          upgradeseries.NewResolver(),
+			Leadership:             uniterleadership.NewResolver(),
+			Relations:              relation.NewRelationsResolver(u.relations),
+			Storage:                storage.NewResolver(u.storage, u.modelType),
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
@@ -527,6 +600,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		UnitTag:          unitTag,
 		Tracker:          u.leadershipTracker,
 		GetRelationInfos: u.relations.GetInfo,
+		GetHealth:        u.currentHealth,
 		Storage:          u.storage,
 		Paths:            u.paths,
 		Clock:            u.clock,
@@ -577,6 +651,22 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	}
 	u.operationExecutor = operationExecutor
 
+	// The controller's copy of the deferred events is only updated when
+	// they change, so report them in case it has missed a change. The
+	// local state remains authoritative, so a failure here is not fatal;
+	// the events are reported again when a hook next changes them.
+	if deferred := operationExecutor.State().Deferred; len(deferred) > 0 {
+		callbacks := &operationCallbacks{u}
+		if err := callbacks.SetDeferredEvents(deferred); err != nil {
+			logger.Warningf("cannot record deferred events: %v", err)
+		}
+	}
+
+	u.healthChannel = make(chan struct{}, 1)
+	if err := u.startHealthProbes(); err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Debugf("starting juju-run listener on unix:%s", u.paths.Runtime.JujuRunSocket)
 	commandRunner, err := NewChannelCommandRunner(ChannelCommandRunnerConfig{
 		Abort:          u.catacomb.Dying(),